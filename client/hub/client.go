@@ -7,11 +7,8 @@ package hub
 import (
 	"context"
 	"crypto/tls"
-	"crypto/x509"
-	"encoding/base64"
 	"net/http"
 	"os"
-	"strconv"
 
 	"github.com/pkg/errors"
 
@@ -145,12 +142,15 @@ func getTanzuHubEndpointFromContext(contextName string) (string, error) {
 	if err != nil {
 		return "", err
 	}
-
-	tanzuHubEndpoint := tzCtx.AdditionalMetadata[config.TanzuHubEndpointKey]
+	// Only the endpoint is validated, so that unrelated metadata does not prevent the use of the hub
+	tanzuHubEndpoint, err := config.TanzuContextMetadataValue(tzCtx, config.TanzuHubEndpointKey)
+	if err != nil {
+		return "", err
+	}
 	if tanzuHubEndpoint == "" {
 		return "", errors.Errorf("%q has not been configured for the %q context", config.TanzuHubEndpointKey, tzCtx.Name)
 	}
-	return tanzuHubEndpoint.(string), nil
+	return tanzuHubEndpoint, nil
 }
 
 func (c *hubClient) getTLSConfig() *tls.Config {
@@ -161,36 +161,12 @@ func (c *hubClient) getTLSConfig() *tls.Config {
 		return nil
 	}
 
-	// If CACertData is present use it
-	if certData.CACertData != "" {
-		var pool *x509.CertPool
-
-		decodedCACertData, err := base64.StdEncoding.DecodeString(certData.CACertData)
-		if err != nil {
-			log.Infof("unable to use custom cert for '%s' endpoint. Error: %s", c.tanzuHubEndpoint, err.Error())
-			return nil
-		}
-
-		pool, err = x509.SystemCertPool()
-		if err != nil || pool == nil {
-			pool = x509.NewCertPool()
-		}
-
-		if ok := pool.AppendCertsFromPEM(decodedCACertData); !ok {
-			log.Infof("unable to use custom cert for %s endpoint", c.tanzuHubEndpoint)
-			return nil
-		}
-		return &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
-	}
-
-	skipCertVerify, _ := strconv.ParseBool(certData.SkipCertVerify)
-	if skipCertVerify {
-		//nolint:gosec
-		// skipTLSVerify: true is only possible if the user has explicitly enabled it
-		return &tls.Config{InsecureSkipVerify: skipCertVerify, MinVersion: tls.VersionTLS12}
+	tlsConfig, err := config.TLSConfigForCert(certData)
+	if err != nil {
+		log.Infof("unable to use custom cert for '%s' endpoint. Error: %s", c.tanzuHubEndpoint, err.Error())
+		return nil
 	}
-
-	return nil
+	return tlsConfig
 }
 
 // Configure the auth Transport to include authorization token when invoking GraphQL requests
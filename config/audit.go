@@ -0,0 +1,414 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+)
+
+const (
+	// EnvConfigAuditFileKey is the environment variable that points to the config audit trail file.
+	EnvConfigAuditFileKey = "TANZU_CONFIG_AUDIT_FILE"
+
+	// ConfigAuditName is the name of the config audit trail file
+	ConfigAuditName = "config-audit.jsonl"
+	// LocalConfigAuditFileLock is the name of the lock file of the config audit trail, next to the audit trail file
+	LocalConfigAuditFileLock = ".config-audit.lock"
+	// DefaultConfigAuditLockTimeout is the default time waiting on the filelock of the config audit trail
+	DefaultConfigAuditLockTimeout = 10 * time.Minute
+
+	// DiffSectionPatchStrategy is the section of the audit records for the config metadata patch strategies
+	DiffSectionPatchStrategy DiffSection = "patchStrategy"
+	// DiffSectionSettings is the section of the audit records for the config metadata settings
+	DiffSectionSettings DiffSection = "settings"
+
+	configPackagePath = "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
+)
+
+var (
+	// auditMaxSize is the size in bytes above which the audit trail file is rotated
+	auditMaxSize int64 = 5 * 1024 * 1024
+	// auditMaxBackups is the number of rotated audit trail files that are retained
+	auditMaxBackups = 3
+
+	auditPluginName   string
+	auditPluginNameMu sync.RWMutex
+)
+
+// AuditRecord is a single mutation of the tanzu config recorded in the audit trail
+type AuditRecord struct {
+	// Timestamp of the mutation
+	Timestamp time.Time `json:"timestamp" yaml:"timestamp"`
+	// PID of the process that mutated the config
+	PID int `json:"pid" yaml:"pid"`
+	// Executable of the process that mutated the config
+	Executable string `json:"executable,omitempty" yaml:"executable,omitempty"`
+	// Plugin that mutated the config, if known
+	Plugin string `json:"plugin,omitempty" yaml:"plugin,omitempty"`
+	// Invocation is the CLI command invoked, if known
+	Invocation string `json:"invocation,omitempty" yaml:"invocation,omitempty"`
+	// API is the config API that mutated the config
+	API string `json:"api,omitempty" yaml:"api,omitempty"`
+	// Changes is the redacted semantic diff of the mutation
+	Changes []Change `json:"changes" yaml:"changes"`
+}
+
+// AuditQueryOptions are the options used to query the audit trail
+type AuditQueryOptions struct {
+	// From is the inclusive start of the time range, ignored if zero
+	From time.Time
+	// To is the inclusive end of the time range, ignored if zero
+	To time.Time
+	// Sections restricts the records and their changes to the sections, ignored if empty
+	Sections []DiffSection
+}
+
+// AuditQueryOpts is a function type that applies configuration options to AuditQueryOptions
+type AuditQueryOpts func(opts *AuditQueryOptions)
+
+// WithAuditTimeRange restricts the records to the mutations between from and to. A zero time leaves the range open.
+func WithAuditTimeRange(from, to time.Time) AuditQueryOpts {
+	return func(opts *AuditQueryOptions) {
+		opts.From = from
+		opts.To = to
+	}
+}
+
+// WithAuditSection restricts the records and their changes to the section. It can be specified multiple times.
+func WithAuditSection(section DiffSection) AuditQueryOpts {
+	return func(opts *AuditQueryOptions) {
+		opts.Sections = append(opts.Sections, section)
+	}
+}
+
+// SetAuditPluginName sets the name of the plugin recorded in the audit trail for the mutations of this process
+func SetAuditPluginName(name string) {
+	auditPluginNameMu.Lock()
+	defer auditPluginNameMu.Unlock()
+	auditPluginName = name
+}
+
+// AuditFilePath returns the path of the config audit trail file, checking for environment overrides.
+func AuditFilePath() (string, error) {
+	if path, ok := os.LookupEnv(EnvConfigAuditFileKey); ok && path != "" {
+		return path, nil
+	}
+	// The audit trail is a log, which the XDG layout keeps with the state rather than the config
+	if UseXDGLayout() {
+		stateDir, err := stateBaseDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(stateDir, ConfigAuditName), nil
+	}
+	localDir, err := LocalDir()
+	if err != nil {
+		return "", errors.Wrap(err, "could not find local tanzu dir for OS")
+	}
+	return filepath.Join(localDir, ConfigAuditName), nil
+}
+
+// QueryAuditTrail returns the records of the audit trail, including the rotated files, oldest first.
+// Lines of the audit trail that cannot be parsed are skipped.
+func QueryAuditTrail(opts ...AuditQueryOpts) ([]AuditRecord, error) {
+	options := &AuditQueryOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	path, err := AuditFilePath()
+	if err != nil {
+		return nil, err
+	}
+	records := make([]AuditRecord, 0)
+	for i := auditMaxBackups; i >= 0; i-- {
+		fileRecords, err := readAuditFile(auditBackupPath(path, i))
+		if err != nil {
+			return nil, err
+		}
+		for j := range fileRecords {
+			if record, ok := filterAuditRecord(&fileRecords[j], options); ok {
+				records = append(records, *record)
+			}
+		}
+	}
+	return records, nil
+}
+
+func readAuditFile(path string) ([]AuditRecord, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to open the config audit trail")
+	}
+	defer f.Close()
+
+	var records []AuditRecord
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), int(auditMaxSize)+1)
+	for scanner.Scan() {
+		var record AuditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			continue
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read the config audit trail")
+	}
+	return records, nil
+}
+
+func filterAuditRecord(record *AuditRecord, options *AuditQueryOptions) (*AuditRecord, bool) {
+	if !options.From.IsZero() && record.Timestamp.Before(options.From) {
+		return nil, false
+	}
+	if !options.To.IsZero() && record.Timestamp.After(options.To) {
+		return nil, false
+	}
+	if len(options.Sections) == 0 {
+		return record, true
+	}
+	var changes []Change
+	for _, change := range record.Changes {
+		for _, section := range options.Sections {
+			if change.Section == section {
+				changes = append(changes, change)
+				break
+			}
+		}
+	}
+	if len(changes) == 0 {
+		return nil, false
+	}
+	filtered := *record
+	filtered.Changes = changes
+	return &filtered, true
+}
+
+// auditClientConfig records the mutation of the client config from the old node to the new node.
+// Failures are logged and never fail the mutation.
+func auditClientConfig(oldNode, newNode *yaml.Node) {
+	if err := recordClientConfigMutation(oldNode, newNode); err != nil {
+		log.V(6).Infof("unable to record the config audit trail: %v", err)
+	}
+}
+
+func recordClientConfigMutation(oldNode, newNode *yaml.Node) error {
+	if oldNode == nil || newNode == nil {
+		return nil
+	}
+	oldCfg, err := convertNodeToClientConfig(oldNode)
+	if err != nil {
+		return err
+	}
+	newCfg, err := convertNodeToClientConfig(newNode)
+	if err != nil {
+		return err
+	}
+	d, err := Diff(oldCfg, newCfg)
+	if err != nil {
+		return err
+	}
+	return appendAuditRecord(d.Changes)
+}
+
+// auditMetadata records the mutation of the config metadata from the old node to the new node.
+// Failures are logged and never fail the mutation.
+func auditMetadata(oldNode, newNode *yaml.Node) {
+	if err := recordMetadataMutation(oldNode, newNode); err != nil {
+		log.V(6).Infof("unable to record the config audit trail: %v", err)
+	}
+}
+
+func recordMetadataMutation(oldNode, newNode *yaml.Node) error {
+	if oldNode == nil || newNode == nil {
+		return nil
+	}
+	oldMetadata, err := convertNodeToMetadata(oldNode)
+	if err != nil {
+		return err
+	}
+	newMetadata, err := convertNodeToMetadata(newNode)
+	if err != nil {
+		return err
+	}
+	oldPatchStrategy, oldSettings := map[string]string{}, map[string]string{}
+	if oldMetadata.ConfigMetadata != nil {
+		oldPatchStrategy, oldSettings = oldMetadata.ConfigMetadata.PatchStrategy, oldMetadata.ConfigMetadata.Settings
+	}
+	newPatchStrategy, newSettings := map[string]string{}, map[string]string{}
+	if newMetadata.ConfigMetadata != nil {
+		newPatchStrategy, newSettings = newMetadata.ConfigMetadata.PatchStrategy, newMetadata.ConfigMetadata.Settings
+	}
+	d := &ConfigDiff{Changes: make([]Change, 0)}
+	d.diffScalars(DiffSectionPatchStrategy, oldPatchStrategy, newPatchStrategy)
+	d.diffScalars(DiffSectionSettings, oldSettings, newSettings)
+	return appendAuditRecord(d.Changes)
+}
+
+// appendAuditRecord appends a record with the changes to the audit trail, rotating the file if required.
+// Nothing is recorded if there are no changes.
+func appendAuditRecord(changes []Change) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	path, err := AuditFilePath()
+	if err != nil {
+		return err
+	}
+	// Encode without html escaping to keep the redaction placeholders readable
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(newAuditRecord(changes)); err != nil {
+		return errors.Wrap(err, "failed to marshal the audit record")
+	}
+	data := buf.Bytes()
+
+	if err := os.MkdirAll(filepath.Dir(path), configDirMode); err != nil {
+		return errors.Wrap(err, "could not make the config audit trail directory")
+	}
+	// The config and the config metadata are mutated under different locks, so the audit trail has its own
+	// to serialize the rotations and appends
+	return withConfigAuditLock(path, func() error {
+		if err := rotateAuditFile(path, int64(len(data))); err != nil {
+			return err
+		}
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, configFileMode)
+		if err != nil {
+			return errors.Wrap(err, "failed to open the config audit trail")
+		}
+		defer f.Close()
+		if _, err := f.Write(data); err != nil {
+			return errors.Wrap(err, "failed to write the config audit trail")
+		}
+		return nil
+	})
+}
+
+// withConfigAuditLock runs the function while holding the lock of the config audit trail file
+func withConfigAuditLock(path string, f func() error) error {
+	lock, err := getFileLockWithTimeOut(filepath.Join(filepath.Dir(path), LocalConfigAuditFileLock), DefaultConfigAuditLockTimeout)
+	if lock != nil {
+		defer func() { _ = lock.Close() }()
+	}
+	if err != nil {
+		return errors.Wrap(err, "cannot acquire lock for the config audit trail")
+	}
+	return f()
+}
+
+func newAuditRecord(changes []Change) *AuditRecord {
+	record := &AuditRecord{
+		Timestamp: time.Now().UTC(),
+		PID:       os.Getpid(),
+		API:       callerConfigAPI(),
+		Changes:   changes,
+	}
+	if executable, err := os.Executable(); err == nil {
+		record.Executable = executable
+	}
+	auditPluginNameMu.RLock()
+	record.Plugin = auditPluginName
+	auditPluginNameMu.RUnlock()
+
+	// The invocation context is set by the CLI when invoking the plugin
+	invokedGroup := os.Getenv("TANZU_CLI_INVOKED_GROUP")
+	invokedCommand := os.Getenv("TANZU_CLI_INVOKED_COMMAND")
+	if record.Plugin == "" {
+		record.Plugin = invokedCommand
+	}
+	if invokedCommand != "" {
+		record.Invocation = strings.Join(strings.Fields(fmt.Sprintf("tanzu %s %s", invokedGroup, invokedCommand)), " ")
+	}
+	return record
+}
+
+// rotateAuditFile rotates the audit trail file if appending size bytes would exceed the maximum size
+func rotateAuditFile(path string, size int64) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Wrap(err, "failed to check the config audit trail")
+	}
+	if info.Size()+size <= auditMaxSize {
+		return nil
+	}
+	if err := os.Remove(auditBackupPath(path, auditMaxBackups)); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "failed to remove the oldest config audit trail")
+	}
+	for i := auditMaxBackups - 1; i >= 0; i-- {
+		if err := os.Rename(auditBackupPath(path, i), auditBackupPath(path, i+1)); err != nil && !os.IsNotExist(err) {
+			return errors.Wrap(err, "failed to rotate the config audit trail")
+		}
+	}
+	return nil
+}
+
+// auditBackupPath returns the path of the rotated audit trail file with the index, or the path itself for index 0
+func auditBackupPath(path string, index int) string {
+	if index == 0 {
+		return path
+	}
+	return fmt.Sprintf("%s.%d", path, index)
+}
+
+// callerConfigAPI returns the outermost exported function of the config package in the call stack
+func callerConfigAPI() string {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	api := ""
+	for {
+		frame, more := frames.Next()
+		if name, ok := configAPIName(frame.Function); ok {
+			api = name
+		}
+		if !more {
+			break
+		}
+	}
+	return api
+}
+
+// configAPIName returns the exported function or method name of the fully qualified function of the config package
+func configAPIName(function string) (string, bool) {
+	if !strings.HasPrefix(function, configPackagePath+".") {
+		return "", false
+	}
+	name := strings.TrimPrefix(function, configPackagePath+".")
+	// Methods are qualified as "(*Type).Method"
+	if strings.HasPrefix(name, "(") {
+		if i := strings.Index(name, ")."); i != -1 {
+			name = name[i+2:]
+		}
+	}
+	// Closures are qualified as "Function.func1"
+	if i := strings.Index(name, "."); i != -1 {
+		name = name[:i]
+	}
+	if name == "" || !unicode.IsUpper(rune(name[0])) || strings.HasPrefix(name, "Test") {
+		return "", false
+	}
+	return name, true
+}
@@ -0,0 +1,124 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"net"
+	"net/url"
+	"strings"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+// Match tiers of the cert entries, higher tier takes precedence
+const (
+	certMatchNone = iota
+	certMatchCIDR
+	certMatchWildcard
+	certMatchWildcardPort
+	certMatchHost
+	certMatchHostPort
+)
+
+// parseCertHost parses the host, host:port or URI into hostname and port
+func parseCertHost(host string) (hostname, port string, err error) {
+	if strings.Contains(host, "://") {
+		u, err := url.Parse(host)
+		if err != nil {
+			return "", "", err
+		}
+		if u.Hostname() != "" {
+			return u.Hostname(), u.Port(), nil
+		}
+	}
+	if h, p, err := net.SplitHostPort(host); err == nil {
+		return h, p, nil
+	}
+	return host, "", nil
+}
+
+func joinHostPort(hostname, port string) string {
+	if port == "" {
+		return hostname
+	}
+	return net.JoinHostPort(hostname, port)
+}
+
+// matchCert returns the cert entry that best matches the hostname and port.
+// The entries are matched in the following order of precedence:
+//  1. exact host:port match
+//  2. exact host match of an entry without port
+//  3. wildcard host:port match (e.g., *.example.com:443)
+//  4. wildcard host match of an entry without port (e.g., *.example.com)
+//  5. CIDR range match for IP addresses (e.g., 10.0.0.0/8)
+//
+// Within the same tier, the most specific wildcard or CIDR range wins.
+// A wildcard matches exactly one leading label of the hostname.
+func matchCert(certs []*configtypes.Cert, hostname, port string) *configtypes.Cert {
+	var best *configtypes.Cert
+	bestTier, bestSpecificity := certMatchNone, -1
+	for _, cert := range certs {
+		tier, specificity := matchCertHost(cert.Host, hostname, port)
+		if tier > bestTier || (tier == bestTier && tier != certMatchNone && specificity > bestSpecificity) {
+			best, bestTier, bestSpecificity = cert, tier, specificity
+		}
+	}
+	return best
+}
+
+// matchCertHost returns the match tier and the specificity of the cert entry host for the hostname and port
+func matchCertHost(entry, hostname, port string) (tier, specificity int) {
+	if entry == "" || hostname == "" {
+		return certMatchNone, 0
+	}
+	// Exact match with the entry as is, to stay compatible with entries that
+	// cannot be parsed as host or host:port
+	if strings.EqualFold(entry, joinHostPort(hostname, port)) {
+		if port == "" {
+			return certMatchHost, 0
+		}
+		return certMatchHostPort, 0
+	}
+
+	if strings.Contains(entry, "/") {
+		_, ipNet, err := net.ParseCIDR(entry)
+		ip := net.ParseIP(hostname)
+		if err != nil || ip == nil || !ipNet.Contains(ip) {
+			return certMatchNone, 0
+		}
+		ones, _ := ipNet.Mask.Size()
+		return certMatchCIDR, ones
+	}
+
+	entryHost, entryPort := entry, ""
+	if h, p, err := net.SplitHostPort(entry); err == nil {
+		entryHost, entryPort = h, p
+	}
+	if entryPort != "" && entryPort != port {
+		return certMatchNone, 0
+	}
+
+	if strings.HasPrefix(entryHost, "*.") {
+		suffix := entryHost[1:]
+		if !strings.HasSuffix(strings.ToLower(hostname), strings.ToLower(suffix)) {
+			return certMatchNone, 0
+		}
+		label := hostname[:len(hostname)-len(suffix)]
+		if label == "" || strings.Contains(label, ".") {
+			return certMatchNone, 0
+		}
+		if entryPort != "" {
+			return certMatchWildcardPort, len(suffix)
+		}
+		return certMatchWildcard, len(suffix)
+	}
+
+	if !strings.EqualFold(entryHost, hostname) {
+		return certMatchNone, 0
+	}
+	if entryPort != "" {
+		return certMatchHostPort, 0
+	}
+	return certMatchHost, 0
+}
@@ -0,0 +1,83 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+func TestMatchCert(t *testing.T) {
+	certs := []*configtypes.Cert{
+		{Host: "example.com"},
+		{Host: "example.com:8443"},
+		{Host: "*.example.com"},
+		{Host: "*.example.com:9443"},
+		{Host: "*.dev.example.com"},
+		{Host: "10.0.0.0/8"},
+		{Host: "10.1.0.0/16"},
+		{Host: "10.1.2.3:443"},
+		{Host: "[::1]:8443"},
+	}
+
+	tests := []struct {
+		name     string
+		hostname string
+		port     string
+		expected string
+	}{
+		{name: "exact host", hostname: "example.com", expected: "example.com"},
+		{name: "exact host is case insensitive", hostname: "EXAMPLE.com", expected: "example.com"},
+		{name: "exact host and port", hostname: "example.com", port: "8443", expected: "example.com:8443"},
+		{name: "host entry without port matches any port", hostname: "example.com", port: "443", expected: "example.com"},
+		{name: "wildcard host", hostname: "api.example.com", expected: "*.example.com"},
+		{name: "wildcard host and port", hostname: "api.example.com", port: "9443", expected: "*.example.com:9443"},
+		{name: "wildcard host with other port", hostname: "api.example.com", port: "443", expected: "*.example.com"},
+		{name: "most specific wildcard", hostname: "api.dev.example.com", expected: "*.dev.example.com"},
+		{name: "wildcard matches single label only", hostname: "a.b.test.example.com", expected: ""},
+		{name: "cidr", hostname: "10.2.3.4", expected: "10.0.0.0/8"},
+		{name: "most specific cidr", hostname: "10.1.3.4", expected: "10.1.0.0/16"},
+		{name: "ip and port takes precedence over cidr", hostname: "10.1.2.3", port: "443", expected: "10.1.2.3:443"},
+		{name: "ipv6 host and port", hostname: "::1", port: "8443", expected: "[::1]:8443"},
+		{name: "no match", hostname: "other.com", expected: ""},
+		{name: "no match for ip outside cidr", hostname: "192.168.1.1", expected: ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cert := matchCert(certs, tc.hostname, tc.port)
+			if tc.expected == "" {
+				assert.Nil(t, cert)
+				return
+			}
+			assert.NotNil(t, cert)
+			assert.Equal(t, tc.expected, cert.Host)
+		})
+	}
+}
+
+func TestParseCertHost(t *testing.T) {
+	tests := []struct {
+		host     string
+		hostname string
+		port     string
+	}{
+		{host: "example.com", hostname: "example.com"},
+		{host: "example.com:8443", hostname: "example.com", port: "8443"},
+		{host: "https://example.com", hostname: "example.com"},
+		{host: "https://example.com:8443/path", hostname: "example.com", port: "8443"},
+		{host: "10.0.0.1", hostname: "10.0.0.1"},
+		{host: "[::1]:443", hostname: "::1", port: "443"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.host, func(t *testing.T) {
+			hostname, port, err := parseCertHost(tc.host)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.hostname, hostname)
+			assert.Equal(t, tc.port, port)
+		})
+	}
+}
@@ -0,0 +1,330 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+const (
+	defaultTLSPort           = "443"
+	defaultCertDialTimeout   = 10 * time.Second
+	defaultCertExpiryWarning = 30 * 24 * time.Hour
+)
+
+// CertCheckStatus is the status of a stored cert reported by CheckCerts
+type CertCheckStatus string
+
+const (
+	// CertStatusExpired indicates the stored CA certificate has expired
+	CertStatusExpired CertCheckStatus = "expired"
+	// CertStatusExpiringSoon indicates the stored CA certificate expires within the configured threshold
+	CertStatusExpiringSoon CertCheckStatus = "expiring-soon"
+	// CertStatusServedExpired indicates a certificate of the chain served by the host has expired, while the chain
+	// is still signed by the stored CA certificate
+	CertStatusServedExpired CertCheckStatus = "served-expired"
+	// CertStatusChainMismatch indicates the certificate chain served by the host is not signed by the stored CA certificate
+	CertStatusChainMismatch CertCheckStatus = "chain-mismatch"
+	// CertStatusInvalid indicates the stored CA certificate data cannot be decoded or parsed
+	CertStatusInvalid CertCheckStatus = "invalid"
+	// CertStatusUnreachable indicates the host could not be reached to verify the served certificate chain
+	CertStatusUnreachable CertCheckStatus = "unreachable"
+)
+
+// CertCheckResult describes a problem found with a stored cert configuration
+type CertCheckResult struct {
+	// Host of the cert configuration
+	Host string `json:"host" yaml:"host"`
+	// Status of the cert configuration
+	Status CertCheckStatus `json:"status" yaml:"status"`
+	// Subject of the CA certificate, if applicable
+	Subject string `json:"subject,omitempty" yaml:"subject,omitempty"`
+	// Fingerprint is the SHA-256 fingerprint of the CA certificate, if applicable
+	Fingerprint string `json:"fingerprint,omitempty" yaml:"fingerprint,omitempty"`
+	// NotAfter is the expiry time of the CA certificate, if applicable
+	NotAfter time.Time `json:"notAfter,omitempty" yaml:"notAfter,omitempty"`
+	// Message describes the problem
+	Message string `json:"message" yaml:"message"`
+}
+
+// CertCheckOptions are the options used by CheckCerts and FetchServerCertChain
+type CertCheckOptions struct {
+	// ExpiryThreshold is the duration before expiry at which a CA certificate is reported as expiring soon
+	ExpiryThreshold time.Duration
+	// VerifyServedChain enables connecting to each host to verify the served chain matches the stored CA certificate
+	VerifyServedChain bool
+	// DialTimeout is the timeout used when connecting to the hosts
+	DialTimeout time.Duration
+	// Now is the time at which the certificates are checked
+	Now time.Time
+}
+
+// CertCheckOpts is a function type that applies configuration options to CertCheckOptions
+type CertCheckOpts func(opts *CertCheckOptions)
+
+// WithExpiryThreshold sets the duration before expiry at which a CA certificate is reported as expiring soon
+func WithExpiryThreshold(threshold time.Duration) CertCheckOpts {
+	return func(opts *CertCheckOptions) {
+		opts.ExpiryThreshold = threshold
+	}
+}
+
+// WithServedChainVerification enables connecting to each host to verify the served chain matches the stored CA certificate
+func WithServedChainVerification() CertCheckOpts {
+	return func(opts *CertCheckOptions) {
+		opts.VerifyServedChain = true
+	}
+}
+
+// WithDialTimeout sets the timeout used when connecting to the hosts
+func WithDialTimeout(timeout time.Duration) CertCheckOpts {
+	return func(opts *CertCheckOptions) {
+		opts.DialTimeout = timeout
+	}
+}
+
+// WithCheckTime sets the time at which the certificates are checked
+func WithCheckTime(now time.Time) CertCheckOpts {
+	return func(opts *CertCheckOptions) {
+		opts.Now = now
+	}
+}
+
+func newCertCheckOptions(opts ...CertCheckOpts) *CertCheckOptions {
+	options := &CertCheckOptions{
+		ExpiryThreshold: defaultCertExpiryWarning,
+		DialTimeout:     defaultCertDialTimeout,
+		Now:             time.Now(),
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
+
+// CertFingerprint returns the SHA-256 fingerprint of the certificate as colon separated hex
+func CertFingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	parts := make([]string, len(sum))
+	for i, b := range sum {
+		parts[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(parts, ":")
+}
+
+// FetchServerCertChain connects to the host, host:port or URI and returns the certificate chain served by it.
+// The served chain is not verified. If no port is specified, port 443 is used.
+func FetchServerCertChain(host string, opts ...CertCheckOpts) ([]*x509.Certificate, error) {
+	options := newCertCheckOptions(opts...)
+	hostname, port, err := parseCertHost(host)
+	if err != nil {
+		return nil, err
+	}
+	if hostname == "" {
+		return nil, errors.New("host is empty")
+	}
+	if port == "" {
+		port = defaultTLSPort
+	}
+	dialer := &net.Dialer{Timeout: options.DialTimeout}
+	// The chain is captured without verification so that it can be presented to the user for confirmation
+	conn, err := tls.DialWithDialer(dialer, "tcp", net.JoinHostPort(hostname, port), &tls.Config{
+		InsecureSkipVerify: true, //nolint:gosec
+		ServerName:         hostname,
+		MinVersion:         tls.VersionTLS12,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to connect to %q", joinHostPort(hostname, port))
+	}
+	defer conn.Close()
+	chain := conn.ConnectionState().PeerCertificates
+	if len(chain) == 0 {
+		return nil, errors.Errorf("no certificates served by %q", joinHostPort(hostname, port))
+	}
+	return chain, nil
+}
+
+// CertConfirmFunc is invoked by TrustOnFirstUse with the certificate chain served by the host
+// and the fingerprint of the certificate to be trusted. It returns true if the certificate should be trusted.
+type CertConfirmFunc func(chain []*x509.Certificate, fingerprint string) (bool, error)
+
+// TrustOnFirstUse connects to the host, host:port or URI and presents the served certificate chain to the confirm function.
+// On confirmation, the last certificate of the served chain (the self-signed certificate or the topmost CA served)
+// is stored as the CACertData of the cert configuration for the host through SetCert.
+// Returns the stored cert configuration, or nil if the certificate was not confirmed.
+func TrustOnFirstUse(host string, confirm CertConfirmFunc, opts ...CertCheckOpts) (*configtypes.Cert, error) {
+	if confirm == nil {
+		return nil, errors.New("confirm function cannot be nil")
+	}
+	chain, err := FetchServerCertChain(host, opts...)
+	if err != nil {
+		return nil, err
+	}
+	caCert := chain[len(chain)-1]
+	ok, err := confirm(chain, CertFingerprint(caCert))
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	hostname, port, err := parseCertHost(host)
+	if err != nil {
+		return nil, err
+	}
+	cert := &configtypes.Cert{
+		Host:       joinHostPort(hostname, port),
+		CACertData: base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caCert.Raw})),
+	}
+	if err := SetCert(cert); err != nil {
+		return nil, err
+	}
+	return cert, nil
+}
+
+// CheckCerts reports the stored CA certificates that are expired or expiring soon.
+// If served chain verification is enabled using WithServedChainVerification, it also reports the hosts
+// whose served certificate chain is no longer signed by the stored CA certificates, and separately the hosts
+// serving an expired certificate that is still signed by them.
+// Cert configurations without CACertData are skipped, and served chain verification is skipped for
+// wildcard and CIDR cert configurations.
+func CheckCerts(opts ...CertCheckOpts) ([]CertCheckResult, error) {
+	options := newCertCheckOptions(opts...)
+	certs, err := GetCerts()
+	if err != nil {
+		return nil, err
+	}
+	results := make([]CertCheckResult, 0)
+	for _, cert := range certs {
+		if cert.CACertData == "" {
+			continue
+		}
+		caCerts, err := parseCACertData(cert.CACertData)
+		if err != nil {
+			results = append(results, CertCheckResult{Host: cert.Host, Status: CertStatusInvalid, Message: err.Error()})
+			continue
+		}
+		results = append(results, checkCertExpiry(cert.Host, caCerts, options)...)
+		if options.VerifyServedChain && !strings.HasPrefix(cert.Host, "*.") && !strings.Contains(cert.Host, "/") {
+			if result := checkServedChain(cert.Host, caCerts, options); result != nil {
+				results = append(results, *result)
+			}
+		}
+	}
+	return results, nil
+}
+
+// parseCACertData decodes the base64 encoded PEM CA certificate data
+func parseCACertData(caCertData string) ([]*x509.Certificate, error) {
+	data, err := base64.StdEncoding.DecodeString(caCertData)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to decode the CA certificate data")
+	}
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to parse the CA certificate data")
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("no certificates found in the CA certificate data")
+	}
+	return certs, nil
+}
+
+func checkCertExpiry(host string, caCerts []*x509.Certificate, options *CertCheckOptions) []CertCheckResult {
+	var results []CertCheckResult
+	for _, caCert := range caCerts {
+		result := CertCheckResult{
+			Host:        host,
+			Subject:     caCert.Subject.String(),
+			Fingerprint: CertFingerprint(caCert),
+			NotAfter:    caCert.NotAfter,
+		}
+		switch {
+		case options.Now.After(caCert.NotAfter):
+			result.Status = CertStatusExpired
+			result.Message = fmt.Sprintf("CA certificate expired on %s", caCert.NotAfter.Format(time.RFC3339))
+		case options.Now.Add(options.ExpiryThreshold).After(caCert.NotAfter):
+			result.Status = CertStatusExpiringSoon
+			result.Message = fmt.Sprintf("CA certificate expires on %s", caCert.NotAfter.Format(time.RFC3339))
+		default:
+			continue
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func checkServedChain(host string, caCerts []*x509.Certificate, options *CertCheckOptions) *CertCheckResult {
+	chain, err := FetchServerCertChain(host, WithDialTimeout(options.DialTimeout))
+	if err != nil {
+		return &CertCheckResult{Host: host, Status: CertStatusUnreachable, Message: err.Error()}
+	}
+	roots := x509.NewCertPool()
+	for _, caCert := range caCerts {
+		roots.AddCert(caCert)
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range chain[1:] {
+		intermediates.AddCert(cert)
+	}
+	// The expiry of a certificate is reported separately from a mismatch of the chain, which is verified at
+	// the time the earliest expired certificate was last valid
+	verifyTime := options.Now
+	for _, cert := range append(append([]*x509.Certificate{}, chain...), caCerts...) {
+		if cert.NotAfter.Before(verifyTime) {
+			verifyTime = cert.NotAfter
+		}
+	}
+	_, err = chain[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   verifyTime,
+	})
+	if err != nil {
+		return &CertCheckResult{
+			Host:        host,
+			Status:      CertStatusChainMismatch,
+			Subject:     chain[0].Subject.String(),
+			Fingerprint: CertFingerprint(chain[len(chain)-1]),
+			NotAfter:    chain[0].NotAfter,
+			Message:     fmt.Sprintf("served certificate chain does not match the stored CA certificate: %v", err),
+		}
+	}
+	// The expiry of the stored CA certificates is reported by checkCertExpiry
+	for _, cert := range chain {
+		if options.Now.After(cert.NotAfter) {
+			return &CertCheckResult{
+				Host:        host,
+				Status:      CertStatusServedExpired,
+				Subject:     cert.Subject.String(),
+				Fingerprint: CertFingerprint(cert),
+				NotAfter:    cert.NotAfter,
+				Message:     fmt.Sprintf("served certificate expired on %s", cert.NotAfter.Format(time.RFC3339)),
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+func TestTrustOnFirstUse(t *testing.T) {
+	// Setup config data
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+
+	defer func() {
+		cleanUp()
+	}()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	host := server.Listener.Addr().String()
+
+	// Declined certificate is not stored
+	var shownFingerprint string
+	cert, err := TrustOnFirstUse(server.URL, func(chain []*x509.Certificate, fingerprint string) (bool, error) {
+		assert.NotEmpty(t, chain)
+		shownFingerprint = fingerprint
+		return false, nil
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, cert)
+	assert.Equal(t, CertFingerprint(server.Certificate()), shownFingerprint)
+	exists, _ := CertExists(host)
+	assert.False(t, exists)
+
+	// Error from the confirm function is returned
+	_, err = TrustOnFirstUse(server.URL, func(chain []*x509.Certificate, fingerprint string) (bool, error) {
+		return false, errors.New("prompt failed")
+	})
+	assert.EqualError(t, err, "prompt failed")
+
+	// Confirmed certificate is stored
+	cert, err = TrustOnFirstUse(server.URL, func(chain []*x509.Certificate, fingerprint string) (bool, error) {
+		return true, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, host, cert.Host)
+	storedCert, err := GetCert(host)
+	assert.NoError(t, err)
+	assert.Equal(t, cert.CACertData, storedCert.CACertData)
+
+	// Stored certificate can be used to connect to the host
+	tlsConfig, err := TLSConfigForHost(server.URL)
+	assert.NoError(t, err)
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+	resp, err := client.Get(server.URL)
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	_, err = TrustOnFirstUse("127.0.0.1:1", func(chain []*x509.Certificate, fingerprint string) (bool, error) {
+		return true, nil
+	}, WithDialTimeout(time.Second))
+	assert.ErrorContains(t, err, "unable to connect to \"127.0.0.1:1\"")
+}
+
+func TestCheckCerts(t *testing.T) {
+	// Setup config data
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+
+	defer func() {
+		cleanUp()
+	}()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	host := server.Listener.Addr().String()
+
+	_, err := TrustOnFirstUse(server.URL, func(chain []*x509.Certificate, fingerprint string) (bool, error) {
+		return true, nil
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, SetCert(&configtypes.Cert{Host: "insecure.example.com", SkipCertVerify: "true"}))
+
+	// No problems with the stored certificates
+	results, err := CheckCerts(WithServedChainVerification())
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+
+	// Expiring soon
+	notAfter := server.Certificate().NotAfter
+	results, err = CheckCerts(WithCheckTime(notAfter.Add(-24 * time.Hour)))
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, CertStatusExpiringSoon, results[0].Status)
+	assert.Equal(t, host, results[0].Host)
+	assert.Equal(t, CertFingerprint(server.Certificate()), results[0].Fingerprint)
+
+	results, err = CheckCerts(WithCheckTime(notAfter.Add(-24*time.Hour)), WithExpiryThreshold(time.Hour))
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+
+	// Expired
+	results, err = CheckCerts(WithCheckTime(notAfter.Add(time.Hour)))
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, CertStatusExpired, results[0].Status)
+
+	// An expired but unchanged served certificate is reported as expired rather than as a chain mismatch
+	results, err = CheckCerts(WithServedChainVerification(), WithCheckTime(notAfter.Add(time.Hour)))
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, CertStatusExpired, results[0].Status)
+	assert.Equal(t, CertStatusServedExpired, results[1].Status)
+	assert.Equal(t, host, results[1].Host)
+	assert.Equal(t, CertFingerprint(server.Certificate()), results[1].Fingerprint)
+	assert.Equal(t, notAfter, results[1].NotAfter)
+
+	// Served chain no longer matches the stored certificate
+	otherCertPEM, _ := generateTestClientCert(t)
+	assert.NoError(t, SetCert(&configtypes.Cert{Host: host, CACertData: base64.StdEncoding.EncodeToString(otherCertPEM)}))
+	results, err = CheckCerts(WithServedChainVerification(), WithExpiryThreshold(time.Minute))
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, CertStatusChainMismatch, results[0].Status)
+
+	// Invalid and unreachable
+	assert.NoError(t, SetCert(&configtypes.Cert{Host: "invalid.example.com", CACertData: "invalid"}))
+	assert.NoError(t, SetCert(&configtypes.Cert{Host: "127.0.0.1:1", CACertData: base64.StdEncoding.EncodeToString(otherCertPEM)}))
+	results, err = CheckCerts(WithServedChainVerification(), WithDialTimeout(time.Second), WithExpiryThreshold(time.Minute))
+	assert.NoError(t, err)
+	statuses := map[string]CertCheckStatus{}
+	for _, result := range results {
+		statuses[result.Host] = result.Status
+	}
+	assert.Equal(t, map[string]CertCheckStatus{
+		host:                  CertStatusChainMismatch,
+		"invalid.example.com": CertStatusInvalid,
+		"127.0.0.1:1":         CertStatusUnreachable,
+	}, statuses)
+}
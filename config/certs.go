@@ -5,7 +5,6 @@ package config
 
 import (
 	"fmt"
-	"net/url"
 
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v3"
@@ -31,20 +30,35 @@ func GetCert(host string) (*configtypes.Cert, error) {
 	if host == "" {
 		return nil, errors.New("host is empty")
 	}
-	u, err := url.Parse(host)
+	cert, err := getMatchingCert(host)
 	if err != nil {
 		return nil, err
 	}
-	if u.Hostname() != "" {
-		host = u.Hostname()
+	if cert == nil {
+		hostname, port, _ := parseCertHost(host)
+		return nil, fmt.Errorf("cert configuration for %v not found", joinHostPort(hostname, port))
 	}
+	return cert, nil
+}
 
-	// Retrieve client config node
-	node, err := getClientConfigNode()
+// getMatchingCert retrieves the cert configuration that best matches the host or URI.
+// Returns nil if there is no matching cert configuration.
+func getMatchingCert(host string) (*configtypes.Cert, error) {
+	hostname, port, err := parseCertHost(host)
+	if err != nil {
+		return nil, err
+	}
+
+	// Retrieve the client config, which is cached while the config files are unchanged
+	cfg, err := readClientConfig()
 	if err != nil {
 		return nil, err
 	}
-	return getCert(node, host)
+	cert := matchCert(cfg.Certs, hostname, port)
+	if cert == nil {
+		return nil, nil
+	}
+	return deepCopy(cert), nil
 }
 
 // SetCert add or update cert configuration
@@ -55,6 +69,9 @@ func SetCert(c *configtypes.Cert) error {
 	if c.Host == "" {
 		return errors.New("host is empty")
 	}
+	if err := checkSystemConfigLock(DiffSectionCerts, c.Host); err != nil {
+		return err
+	}
 	// Retrieve client config node
 	AcquireTanzuConfigLock()
 	defer ReleaseTanzuConfigLock()
@@ -81,6 +98,9 @@ func DeleteCert(host string) error {
 	if host == "" {
 		return errors.New("host is empty")
 	}
+	if err := checkSystemConfigLock(DiffSectionCerts, host); err != nil {
+		return err
+	}
 	// Retrieve client config node
 	AcquireTanzuConfigLock()
 	defer ReleaseTanzuConfigLock()
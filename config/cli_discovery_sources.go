@@ -5,6 +5,8 @@ package config
 
 import (
 	"fmt"
+	"reflect"
+	"strconv"
 
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v3"
@@ -36,7 +38,13 @@ func GetCLIDiscoverySource(name string) (*configtypes.PluginDiscovery, error) {
 }
 
 // SetCLIDiscoverySources Add/Update array of cli discovery sources to the yaml node
+// Added or changed discovery sources that are invalid are rejected as per ValidateDiscoverySource
 func SetCLIDiscoverySources(discoverySources []configtypes.PluginDiscovery) (err error) {
+	for _, discoverySource := range discoverySources {
+		if err := checkDiscoverySourceSystemConfigLock(discoverySource); err != nil {
+			return err
+		}
+	}
 	// Retrieve client config node
 	AcquireTanzuConfigLock()
 	defer ReleaseTanzuConfigLock()
@@ -44,6 +52,11 @@ func SetCLIDiscoverySources(discoverySources []configtypes.PluginDiscovery) (err
 	if err != nil {
 		return err
 	}
+	for _, discoverySource := range discoverySources {
+		if err := validateChangedDiscoverySource(node, discoverySource); err != nil {
+			return err
+		}
+	}
 
 	// Loop through each discovery source and add or update existing node
 	for _, discoverySource := range discoverySources {
@@ -63,7 +76,11 @@ func SetCLIDiscoverySources(discoverySources []configtypes.PluginDiscovery) (err
 }
 
 // SetCLIDiscoverySource add or update a cli discoverySource
+// An added or changed discovery source that is invalid is rejected as per ValidateDiscoverySource
 func SetCLIDiscoverySource(discoverySource configtypes.PluginDiscovery) (err error) {
+	if err := checkDiscoverySourceSystemConfigLock(discoverySource); err != nil {
+		return err
+	}
 	// Retrieve client config node
 	AcquireTanzuConfigLock()
 	defer ReleaseTanzuConfigLock()
@@ -71,6 +88,9 @@ func SetCLIDiscoverySource(discoverySource configtypes.PluginDiscovery) (err err
 	if err != nil {
 		return err
 	}
+	if err := validateChangedDiscoverySource(node, discoverySource); err != nil {
+		return err
+	}
 
 	// Add/Update cli discovery source in the yaml node
 	persist, err := setCLIDiscoverySource(node, discoverySource)
@@ -88,6 +108,9 @@ func SetCLIDiscoverySource(discoverySource configtypes.PluginDiscovery) (err err
 
 // DeleteCLIDiscoverySource delete cli discoverySource by name
 func DeleteCLIDiscoverySource(name string) error {
+	if err := checkSystemConfigLock(DiffSectionDiscoverySources, name); err != nil {
+		return err
+	}
 	// Retrieve client config node
 	AcquireTanzuConfigLock()
 	defer ReleaseTanzuConfigLock()
@@ -117,6 +140,19 @@ func getCLIDiscoverySources(node *yaml.Node) ([]configtypes.PluginDiscovery, err
 	return nil, errors.New("cli discovery sources not found")
 }
 
+// validateChangedDiscoverySource validates the discovery source unless the config already holds it unchanged,
+// so that the sources written before they were validated do not prevent writing them back
+func validateChangedDiscoverySource(node *yaml.Node, discoverySource configtypes.PluginDiscovery) error {
+	_, name, err := getDiscoverySourceTypeAndName(discoverySource)
+	if err != nil {
+		return err
+	}
+	if existing, err := getCLIDiscoverySource(node, name); err == nil && reflect.DeepEqual(*existing, discoverySource) {
+		return nil
+	}
+	return ValidateDiscoverySource(discoverySource)
+}
+
 func getCLIDiscoverySource(node *yaml.Node, name string) (*configtypes.PluginDiscovery, error) {
 	// check if context name is empty
 	if name == "" {
@@ -201,3 +237,106 @@ func deleteCLIDiscoverySource(node *yaml.Node, name string) error {
 	cliDiscoverySourcesNode.Content = result
 	return nil
 }
+
+// EnableCLIDiscoverySource enables the cli discovery source by name
+func EnableCLIDiscoverySource(name string) error {
+	return updateCLIDiscoverySourceNode(name, func(discoverySourceNode *yaml.Node) bool {
+		return removeScalarValue(discoverySourceNode, KeyDisabled)
+	})
+}
+
+// DisableCLIDiscoverySource disables the cli discovery source by name.
+// A disabled discovery source is retained in the config but excluded from the effective discovery sources.
+func DisableCLIDiscoverySource(name string) error {
+	return updateCLIDiscoverySourceNode(name, func(discoverySourceNode *yaml.Node) bool {
+		return setScalarValue(discoverySourceNode, KeyDisabled, "true", "!!bool")
+	})
+}
+
+// SetCLIDiscoverySourcePriority sets the priority of the cli discovery source by name.
+// Discovery sources with a higher priority are ordered first in the effective discovery sources.
+func SetCLIDiscoverySourcePriority(name string, priority int) error {
+	return updateCLIDiscoverySourceNode(name, func(discoverySourceNode *yaml.Node) bool {
+		if priority == 0 {
+			return removeScalarValue(discoverySourceNode, KeyPriority)
+		}
+		return setScalarValue(discoverySourceNode, KeyPriority, strconv.Itoa(priority), "!!int")
+	})
+}
+
+// updateCLIDiscoverySourceNode applies the update to the cli discovery source node matched by name
+// and persists the config if the update modified the node
+func updateCLIDiscoverySourceNode(name string, update func(discoverySourceNode *yaml.Node) bool) error {
+	if name == "" {
+		return errors.New("discovery source name cannot be empty")
+	}
+	if err := checkSystemConfigLock(DiffSectionDiscoverySources, name); err != nil {
+		return err
+	}
+	// Retrieve client config node
+	AcquireTanzuConfigLock()
+	defer ReleaseTanzuConfigLock()
+	node, err := getClientConfigNodeNoLock()
+	if err != nil {
+		return err
+	}
+
+	keys := []nodeutils.Key{
+		{Name: KeyCLI},
+		{Name: KeyDiscoverySources},
+	}
+	cliDiscoverySourcesNode := nodeutils.FindNode(node.Content[0], nodeutils.WithKeys(keys))
+	if cliDiscoverySourcesNode == nil {
+		return errors.New("cli discovery source not found")
+	}
+	for _, discoverySourceNode := range cliDiscoverySourcesNode.Content {
+		discoverySourceType, discoverySourceIndex := findDiscoverySourceTypeAndIndexByWeakMatch(discoverySourceNode.Content)
+		if discoverySourceType == "" {
+			continue
+		}
+		nameIndex := nodeutils.GetNodeIndex(discoverySourceNode.Content[discoverySourceIndex].Content, "name")
+		if nameIndex == -1 || discoverySourceNode.Content[discoverySourceIndex].Content[nameIndex].Value != name {
+			continue
+		}
+		if update(discoverySourceNode) {
+			return persistConfig(node)
+		}
+		return nil
+	}
+	return errors.New("cli discovery source not found")
+}
+
+// setScalarValue adds or updates the scalar value of the key in the mapping node and returns true if the node is modified
+func setScalarValue(mappingNode *yaml.Node, key, value, tag string) bool {
+	if idx := nodeutils.GetNodeIndex(mappingNode.Content, key); idx != -1 {
+		if mappingNode.Content[idx].Value == value {
+			return false
+		}
+		mappingNode.Content[idx].Value = value
+		mappingNode.Content[idx].Tag = tag
+		return true
+	}
+	scalarNodes := nodeutils.CreateScalarNode(key, value)
+	scalarNodes[1].Tag = tag
+	mappingNode.Content = append(mappingNode.Content, scalarNodes...)
+	return true
+}
+
+// removeScalarValue removes the key from the mapping node and returns true if the node is modified
+func removeScalarValue(mappingNode *yaml.Node, key string) bool {
+	idx := nodeutils.GetNodeIndex(mappingNode.Content, key)
+	if idx == -1 {
+		return false
+	}
+	mappingNode.Content = append(mappingNode.Content[:idx-1], mappingNode.Content[idx+1:]...)
+	return true
+}
+
+// checkDiscoverySourceSystemConfigLock returns an error if the discovery source is locked by the system config
+func checkDiscoverySourceSystemConfigLock(discoverySource configtypes.PluginDiscovery) error {
+	_, name, err := getDiscoverySourceTypeAndName(discoverySource)
+	if err != nil {
+		return nil
+	}
+	return checkSystemConfigLock(DiffSectionDiscoverySources, name)
+}
@@ -172,7 +172,7 @@ func TestSetCLIDiscoverySources(t *testing.T) {
 				{
 					OCI: &configtypes.OCIDiscovery{
 						Name:  "test",
-						Image: "updatedImage",
+						Image: "updated-image",
 					},
 				},
 			},
@@ -184,7 +184,7 @@ func TestSetCLIDiscoverySources(t *testing.T) {
 				{
 					OCI: &configtypes.OCIDiscovery{
 						Name:  "test",
-						Image: "updatedImage",
+						Image: "updated-image",
 					},
 				},
 			},
@@ -208,7 +208,7 @@ func TestSetCLIDiscoverySources(t *testing.T) {
 				{
 					OCI: &configtypes.OCIDiscovery{
 						Name:  "default-local",
-						Image: "localImage",
+						Image: "local-image",
 					},
 				},
 			},
@@ -524,7 +524,7 @@ func TestSetCLIDiscoverySourceWithDefaultAndDefaultLocal(t *testing.T) {
 				{
 					OCI: &configtypes.OCIDiscovery{
 						Name:  "default",
-						Image: "updatedImage",
+						Image: "updated-image",
 					},
 				},
 			},
@@ -537,7 +537,7 @@ func TestSetCLIDiscoverySourceWithDefaultAndDefaultLocal(t *testing.T) {
 				{
 					OCI: &configtypes.OCIDiscovery{
 						Name:  "default-local",
-						Image: "updatedImage",
+						Image: "updated-image",
 					},
 				},
 			},
@@ -550,7 +550,7 @@ func TestSetCLIDiscoverySourceWithDefaultAndDefaultLocal(t *testing.T) {
 				{
 					OCI: &configtypes.OCIDiscovery{
 						Name:  "default",
-						Image: "updatedImage",
+						Image: "updated-image",
 					},
 				},
 				{
@@ -568,13 +568,13 @@ func TestSetCLIDiscoverySourceWithDefaultAndDefaultLocal(t *testing.T) {
 				{
 					OCI: &configtypes.OCIDiscovery{
 						Name:  "default",
-						Image: "updatedImage2",
+						Image: "updated-image2",
 					},
 				},
 				{
 					OCI: &configtypes.OCIDiscovery{
 						Name:  "test-oci1",
-						Image: "updatedImage",
+						Image: "updated-image",
 					},
 				},
 				{
@@ -629,7 +629,7 @@ func TestSetCLIDiscoverySourceMultiTypes(t *testing.T) {
 				{
 					OCI: &configtypes.OCIDiscovery{
 						Name:  "default",
-						Image: "defaultImage",
+						Image: "default-image",
 					},
 				},
 				{
@@ -647,13 +647,13 @@ func TestSetCLIDiscoverySourceMultiTypes(t *testing.T) {
 				{
 					OCI: &configtypes.OCIDiscovery{
 						Name:  "default",
-						Image: "defaultImage2",
+						Image: "default-image2",
 					},
 				},
 				{
 					OCI: &configtypes.OCIDiscovery{
 						Name:  "test-oci1",
-						Image: "updatedImage",
+						Image: "updated-image",
 					},
 				},
 				{
@@ -671,7 +671,7 @@ func TestSetCLIDiscoverySourceMultiTypes(t *testing.T) {
 				{
 					OCI: &configtypes.OCIDiscovery{
 						Name:  "test-oci2",
-						Image: "updatedImage",
+						Image: "updated-image",
 					},
 				},
 			},
@@ -13,6 +13,14 @@ import (
 	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
 )
 
+// Repository type constants
+const (
+	RepositoryTypeGCP   = "gcpPluginRepository"
+	RepositoryTypeOCI   = "ociPluginRepository"
+	RepositoryTypeLocal = "localPluginRepository"
+	RepositoryTypeHTTP  = "httpPluginRepository"
+)
+
 // GetCLIRepositories retrieves cli repositories
 //
 // Deprecated: This API is deprecated
@@ -177,10 +185,8 @@ func deleteCLIRepository(node *yaml.Node, name string) error {
 	}
 	var result []*yaml.Node
 	for _, repositoryNode := range cliRepositoriesNode.Content {
-		if repositoryIndex := nodeutils.GetNodeIndex(repositoryNode.Content, repositoryType); repositoryIndex != -1 {
-			if repositoryFieldIndex := nodeutils.GetNodeIndex(repositoryNode.Content[repositoryIndex].Content, "name"); repositoryFieldIndex != -1 && repositoryNode.Content[repositoryIndex].Content[repositoryFieldIndex].Value == repositoryName {
-				continue
-			}
+		if _, sameName := isRepositoryNodeOfName(repositoryNode, repositoryName); sameName {
+			continue
 		}
 		result = append(result, repositoryNode)
 	}
@@ -199,32 +205,35 @@ func setRepository(repositoriesNode *yaml.Node, repository configtypes.PluginRep
 	exists := false
 	var result []*yaml.Node
 
-	repositoryType, repositoryName := getRepositoryTypeAndName(repository)
-	if repositoryType == "" || repositoryName == "" {
-		return persist, errors.New("not found")
+	if err = validateRepository(repository); err != nil {
+		return persist, err
 	}
+	repositoryType, repositoryName := getRepositoryTypeAndName(repository)
 
 	// loop through the repositories seqence node
 	for _, repositoryNode := range repositoriesNode.Content {
-		// find the repository matching by repository type
-		if repositoryIndex := nodeutils.GetNodeIndex(repositoryNode.Content, repositoryType); repositoryIndex != -1 {
-			// find the repository matching by name
-			if repositoryFieldIndex := nodeutils.GetNodeIndex(repositoryNode.Content[repositoryIndex].Content, "name"); repositoryFieldIndex != -1 &&
-				repositoryNode.Content[repositoryIndex].Content[repositoryFieldIndex].Value == repositoryName {
-				exists = true
-				// delete nodes specified in the patch strategy
-				_, err = nodeutils.DeleteNodes(newNode.Content[0], repositoryNode, patchStrategyOpts...)
-				if err != nil {
-					return false, err
-				}
-				// merge the new node into repository node
-				persist, err = nodeutils.MergeNodes(newNode.Content[0], repositoryNode)
-				if err != nil {
-					return false, err
-				}
-				result = append(result, repositoryNode)
-				continue
-			}
+		// find the repository matching by name irrespective of the repository type
+		existingRepositoryType, sameName := isRepositoryNodeOfName(repositoryNode, repositoryName)
+		if !sameName {
+			result = append(result, repositoryNode)
+			continue
+		}
+		exists = true
+		// repository of a different type with the same name is replaced
+		if existingRepositoryType != repositoryType {
+			result = append(result, newNode.Content[0])
+			persist = true
+			continue
+		}
+		// delete nodes specified in the patch strategy
+		_, err = nodeutils.DeleteNodes(newNode.Content[0], repositoryNode, patchStrategyOpts...)
+		if err != nil {
+			return false, err
+		}
+		// merge the new node into repository node
+		persist, err = nodeutils.MergeNodes(newNode.Content[0], repositoryNode)
+		if err != nil {
+			return false, err
 		}
 		result = append(result, repositoryNode)
 	}
@@ -239,8 +248,72 @@ func setRepository(repositoriesNode *yaml.Node, repository configtypes.PluginRep
 
 // Deprecated: This method is deprecated
 func getRepositoryTypeAndName(repository configtypes.PluginRepository) (string, string) {
-	if repository.GCPPluginRepository != nil && repository.GCPPluginRepository.Name != "" {
-		return "gcpPluginRepository", repository.GCPPluginRepository.Name
+	switch {
+	case repository.GCPPluginRepository != nil && repository.GCPPluginRepository.Name != "":
+		return RepositoryTypeGCP, repository.GCPPluginRepository.Name
+	case repository.OCIPluginRepository != nil && repository.OCIPluginRepository.Name != "":
+		return RepositoryTypeOCI, repository.OCIPluginRepository.Name
+	case repository.LocalPluginRepository != nil && repository.LocalPluginRepository.Name != "":
+		return RepositoryTypeLocal, repository.LocalPluginRepository.Name
+	case repository.HTTPPluginRepository != nil && repository.HTTPPluginRepository.Name != "":
+		return RepositoryTypeHTTP, repository.HTTPPluginRepository.Name
 	}
 	return "", ""
 }
+
+// validateRepository validates that exactly one repository type is configured with the required fields
+func validateRepository(repository configtypes.PluginRepository) error {
+	var configured int
+	for _, isSet := range []bool{
+		repository.GCPPluginRepository != nil,
+		repository.OCIPluginRepository != nil,
+		repository.LocalPluginRepository != nil,
+		repository.HTTPPluginRepository != nil,
+	} {
+		if isSet {
+			configured++
+		}
+	}
+	if configured == 0 {
+		return errors.New("repository type cannot be empty")
+	}
+	if configured > 1 {
+		return errors.New("only one repository type can be configured")
+	}
+	if _, name := getRepositoryTypeAndName(repository); name == "" {
+		return errors.New("repository name cannot be empty")
+	}
+
+	switch {
+	case repository.OCIPluginRepository != nil:
+		return errors.Wrap(validateOCIImageRef(repository.OCIPluginRepository.Image), "invalid oci repository")
+	case repository.LocalPluginRepository != nil:
+		if repository.LocalPluginRepository.Path == "" {
+			return errors.New("invalid local repository: path cannot be empty")
+		}
+	case repository.HTTPPluginRepository != nil:
+		return errors.Wrap(validateHTTPURL(repository.HTTPPluginRepository.URL), "invalid http repository")
+	}
+	return nil
+}
+
+// Find the matching repository type and index from accepted repository types
+func findRepositoryTypeAndIndexByWeakMatch(repositoryContentNodes []*yaml.Node) (string, int) {
+	for _, repositoryType := range []string{RepositoryTypeGCP, RepositoryTypeOCI, RepositoryTypeLocal, RepositoryTypeHTTP} {
+		idx := nodeutils.GetNodeIndex(repositoryContentNodes, repositoryType)
+		if idx != -1 {
+			return repositoryType, idx
+		}
+	}
+	return "", -1
+}
+
+// isRepositoryNodeOfName returns the repository type of the repository node if it is named as specified
+func isRepositoryNodeOfName(repositoryNode *yaml.Node, name string) (string, bool) {
+	repositoryType, repositoryIndex := findRepositoryTypeAndIndexByWeakMatch(repositoryNode.Content)
+	if repositoryIndex == -1 {
+		return "", false
+	}
+	nameIndex := nodeutils.GetNodeIndex(repositoryNode.Content[repositoryIndex].Content, "name")
+	return repositoryType, nameIndex != -1 && repositoryNode.Content[repositoryIndex].Content[nameIndex].Value == name
+}
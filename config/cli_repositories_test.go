@@ -89,3 +89,138 @@ func TestSetGetRepository(t *testing.T) {
 		})
 	}
 }
+
+func TestSetRepositoryTypes(t *testing.T) {
+	// Setup config test data
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+
+	defer func() {
+		cleanUp()
+	}()
+
+	tests := []struct {
+		name   string
+		in     configtypes.PluginRepository
+		errStr string
+	}{
+		{
+			name: "should persist oci repository",
+			in: configtypes.PluginRepository{
+				OCIPluginRepository: &configtypes.OCIPluginRepository{Name: "oci-repo", Image: "example.com:5000/tanzu/plugins:v1.0.0"},
+			},
+		},
+		{
+			name: "should persist local repository",
+			in: configtypes.PluginRepository{
+				LocalPluginRepository: &configtypes.LocalPluginRepository{Name: "local-repo", Path: "/tmp/plugins"},
+			},
+		},
+		{
+			name: "should persist http repository",
+			in: configtypes.PluginRepository{
+				HTTPPluginRepository: &configtypes.HTTPPluginRepository{Name: "http-repo", URL: "https://example.com/plugins"},
+			},
+		},
+		{
+			name:   "should fail when no repository type is set",
+			in:     configtypes.PluginRepository{},
+			errStr: "repository type cannot be empty",
+		},
+		{
+			name: "should fail when multiple repository types are set",
+			in: configtypes.PluginRepository{
+				LocalPluginRepository: &configtypes.LocalPluginRepository{Name: "repo", Path: "/tmp/plugins"},
+				HTTPPluginRepository:  &configtypes.HTTPPluginRepository{Name: "repo", URL: "https://example.com/plugins"},
+			},
+			errStr: "only one repository type can be configured",
+		},
+		{
+			name: "should fail when name is empty",
+			in: configtypes.PluginRepository{
+				LocalPluginRepository: &configtypes.LocalPluginRepository{Path: "/tmp/plugins"},
+			},
+			errStr: "repository name cannot be empty",
+		},
+		{
+			name: "should fail for invalid oci image",
+			in: configtypes.PluginRepository{
+				OCIPluginRepository: &configtypes.OCIPluginRepository{Name: "oci-repo", Image: "Example/UPPER:tag"},
+			},
+			errStr: `invalid oci repository: invalid OCI image reference "Example/UPPER:tag"`,
+		},
+		{
+			name: "should fail for empty local path",
+			in: configtypes.PluginRepository{
+				LocalPluginRepository: &configtypes.LocalPluginRepository{Name: "local-repo"},
+			},
+			errStr: "invalid local repository: path cannot be empty",
+		},
+		{
+			name: "should fail for non http url",
+			in: configtypes.PluginRepository{
+				HTTPPluginRepository: &configtypes.HTTPPluginRepository{Name: "http-repo", URL: "ftp://example.com/plugins"},
+			},
+			errStr: `invalid http repository: invalid url "ftp://example.com/plugins": must be an absolute http or https url`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := SetCLIRepository(tc.in)
+			if tc.errStr != "" {
+				assert.EqualError(t, err, tc.errStr)
+				return
+			}
+			assert.NoError(t, err)
+			_, name := getRepositoryTypeAndName(tc.in)
+			r, err := GetCLIRepository(name)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.in, *r)
+		})
+	}
+}
+
+func TestSetRepositoryReplacesTypeWithSameName(t *testing.T) {
+	// Setup config test data
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+
+	defer func() {
+		cleanUp()
+	}()
+
+	err := SetCLIRepository(configtypes.PluginRepository{
+		GCPPluginRepository: &configtypes.GCPPluginRepository{Name: "repo", BucketName: "bucket"},
+	})
+	assert.NoError(t, err)
+	err = SetCLIRepository(configtypes.PluginRepository{
+		OCIPluginRepository: &configtypes.OCIPluginRepository{Name: "repo", Image: "example.com/tanzu/plugins:latest"},
+	})
+	assert.NoError(t, err)
+
+	repos, err := GetCLIRepositories()
+	assert.NoError(t, err)
+	assert.Len(t, repos, 1)
+	assert.Nil(t, repos[0].GCPPluginRepository)
+	assert.Equal(t, "example.com/tanzu/plugins:latest", repos[0].OCIPluginRepository.Image)
+
+	err = DeleteCLIRepository("repo")
+	assert.NoError(t, err)
+	repos, err = GetCLIRepositories()
+	assert.NoError(t, err)
+	assert.Len(t, repos, 0)
+}
+
+func TestValidateOCIImageRef(t *testing.T) {
+	for _, image := range []string{
+		"ubuntu",
+		"library/ubuntu:22.04",
+		"localhost:5000/tanzu/plugins",
+		"projects.registry.vmware.com/tanzu_cli/plugins/plugin-inventory:latest",
+		"example.com/repo@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+	} {
+		assert.NoError(t, validateOCIImageRef(image), image)
+	}
+	for _, image := range []string{"", "example.com/UPPER/case", "example.com/repo:", "repo@sha256:xyz", "-repo"} {
+		assert.Error(t, validateOCIImageRef(image), image)
+	}
+}
@@ -9,8 +9,6 @@
 package config
 
 import (
-	"os"
-
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v3"
 
@@ -19,21 +17,44 @@ import (
 
 // GetClientConfig retrieves the config from the local directory with file lock
 func GetClientConfig() (cfg *configtypes.ClientConfig, err error) {
+	cfg, err = readClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	// The cached config is shared, the caller gets its own copy to modify
+	return deepCopy(cfg), nil
+}
+
+// readClientConfig retrieves the converted config, which is cached for as long as the files it is read from
+// are unchanged. The returned config may be shared with the cache and must not be modified.
+func readClientConfig() (*configtypes.ClientConfig, error) {
+	// The file states are captured before reading, so that a concurrent write is detected on the next call.
+	states, cacheable := clientConfigFileStates()
+	if cacheable {
+		if cfg := getCachedClientConfig(states); cfg != nil {
+			return cfg, nil
+		}
+	}
+
 	// Retrieve client config node
 	node, err := getClientConfigNode()
 	if err != nil {
 		return nil, err
 	}
 
-	cfg, err = convertNodeToClientConfig(node)
+	cfg, err := convertNodeToClientConfig(node)
 	if err != nil {
 		return nil, err
 	}
 
+	if cacheable {
+		putCachedClientConfig(states, cfg)
+	}
 	return cfg, nil
 }
 
-// GetClientConfigNoLock retrieves the config from the local directory without acquiring the lock
+// GetClientConfigNoLock retrieves the config from the local directory without acquiring the lock.
+// The system config is not merged, so that the returned config can be modified and stored with StoreClientConfig.
 func GetClientConfigNoLock() (cfg *configtypes.ClientConfig, err error) {
 	node, err := getClientConfigNodeNoLock()
 	if err != nil {
@@ -48,20 +69,38 @@ func GetClientConfigNoLock() (cfg *configtypes.ClientConfig, err error) {
 }
 
 // getClientConfigNode retrieves the multi config from the local directory with file lock
+// and merges the system config beneath it
 func getClientConfigNode() (*yaml.Node, error) {
+	if node, err := getEphemeralConfigNode(); node != nil || err != nil {
+		if err != nil {
+			return nil, err
+		}
+		return node, mergeSystemConfig(node)
+	}
+
 	useUnifiedConfig, err := UseUnifiedConfig()
 	if err != nil {
 		useUnifiedConfig = false
 	}
 
+	var node *yaml.Node
 	if useUnifiedConfig {
-		return getClientConfigNextGenNode()
+		node, err = getClientConfigNextGenNode()
+	} else {
+		node, err = getMultiConfig()
 	}
-	return getMultiConfig()
+	if err != nil {
+		return node, err
+	}
+	return node, mergeSystemConfig(node)
 }
 
 // getClientConfigNodeNoLock retrieves the multi config from the local directory without acquiring the lock
 func getClientConfigNodeNoLock() (*yaml.Node, error) {
+	if node, err := getEphemeralConfigNode(); node != nil || err != nil {
+		return node, err
+	}
+
 	// Check config migration feature flag
 	useUnifiedConfig, err := UseUnifiedConfig()
 	if err != nil {
@@ -88,21 +127,23 @@ func getClientConfigNoLock() (*yaml.Node, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "getClientConfigNodeNoLock: failed getting client config path")
 	}
-	bytes, err := os.ReadFile(cfgPath)
-	if err != nil || len(bytes) == 0 {
-		node, err := newClientConfigNode()
+	node, err := readConfigFileNode(cfgPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "getClientConfigNodeNoLock: failed to construct struct from config data")
+	}
+	if node == nil && IsReadOnly() {
+		node, err = readLegacyClientConfigNode()
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to create new client config")
+			return nil, errors.Wrap(err, "getClientConfigNodeNoLock: failed to construct struct from legacy config data")
 		}
-		return node, nil
 	}
-	var node yaml.Node
-	err = yaml.Unmarshal(bytes, &node)
-	if err != nil {
-		return nil, errors.Wrap(err, "getClientConfigNodeNoLock: failed to construct struct from config data")
+	if node == nil {
+		node, err = newClientConfigNode()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create new client config")
+		}
 	}
-	node.Content[0].Style = 0
-	return &node, nil
+	return node, nil
 }
 
 // newClientConfigNode create and return new client config node
@@ -9,8 +9,6 @@
 package config
 
 import (
-	"os"
-
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v3"
 )
@@ -29,21 +27,17 @@ func getClientConfigNextGenNodeNoLock() (*yaml.Node, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "failed getting client config path")
 	}
-	bytes, err := os.ReadFile(cfgPath)
-	if err != nil || len(bytes) == 0 {
-		node, err := newClientConfigNode()
+	node, err := readConfigFileNode(cfgPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to construct struct from config ng data")
+	}
+	if node == nil {
+		node, err = newClientConfigNode()
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to create new client config ng")
 		}
-		return node, nil
-	}
-	var node yaml.Node
-	err = yaml.Unmarshal(bytes, &node)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to construct struct from config ng data")
 	}
-	node.Content[0].Style = 0
-	return &node, nil
+	return node, nil
 }
 
 func persistClientConfigNextGen(node *yaml.Node) error {
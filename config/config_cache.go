@@ -0,0 +1,275 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+// configCacheRacyWindow is the duration after the modification of a config file during which
+// the size and modification time are not trusted to detect changes of the file, as a change
+// within the timestamp granularity of the filesystem may not update the modification time.
+// Entries of such files are validated by comparing the content instead.
+const configCacheRacyWindow = 2 * time.Second
+
+// configFileCacheEnabled enables the process local cache of the parsed config files
+var configFileCacheEnabled = true
+
+// configFileCacheEntry is the parsed yaml node of a config file along with the file state it was parsed from
+type configFileCacheEntry struct {
+	size     int64
+	modTime  time.Time
+	cachedAt time.Time
+	data     []byte
+	node     *yaml.Node
+}
+
+// configFileCache is the process local cache of the parsed config files keyed on the file path
+var configFileCache = struct {
+	sync.Mutex
+	entries map[string]*configFileCacheEntry
+}{entries: make(map[string]*configFileCacheEntry)}
+
+// readConfigFileNode returns the parsed yaml node of the config file, or nil if the file does not exist or is empty.
+// The parsed node is cached and reused for as long as the size and modification time of the file are unchanged.
+// The returned node is a copy that can be modified by the caller.
+func readConfigFileNode(path string) (*yaml.Node, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, nil
+	}
+	if node := getCachedConfigFileNode(path, info, nil); node != nil {
+		return node, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil || len(data) == 0 {
+		return nil, nil
+	}
+	if node := getCachedConfigFileNode(path, info, data); node != nil {
+		return node, nil
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	if len(node.Content) > 0 {
+		node.Content[0].Style = 0
+	}
+	putCachedConfigFileNode(path, info, data, &node)
+	return &node, nil
+}
+
+// getCachedConfigFileNode returns a copy of the cached node of the config file if it matches the file info.
+// The content of the file is required to validate entries within the racy window, nil otherwise.
+func getCachedConfigFileNode(path string, info os.FileInfo, data []byte) *yaml.Node {
+	if !configFileCacheEnabled {
+		return nil
+	}
+	configFileCache.Lock()
+	defer configFileCache.Unlock()
+	entry, ok := configFileCache.entries[path]
+	if !ok || entry.size != info.Size() || !entry.modTime.Equal(info.ModTime()) {
+		return nil
+	}
+	if entry.cachedAt.Sub(entry.modTime) < configCacheRacyWindow {
+		if data == nil || !bytes.Equal(entry.data, data) {
+			return nil
+		}
+		entry.cachedAt = time.Now()
+	}
+	return cloneNode(entry.node)
+}
+
+func putCachedConfigFileNode(path string, info os.FileInfo, data []byte, node *yaml.Node) {
+	if !configFileCacheEnabled {
+		return
+	}
+	configFileCache.Lock()
+	defer configFileCache.Unlock()
+	configFileCache.entries[path] = &configFileCacheEntry{
+		size:     info.Size(),
+		modTime:  info.ModTime(),
+		cachedAt: time.Now(),
+		data:     data,
+		node:     cloneNode(node),
+	}
+}
+
+// invalidateConfigFileCache removes the cached node of the config file, along with the cached client config
+func invalidateConfigFileCache(path string) {
+	invalidateClientConfigCache()
+	configFileCache.Lock()
+	defer configFileCache.Unlock()
+	delete(configFileCache.entries, path)
+}
+
+// cloneNode returns a deep copy of the yaml node. The anchored node of an alias is copied along with the alias.
+func cloneNode(node *yaml.Node) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+	c := *node
+	if node.Content != nil {
+		c.Content = make([]*yaml.Node, len(node.Content))
+		for i, child := range node.Content {
+			c.Content[i] = cloneNode(child)
+		}
+	}
+	c.Alias = cloneNode(node.Alias)
+	return &c
+}
+
+// configFileState is the size and modification time of a config file, with a negative size for a missing file
+type configFileState struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// clientConfigCacheEntry is the converted client config along with the state of the files it was read from
+type clientConfigCacheEntry struct {
+	states   []configFileState
+	cachedAt time.Time
+	cfg      *configtypes.ClientConfig
+}
+
+// clientConfigCache is the process local cache of the client config returned by GetClientConfig
+var clientConfigCache = struct {
+	sync.Mutex
+	entry *clientConfigCacheEntry
+}{}
+
+// clientConfigFileStates returns the state of the files the client config is read from: the config files,
+// the config metadata that selects the unified config and the system config merged beneath them.
+// It returns false if the client config is not read from these files only, in which case it is not cached.
+func clientConfigFileStates() ([]configFileState, bool) {
+	if !configFileCacheEnabled || IsEphemeralConfig() || IsReadOnly() {
+		return nil, false
+	}
+	var paths []string
+	for _, pathFn := range []func() (string, error){ClientConfigPath, ClientConfigNextGenPath, CfgMetadataFilePath} {
+		path, err := pathFn()
+		if err != nil {
+			return nil, false
+		}
+		paths = append(paths, path)
+	}
+	if path := SystemConfigPath(); path != "" {
+		paths = append(paths, path)
+	}
+	states := make([]configFileState, 0, len(paths))
+	for _, path := range paths {
+		state := configFileState{path: path, size: -1}
+		if info, err := os.Stat(path); err == nil {
+			state.size, state.modTime = info.Size(), info.ModTime()
+		}
+		states = append(states, state)
+	}
+	return states, true
+}
+
+// getCachedClientConfig returns the cached client config if the files it was read from are unchanged.
+// The returned config is shared and must not be modified.
+// Entries cached within the racy window of the modification of a file are not used, as a later change of
+// the file may not update its modification time.
+func getCachedClientConfig(states []configFileState) *configtypes.ClientConfig {
+	clientConfigCache.Lock()
+	defer clientConfigCache.Unlock()
+	entry := clientConfigCache.entry
+	if entry == nil || len(entry.states) != len(states) {
+		return nil
+	}
+	for i, state := range states {
+		cached := entry.states[i]
+		if cached.path != state.path || cached.size != state.size || !cached.modTime.Equal(state.modTime) {
+			return nil
+		}
+		if state.size >= 0 && entry.cachedAt.Sub(state.modTime) < configCacheRacyWindow {
+			return nil
+		}
+	}
+	return entry.cfg
+}
+
+// putCachedClientConfig caches the client config, which must not be modified afterwards
+func putCachedClientConfig(states []configFileState, cfg *configtypes.ClientConfig) {
+	clientConfigCache.Lock()
+	defer clientConfigCache.Unlock()
+	clientConfigCache.entry = &clientConfigCacheEntry{
+		states:   states,
+		cachedAt: time.Now(),
+		cfg:      cfg,
+	}
+}
+
+// invalidateClientConfigCache removes the cached client config
+func invalidateClientConfigCache() {
+	clientConfigCache.Lock()
+	defer clientConfigCache.Unlock()
+	clientConfigCache.entry = nil
+}
+
+// deepCopy returns a deep copy of the value, which must not hold cycles. Unexported fields are copied shallowly.
+func deepCopy[T any](v T) T {
+	return deepCopyValue(reflect.ValueOf(v)).Interface().(T)
+}
+
+func deepCopyValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Elem().Type())
+		c.Elem().Set(deepCopyValue(v.Elem()))
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(deepCopyValue(v.Elem()))
+		return c
+	case reflect.Struct:
+		// Unexported fields, such as those of time.Time, are copied by value
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" {
+				c.Field(i).Set(deepCopyValue(v.Field(i)))
+			}
+		}
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopyValue(v.Index(i)))
+		}
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), deepCopyValue(iter.Value()))
+		}
+		return c
+	default:
+		return v
+	}
+}
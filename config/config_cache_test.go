@@ -0,0 +1,357 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+func TestReadConfigFileNode(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	defer invalidateConfigFileCache(path)
+
+	node, err := readConfigFileNode(path)
+	require.NoError(t, err)
+	assert.Nil(t, node)
+
+	require.NoError(t, os.WriteFile(path, []byte("kind: ClientConfig\n"), 0644))
+	node, err = readConfigFileNode(path)
+	require.NoError(t, err)
+	require.NotNil(t, node)
+	assert.Equal(t, "ClientConfig", node.Content[0].Content[1].Value)
+
+	// The returned node is a defensive copy
+	node.Content[0].Content[1].Value = "Modified"
+	node, err = readConfigFileNode(path)
+	require.NoError(t, err)
+	assert.Equal(t, "ClientConfig", node.Content[0].Content[1].Value)
+
+	// A change of the same size within the racy window is detected through the content
+	require.NoError(t, os.WriteFile(path, []byte("kind: ClientConfog\n"), 0644))
+	node, err = readConfigFileNode(path)
+	require.NoError(t, err)
+	assert.Equal(t, "ClientConfog", node.Content[0].Content[1].Value)
+
+	// A change of the size is detected
+	require.NoError(t, os.WriteFile(path, []byte("kind: Config\n"), 0644))
+	node, err = readConfigFileNode(path)
+	require.NoError(t, err)
+	assert.Equal(t, "Config", node.Content[0].Content[1].Value)
+
+	_, err = readConfigFileNode(writeTempFile(t, "kind: [\n"))
+	assert.Error(t, err)
+}
+
+func TestReadConfigFileNodeUsesCacheOutsideRacyWindow(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	defer invalidateConfigFileCache(path)
+
+	require.NoError(t, os.WriteFile(path, []byte("kind: ClientConfig\n"), 0644))
+	past := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(path, past, past))
+	_, err := readConfigFileNode(path)
+	require.NoError(t, err)
+
+	// Overwrite the content while preserving the size and modification time to verify the cached node is used
+	require.NoError(t, os.WriteFile(path, []byte("kind: ClientConfog\n"), 0644))
+	require.NoError(t, os.Chtimes(path, past, past))
+	node, err := readConfigFileNode(path)
+	require.NoError(t, err)
+	assert.Equal(t, "ClientConfig", node.Content[0].Content[1].Value)
+
+	// Writes through persistNode invalidate the cached node
+	node.Content[0].Content[1].Value = "Persisted"
+	require.NoError(t, persistNode(node, WithCfgPath(path)))
+	require.NoError(t, os.Chtimes(path, past, past))
+	node, err = readConfigFileNode(path)
+	require.NoError(t, err)
+	assert.Equal(t, "Persisted", node.Content[0].Content[1].Value)
+}
+
+func TestConfigCacheReadAfterWrite(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+
+	for i := 0; i < 3; i++ {
+		value := fmt.Sprintf("value-%d", i)
+		require.NoError(t, SetEnv("FOO", value))
+		got, err := GetEnv("FOO")
+		require.NoError(t, err)
+		assert.Equal(t, value, got)
+	}
+}
+
+func TestGetClientConfigUsesCache(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+
+	require.NoError(t, SetContext(&configtypes.Context{
+		Name:               "test-k8s",
+		ContextType:        configtypes.ContextTypeK8s,
+		ClusterOpts:        &configtypes.ClusterServer{Endpoint: "https://k8s.example.com", Path: "kubeconfig", Context: "k8s"},
+		AdditionalMetadata: map[string]interface{}{"key": "value"},
+	}, false))
+
+	// Move the config files out of the racy window of the cache
+	past := time.Now().Add(-time.Hour)
+	var paths []string
+	for _, pathFn := range []func() (string, error){ClientConfigPath, ClientConfigNextGenPath, CfgMetadataFilePath} {
+		path, err := pathFn()
+		require.NoError(t, err)
+		paths = append(paths, path)
+		if _, err := os.Stat(path); err == nil {
+			require.NoError(t, os.Chtimes(path, past, past))
+		}
+	}
+
+	cfg, err := GetClientConfig()
+	require.NoError(t, err)
+	require.Len(t, cfg.KnownContexts, 1)
+
+	// The returned config is a copy of the cached config
+	cfg.KnownContexts[0].AdditionalMetadata["key"] = "modified"
+	cfg.KnownContexts = nil
+	cfg, err = GetClientConfig()
+	require.NoError(t, err)
+	require.Len(t, cfg.KnownContexts, 1)
+	assert.Equal(t, "value", cfg.KnownContexts[0].AdditionalMetadata["key"])
+
+	// Overwrite the content while preserving the size and modification time to verify the cached config is used
+	data, err := os.ReadFile(paths[1])
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(paths[1], []byte(strings.Replace(string(data), "test-k8s", "test-k9s", 1)), 0644))
+	require.NoError(t, os.Chtimes(paths[1], past, past))
+	cfg, err = GetClientConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "test-k8s", cfg.KnownContexts[0].Name)
+
+	// A change of the modification time is detected
+	require.NoError(t, os.Chtimes(paths[1], past.Add(time.Minute), past.Add(time.Minute)))
+	cfg, err = GetClientConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "test-k9s", cfg.KnownContexts[0].Name)
+
+	// Writes invalidate the cached config
+	require.NoError(t, SetEnv("FOO", "bar"))
+	cfg, err = GetClientConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "bar", cfg.ClientOptions.Env["FOO"])
+}
+
+func TestCachedConfigGettersReturnCopies(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+
+	require.NoError(t, SetContext(&configtypes.Context{
+		Name:               "test-k8s",
+		ContextType:        configtypes.ContextTypeK8s,
+		ClusterOpts:        &configtypes.ClusterServer{Endpoint: "https://k8s.example.com", Path: "kubeconfig", Context: "k8s"},
+		AdditionalMetadata: map[string]interface{}{"key": "value"},
+	}, false))
+	require.NoError(t, SetCert(&configtypes.Cert{Host: "k8s.example.com", SkipCertVerify: "true"}))
+	require.NoError(t, SetFeature("global", "foo", "true"))
+	require.NoError(t, SetEnv("FOO", "bar"))
+
+	// Move the config files out of the racy window of the cache
+	past := time.Now().Add(-time.Hour)
+	for _, pathFn := range []func() (string, error){ClientConfigPath, ClientConfigNextGenPath, CfgMetadataFilePath} {
+		path, err := pathFn()
+		require.NoError(t, err)
+		if _, err := os.Stat(path); err == nil {
+			require.NoError(t, os.Chtimes(path, past, past))
+		}
+	}
+
+	ctx, err := GetContext("test-k8s")
+	require.NoError(t, err)
+	ctx.AdditionalMetadata["key"] = "modified"
+	ctx.ClusterOpts.Endpoint = "https://modified.example.com"
+	cert, err := GetCert("k8s.example.com")
+	require.NoError(t, err)
+	cert.SkipCertVerify = "false"
+
+	// The values returned by the getters are copies of the cached config
+	ctx, err = GetContext("test-k8s")
+	require.NoError(t, err)
+	assert.Equal(t, "value", ctx.AdditionalMetadata["key"])
+	assert.Equal(t, "https://k8s.example.com", ctx.ClusterOpts.Endpoint)
+	cert, err = GetCert("k8s.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "true", cert.SkipCertVerify)
+	enabled, err := IsFeatureEnabled("global", "foo")
+	require.NoError(t, err)
+	assert.True(t, enabled)
+	env, err := GetEnv("FOO")
+	require.NoError(t, err)
+	assert.Equal(t, "bar", env)
+
+	// Writes invalidate the cached config
+	require.NoError(t, SetFeature("global", "foo", "false"))
+	require.NoError(t, SetEnv("FOO", "baz"))
+	enabled, err = IsFeatureEnabled("global", "foo")
+	require.NoError(t, err)
+	assert.False(t, enabled)
+	env, err = GetEnv("FOO")
+	require.NoError(t, err)
+	assert.Equal(t, "baz", env)
+}
+
+func TestDeepCopy(t *testing.T) {
+	expiration := time.Now()
+	cfg := &configtypes.ClientConfig{
+		KnownContexts: []*configtypes.Context{{
+			Name:               "test",
+			GlobalOpts:         &configtypes.GlobalServer{Auth: configtypes.GlobalServerAuth{Expiration: expiration}},
+			AdditionalMetadata: map[string]interface{}{"list": []interface{}{"a"}, "map": map[string]interface{}{"b": "c"}},
+		}},
+	}
+	c := deepCopy(cfg)
+	assert.Equal(t, cfg, c)
+	assert.NotSame(t, cfg.KnownContexts[0], c.KnownContexts[0])
+	assert.True(t, expiration.Equal(c.KnownContexts[0].GlobalOpts.Auth.Expiration))
+
+	c.KnownContexts[0].AdditionalMetadata["list"].([]interface{})[0] = "modified"
+	c.KnownContexts[0].AdditionalMetadata["map"].(map[string]interface{})["b"] = "modified"
+	assert.Equal(t, "a", cfg.KnownContexts[0].AdditionalMetadata["list"].([]interface{})[0])
+	assert.Equal(t, "c", cfg.KnownContexts[0].AdditionalMetadata["map"].(map[string]interface{})["b"])
+}
+
+func TestCloneNode(t *testing.T) {
+	var node yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte("a: &anchor\n  b: c\nd: *anchor\n"), &node))
+
+	c := cloneNode(&node)
+	c.Content[0].Content[1].Content[1].Value = "modified"
+	assert.Equal(t, "c", node.Content[0].Content[1].Content[1].Value)
+	// The alias points to a copy of the anchored node
+	assert.NotSame(t, node.Content[0].Content[3].Alias, c.Content[0].Content[3].Alias)
+	assert.Equal(t, node.Content[0].Content[3].Alias.Content[1].Value, c.Content[0].Content[3].Alias.Content[1].Value)
+	assert.Nil(t, cloneNode(nil))
+}
+
+func writeTempFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "file.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+// setupBenchmarkConfig writes a config with the number of contexts, features, envs and certs to temporary files
+// with a modification time outside the racy window of the cache
+func setupBenchmarkConfig(b *testing.B, contexts int) {
+	dir := b.TempDir()
+	cfg := &configtypes.ClientConfig{
+		ClientOptions: &configtypes.ClientOptions{
+			Features: map[string]configtypes.FeatureMap{"global": {}},
+			Env:      map[string]string{},
+		},
+	}
+	for i := 0; i < contexts; i++ {
+		cfg.KnownContexts = append(cfg.KnownContexts, &configtypes.Context{
+			Name:        fmt.Sprintf("context-%d", i),
+			ContextType: configtypes.ContextTypeK8s,
+			ClusterOpts: &configtypes.ClusterServer{
+				Endpoint: fmt.Sprintf("https://cluster-%d.example.com", i),
+				Path:     filepath.Join(dir, "kubeconfig"),
+				Context:  fmt.Sprintf("context-%d", i),
+			},
+		})
+		cfg.ClientOptions.Features["global"][fmt.Sprintf("feature-%d", i)] = "true"
+		cfg.ClientOptions.Env[fmt.Sprintf("ENV_%d", i)] = "value"
+		cfg.Certs = append(cfg.Certs, &configtypes.Cert{Host: fmt.Sprintf("cluster-%d.example.com", i), SkipCertVerify: "true"})
+	}
+	// The client options are stored in config.yaml and the contexts and certs in config-ng.yaml
+	cfgData, err := yaml.Marshal(&configtypes.ClientConfig{ClientOptions: cfg.ClientOptions})
+	require.NoError(b, err)
+	cfgNextGenData, err := yaml.Marshal(&configtypes.ClientConfig{KnownContexts: cfg.KnownContexts, Certs: cfg.Certs})
+	require.NoError(b, err)
+
+	past := time.Now().Add(-time.Hour)
+	for key, content := range map[string][]byte{
+		EnvConfigKey:          cfgData,
+		EnvConfigNextGenKey:   cfgNextGenData,
+		EnvConfigMetadataKey:  []byte(""),
+		EnvConfigAuditFileKey: nil,
+	} {
+		path := filepath.Join(dir, key)
+		b.Setenv(key, path)
+		if content == nil {
+			continue
+		}
+		require.NoError(b, os.WriteFile(path, content, 0644))
+		require.NoError(b, os.Chtimes(path, past, past))
+	}
+}
+
+func benchmarkConfigCache(b *testing.B, fn func(i int) error) {
+	for _, enabled := range []bool{false, true} {
+		name := "uncached"
+		if enabled {
+			name = "cached"
+		}
+		b.Run(name, func(b *testing.B) {
+			defer func(enabled bool) {
+				configFileCacheEnabled = enabled
+			}(configFileCacheEnabled)
+			configFileCacheEnabled = enabled
+
+			for _, contexts := range []int{10, 500} {
+				b.Run(fmt.Sprintf("contexts=%d", contexts), func(b *testing.B) {
+					setupBenchmarkConfig(b, contexts)
+					b.ResetTimer()
+					for i := 0; i < b.N; i++ {
+						if err := fn(i % contexts); err != nil {
+							b.Fatal(err)
+						}
+					}
+				})
+			}
+		})
+	}
+}
+
+func BenchmarkGetContext(b *testing.B) {
+	benchmarkConfigCache(b, func(i int) error {
+		_, err := GetContext(fmt.Sprintf("context-%d", i))
+		return err
+	})
+}
+
+func BenchmarkIsFeatureEnabled(b *testing.B) {
+	benchmarkConfigCache(b, func(i int) error {
+		_, err := IsFeatureEnabled("global", fmt.Sprintf("feature-%d", i))
+		return err
+	})
+}
+
+func BenchmarkGetEnv(b *testing.B) {
+	benchmarkConfigCache(b, func(i int) error {
+		_, err := GetEnv(fmt.Sprintf("ENV_%d", i))
+		return err
+	})
+}
+
+func BenchmarkGetCert(b *testing.B) {
+	benchmarkConfigCache(b, func(i int) error {
+		_, err := GetCert(fmt.Sprintf("https://cluster-%d.example.com", i))
+		return err
+	})
+}
+
+func BenchmarkGetClientConfig(b *testing.B) {
+	benchmarkConfigCache(b, func(int) error {
+		_, err := GetClientConfig()
+		return err
+	})
+}
@@ -86,7 +86,56 @@ func makeMultiFileCfg(cfgNode, cfgNextGenNode *yaml.Node) (*yaml.Node, error) {
 }
 
 // persistConfig write the updated node data to config.yaml and config-ng.yaml based on cfgItems
+// and records the mutation in the config audit trail
 func persistConfig(node *yaml.Node) error {
+	return persistConfigAndMetadata(node, nil)
+}
+
+// persistConfigAndMetadata writes the config along with the optional update of the config metadata, which is
+// written with the revision of the config once the update is validated, under the config lock held by the caller
+func persistConfigAndMetadata(node *yaml.Node, updateMetadata func(metadataNode *yaml.Node) error) error {
+	if err := checkReadOnly("the config"); err != nil {
+		return err
+	}
+	// The config policy is enforced on every write, refusing the values violating it
+	validate := func(oldNode *yaml.Node) error {
+		return checkPolicy(oldNode, node)
+	}
+	// The ephemeral config is never written to disk
+	if IsEphemeralConfig() {
+		oldNode, err := getEphemeralConfigNode()
+		if err != nil {
+			return err
+		}
+		if err := validate(oldNode); err != nil {
+			return err
+		}
+		if updateMetadata != nil {
+			if err := updateConfigMetadata(updateMetadata); err != nil {
+				return err
+			}
+		}
+		return persistEphemeralConfig(node)
+	}
+	// The revision in the config metadata is incremented once the update is validated and before it is written,
+	// so that a failure to update the revision leaves the config untouched rather than unversioned
+	oldNode, err := persistConfigNodes(node, func(oldNode *yaml.Node) error {
+		if err := validate(oldNode); err != nil {
+			return err
+		}
+		return errors.Wrap(incrementConfigRevision(updateMetadata), "failed to update the config revision")
+	})
+	if err != nil {
+		return err
+	}
+	auditClientConfig(oldNode, node)
+	return nil
+}
+
+// persistConfigNodes write the updated node data to config.yaml and config-ng.yaml based on cfgItems.
+// The update is validated against the config node read before the update, which is returned to record
+// the mutation in the audit trail.
+func persistConfigNodes(node *yaml.Node, validate func(oldNode *yaml.Node) error) (*yaml.Node, error) {
 	// check to persist multi file or to config-ng yaml
 	useUnifiedConfig, err := UseUnifiedConfig()
 	if err != nil {
@@ -95,19 +144,35 @@ func persistConfig(node *yaml.Node) error {
 
 	// If useUnifiedConfig is set to true write to config-ng.yaml
 	if useUnifiedConfig {
-		return persistClientConfigNextGen(node)
+		oldNode, err := getClientConfigNextGenNodeNoLock()
+		if err != nil {
+			oldNode = nil
+		}
+		if err := validate(oldNode); err != nil {
+			return nil, err
+		}
+		return oldNode, persistClientConfigNextGen(node)
 	}
 
 	// config node from config.yaml
 	cfgNode, err := getClientConfigNoLock()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// config next gen node from config-ng.yaml
 	cfgNextGenNode, err := getClientConfigNextGenNodeNoLock()
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	// The multi file node refers to the current item nodes, which the update replaces rather than modifies
+	oldNode, err := makeMultiFileCfg(cfgNode, cfgNextGenNode)
+	if err != nil {
+		oldNode = nil
+	}
+	if err := validate(oldNode); err != nil {
+		return nil, err
 	}
 
 	// for each of the change node update the respective node in cfg and cfg-ng
@@ -154,22 +219,25 @@ func persistConfig(node *yaml.Node) error {
 	// Store the non nextGenItem config data to config.yaml
 	err = persistClientConfig(cfgNode)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Store the nextGenItem config data to config-ng.yaml
 	err = persistClientConfigNextGen(cfgNextGenNode)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Store the config data to legacy client config file/location
+	// Store the config data to legacy client config file/location, unless the migration off servers has been completed
+	if legacyServersMigrated() {
+		return oldNode, nil
+	}
 	err = persistLegacyClientConfig(cfgNode)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	return oldNode, nil
 }
 
 // persistNode stores/writes the yaml node to config path specified in CfgOpts
@@ -178,24 +246,32 @@ func persistNode(node *yaml.Node, opts ...CfgOpts) error {
 	for _, opt := range opts {
 		opt(configurations)
 	}
+	if err := checkReadOnly(configurations.CfgPath); err != nil {
+		return err
+	}
 	cfgPathExists, err := fileExists(configurations.CfgPath)
 	if err != nil {
 		return errors.Wrap(err, "failed to check config path existence")
 	}
+	localDir, err := LocalDir()
+	if err != nil {
+		return errors.Wrap(err, "could not find local tanzu dir for OS")
+	}
 	if !cfgPathExists {
-		localDir, err := LocalDir()
-		if err != nil {
-			return errors.Wrap(err, "could not find local tanzu dir for OS")
-		}
-		if err := os.MkdirAll(localDir, 0755); err != nil {
+		if err := os.MkdirAll(localDir, configDirMode); err != nil {
 			return errors.Wrap(err, "could not make local tanzu directory")
 		}
 	}
+	// The local tanzu directory may have been created with a more permissive mode by older versions
+	tightenDirPermissions(localDir)
 	data, err := yaml.Marshal(node)
 	if err != nil {
 		return errors.Wrap(err, "failed to marshal nodeutils")
 	}
-	err = os.WriteFile(configurations.CfgPath, data, 0644)
+	// Invalidate the cached node of the config file on our own writes
+	defer invalidateConfigFileCache(configurations.CfgPath)
+	tightenFilePermissions(configurations.CfgPath)
+	err = os.WriteFile(configurations.CfgPath, data, configFileMode)
 	if err != nil {
 		return errors.Wrap(err, "failed to write the config to file")
 	}
@@ -28,7 +28,11 @@ var (
 )
 
 // LocalDir returns the local directory in which tanzu state is stored.
+// With the XDG layout, it is the tanzu directory within XDG_CONFIG_HOME, or within ~/.config if not set.
 func LocalDir() (path string, err error) {
+	if UseXDGLayout() {
+		return xdgBaseDirOrDefault(EnvXDGConfigHomeKey)
+	}
 	return localDirPath(LocalDirName)
 }
 
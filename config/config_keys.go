@@ -30,4 +30,10 @@ const (
 	KeyCLIId                   = "cliId"
 	KeySource                  = "source"
 	KeyAdditionalMetadata      = "additionalMetadata"
+	KeyContextTypeFeatures     = "contextTypeFeatures"
+	KeyContextTypeEnv          = "contextTypeEnv"
+	KeyPriority                = "priority"
+	KeyDisabled                = "disabled"
+	KeyContextSets             = "contextSets"
+	KeyCurrentContextSet       = "currentContextSet"
 )
@@ -0,0 +1,553 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/config/internal/kubeconfig"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+const (
+	// ContextBundleKind is the kind of the manifest of a context bundle
+	ContextBundleKind = "ContextBundle"
+	// ContextBundleVersion is the version of the context bundle format written by ExportContext
+	ContextBundleVersion = 1
+
+	// ImportedKubeconfigDir is the directory under LocalDir in which the kubeconfigs of the imported contexts are stored
+	ImportedKubeconfigDir = "kubeconfigs"
+
+	contextBundleManifestEntry         = "manifest.yaml"
+	contextBundleContextEntry          = "context.yaml"
+	contextBundleCertsEntry            = "certs.yaml"
+	contextBundleKubeconfigEntry       = "kubeconfig.yaml"
+	contextBundleDiscoverySourcesEntry = "discovery-sources.yaml"
+
+	// maxContextBundleEntrySize is the maximum size of an entry of a context bundle
+	maxContextBundleEntrySize = 10 << 20
+)
+
+// kubeconfigCredentialKeys are the keys of the credentials of a kubeconfig user stripped on export
+var kubeconfigCredentialKeys = []string{"token", "tokenFile", "password", "client-key", "client-key-data"}
+
+// kubeconfigAuthProviderCredentialKeys are the keys of the credentials of a kubeconfig auth provider stripped on export
+var kubeconfigAuthProviderCredentialKeys = []string{"access-token", "id-token", "refresh-token"}
+
+// ContextBundleManifest describes the content of a context bundle
+type ContextBundleManifest struct {
+	// Kind of the bundle, always ContextBundle
+	Kind string `json:"kind" yaml:"kind"`
+	// Version of the bundle format
+	Version int `json:"version" yaml:"version"`
+	// Context is the name of the exported context
+	Context string `json:"context" yaml:"context"`
+	// ExportedAt is the time of the export
+	ExportedAt time.Time `json:"exportedAt" yaml:"exportedAt"`
+	// Credentials indicates the tokens and keys are included in the bundle
+	Credentials bool `json:"credentials,omitempty" yaml:"credentials,omitempty"`
+}
+
+// contextBundleEntry is a yaml entry of a context bundle
+type contextBundleEntry struct {
+	name  string
+	value interface{}
+}
+
+// ExportContextOptions are the options of ExportContext
+type ExportContextOptions struct {
+	// IncludeCredentials includes the tokens and keys of the context, certs and kubeconfig in the bundle
+	IncludeCredentials bool
+}
+
+type ExportContextOpts func(opts *ExportContextOptions)
+
+// WithExportCredentials includes the tokens and keys in the exported bundle, which are stripped by default
+func WithExportCredentials() ExportContextOpts {
+	return func(opts *ExportContextOptions) {
+		opts.IncludeCredentials = true
+	}
+}
+
+// ImportCollisionPolicy is the handling of an imported context whose name is already used by a context
+type ImportCollisionPolicy string
+
+const (
+	// ImportCollisionFail fails the import
+	ImportCollisionFail ImportCollisionPolicy = "fail"
+	// ImportCollisionOverwrite replaces the existing context and certs
+	ImportCollisionOverwrite ImportCollisionPolicy = "overwrite"
+	// ImportCollisionRename imports the context with the first free name of the form <name>-<n>
+	ImportCollisionRename ImportCollisionPolicy = "rename"
+)
+
+// ImportContextOptions are the options of ImportContext
+type ImportContextOptions struct {
+	// Name of the imported context, defaults to the name of the exported context
+	Name string
+	// OnCollision is the handling of an existing context with the same name, defaults to ImportCollisionFail
+	OnCollision ImportCollisionPolicy
+	// SetCurrent sets the imported context as the current context of its type
+	SetCurrent bool
+}
+
+type ImportContextOpts func(opts *ImportContextOptions)
+
+// WithImportName imports the context with the name instead of the name of the exported context
+func WithImportName(name string) ImportContextOpts {
+	return func(opts *ImportContextOptions) {
+		opts.Name = name
+	}
+}
+
+// WithImportCollisionPolicy sets the handling of an existing context with the same name
+func WithImportCollisionPolicy(policy ImportCollisionPolicy) ImportContextOpts {
+	return func(opts *ImportContextOptions) {
+		opts.OnCollision = policy
+	}
+}
+
+// WithImportSetCurrent sets the imported context as the current context of its type
+func WithImportSetCurrent() ImportContextOpts {
+	return func(opts *ImportContextOptions) {
+		opts.SetCurrent = true
+	}
+}
+
+// ExportContext writes the context along with its matching certs, minified kubeconfig and discovery sources
+// as a versioned bundle (gzipped tar archive) to the writer. Tokens, keys, env values and sensitive additional metadata
+// are stripped unless WithExportCredentials is specified.
+func ExportContext(name string, w io.Writer, opts ...ExportContextOpts) error {
+	options := &ExportContextOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	ctx, err := GetContext(name)
+	if err != nil {
+		return err
+	}
+	certs, err := GetCerts()
+	if err != nil {
+		return err
+	}
+
+	var kc *kubeconfig.Config
+	endpoints := contextEndpoints(ctx)
+	if ctx.ClusterOpts != nil && ctx.ClusterOpts.Path != "" {
+		kc, err = minifiedContextKubeconfig(ctx.ClusterOpts)
+		if err != nil {
+			return errors.Wrapf(err, "failed to export the kubeconfig of context %q", name)
+		}
+		for _, cluster := range kc.Clusters {
+			endpoints = append(endpoints, cluster.Cluster.Server)
+		}
+	}
+	matchingCerts := matchingContextCerts(certs, endpoints)
+
+	sources := ctx.DiscoverySources
+	ctx.DiscoverySources = nil
+	if !options.IncludeCredentials {
+		stripContextCredentials(ctx)
+		for _, cert := range matchingCerts {
+			cert.ClientKeyData = ""
+		}
+		if kc != nil {
+			stripKubeconfigCredentials(kc)
+		}
+	}
+
+	manifest := &ContextBundleManifest{
+		Kind:        ContextBundleKind,
+		Version:     ContextBundleVersion,
+		Context:     name,
+		ExportedAt:  time.Now().UTC(),
+		Credentials: options.IncludeCredentials,
+	}
+	entries := []contextBundleEntry{
+		{contextBundleManifestEntry, manifest},
+		{contextBundleContextEntry, ctx},
+	}
+	if len(matchingCerts) > 0 {
+		entries = append(entries, contextBundleEntry{contextBundleCertsEntry, matchingCerts})
+	}
+	if kc != nil {
+		entries = append(entries, contextBundleEntry{contextBundleKubeconfigEntry, kc})
+	}
+	if len(sources) > 0 {
+		entries = append(entries, contextBundleEntry{contextBundleDiscoverySourcesEntry, sources})
+	}
+
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	for _, entry := range entries {
+		if err := writeContextBundleEntry(tw, entry.name, entry.value, manifest.ExportedAt); err != nil {
+			return errors.Wrapf(err, "failed to write %s of the context bundle", entry.name)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return errors.Wrap(err, "failed to write the context bundle")
+	}
+	return errors.Wrap(gw.Close(), "failed to write the context bundle")
+}
+
+// ImportContext reads a context bundle written by ExportContext and adds the context along with its certs.
+// The kubeconfig of the context is stored under LocalDir and the path of the context is rewritten to it.
+// The context and its certs are written in a single update of the config, which leaves the config untouched on failure.
+// Returns the imported context.
+func ImportContext(r io.Reader, opts ...ImportContextOpts) (*configtypes.Context, error) {
+	options := &ImportContextOptions{OnCollision: ImportCollisionFail}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if err := checkReadOnly("the imported context"); err != nil {
+		return nil, err
+	}
+	entries, err := readContextBundleEntries(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest ContextBundleManifest
+	if err := decodeContextBundleEntry(entries, contextBundleManifestEntry, &manifest, true); err != nil {
+		return nil, err
+	}
+	if manifest.Kind != ContextBundleKind {
+		return nil, errors.Errorf("invalid context bundle kind %q", manifest.Kind)
+	}
+	if manifest.Version < 1 || manifest.Version > ContextBundleVersion {
+		return nil, errors.Errorf("unsupported context bundle version %d, supported versions are up to %d", manifest.Version, ContextBundleVersion)
+	}
+	ctx := &configtypes.Context{}
+	if err := decodeContextBundleEntry(entries, contextBundleContextEntry, ctx, true); err != nil {
+		return nil, err
+	}
+	var certs []*configtypes.Cert
+	if err := decodeContextBundleEntry(entries, contextBundleCertsEntry, &certs, false); err != nil {
+		return nil, err
+	}
+	if err := decodeContextBundleEntry(entries, contextBundleDiscoverySourcesEntry, &ctx.DiscoverySources, false); err != nil {
+		return nil, err
+	}
+
+	for _, cert := range certs {
+		if cert == nil || cert.Host == "" {
+			continue
+		}
+		if err := checkSystemConfigLock(DiffSectionCerts, cert.Host); err != nil {
+			return nil, err
+		}
+	}
+	kubeconfigData, hasKubeconfig := entries[contextBundleKubeconfigEntry]
+	if hasKubeconfig && ctx.ClusterOpts == nil {
+		return nil, errors.Errorf("context bundle holds a kubeconfig for context %q without cluster options", ctx.Name)
+	}
+
+	// The context and its certs are replaced in a single update of the config, so that a failure leaves the config untouched
+	AcquireTanzuConfigLock()
+	defer ReleaseTanzuConfigLock()
+	node, err := getClientConfigNodeNoLock()
+	if err != nil {
+		return nil, err
+	}
+	name, err := importContextName(node, ctx.Name, options)
+	if err != nil {
+		return nil, err
+	}
+	ctx.Name = name
+
+	var kubeconfigPath string
+	if hasKubeconfig {
+		kubeconfigPath, err = importedKubeconfigPath(ctx.Name)
+		if err != nil {
+			return nil, err
+		}
+		ctx.ClusterOpts.Path = kubeconfigPath
+	}
+	if err := importContextNodes(node, ctx, certs, options); err != nil {
+		return nil, err
+	}
+
+	// The kubeconfig is moved in place once the config is written, so that a rejected update leaves it untouched
+	var tmpKubeconfigPath string
+	if hasKubeconfig {
+		tmpKubeconfigPath, err = storeImportedKubeconfig(kubeconfigPath, kubeconfigData)
+		if err != nil {
+			return nil, err
+		}
+		defer os.Remove(tmpKubeconfigPath)
+	}
+	if err := persistConfig(node); err != nil {
+		return nil, err
+	}
+	if hasKubeconfig {
+		if err := os.Rename(tmpKubeconfigPath, kubeconfigPath); err != nil {
+			return nil, errors.Wrap(err, "failed to write the imported kubeconfig")
+		}
+	}
+	return ctx, nil
+}
+
+// importContextNodes adds the imported context along with its certs to the node, replacing the existing context
+// and certs with the overwrite collision policy
+func importContextNodes(node *yaml.Node, ctx *configtypes.Context, certs []*configtypes.Cert, options *ImportContextOptions) error {
+	existingCerts, err := getCerts(node)
+	if err != nil {
+		return err
+	}
+	for _, cert := range certs {
+		if cert == nil || cert.Host == "" {
+			continue
+		}
+		if _, _, err := parseCertHost(cert.Host); err != nil {
+			return errors.Wrapf(err, "failed to import the cert for %q", cert.Host)
+		}
+		// Only the cert of the same host collides, a wildcard or CIDR cert matching the host does not
+		if certHostExists(existingCerts, cert.Host) && options.OnCollision != ImportCollisionOverwrite {
+			continue
+		}
+		if _, err := setCert(node, cert); err != nil {
+			return errors.Wrapf(err, "failed to import the cert for %q", cert.Host)
+		}
+	}
+
+	if options.OnCollision == ImportCollisionOverwrite {
+		if _, err := getContext(node, ctx.Name); err == nil {
+			if err := removeContextAndServer(node, ctx.Name); err != nil {
+				return err
+			}
+		}
+	}
+	_, err = setContextAndServer(node, ctx, options.SetCurrent)
+	return err
+}
+
+// certHostExists checks whether the certs hold a cert for the host, without matching wildcard or CIDR hosts
+func certHostExists(certs []*configtypes.Cert, host string) bool {
+	for _, cert := range certs {
+		if cert != nil && cert.Host == host {
+			return true
+		}
+	}
+	return false
+}
+
+// importContextName returns the name of the imported context according to the collision policy
+func importContextName(node *yaml.Node, exported string, options *ImportContextOptions) (string, error) {
+	name := exported
+	if options.Name != "" {
+		name = options.Name
+	}
+	if name == "" {
+		return "", errors.New("context bundle holds a context without name")
+	}
+	return resolveContextNameCollision(node, name, options.OnCollision)
+}
+
+// resolveContextNameCollision returns the name to store a new context with in the node according to the collision policy
+func resolveContextNameCollision(node *yaml.Node, name string, policy ImportCollisionPolicy) (string, error) {
+	if _, err := getContext(node, name); err != nil {
+		return name, nil
+	}
+	switch policy {
+	case ImportCollisionOverwrite:
+		return name, nil
+	case ImportCollisionRename:
+		for i := 2; ; i++ {
+			candidate := fmt.Sprintf("%s-%d", name, i)
+			if _, err := getContext(node, candidate); err != nil {
+				return candidate, nil
+			}
+		}
+	case ImportCollisionFail, "":
+		return "", errors.Errorf("context %q already exists", name)
+	}
+	return "", errors.Errorf("unsupported import collision policy %q", policy)
+}
+
+// importedKubeconfigPath returns the path under LocalDir of the kubeconfig of the imported context
+func importedKubeconfigPath(name string) (string, error) {
+	localDir, err := LocalDir()
+	if err != nil {
+		return "", errors.Wrap(err, "could not find local tanzu dir for OS")
+	}
+	return filepath.Join(localDir, ImportedKubeconfigDir, unsafeFileNameChars.ReplaceAllString(name, "_")+".yaml"), nil
+}
+
+// storeImportedKubeconfig stores the kubeconfig of the imported context to a temporary file next to the path
+// and returns the path of the temporary file
+func storeImportedKubeconfig(path string, data []byte) (string, error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, configDirMode); err != nil {
+		return "", errors.Wrap(err, "could not make the imported kubeconfig directory")
+	}
+	f, err := os.CreateTemp(dir, filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return "", errors.Wrap(err, "failed to write the imported kubeconfig")
+	}
+	defer f.Close()
+	if err := f.Chmod(configFileMode); err != nil {
+		os.Remove(f.Name())
+		return "", errors.Wrap(err, "failed to write the imported kubeconfig")
+	}
+	if _, err := f.Write(data); err != nil {
+		os.Remove(f.Name())
+		return "", errors.Wrap(err, "failed to write the imported kubeconfig")
+	}
+	return f.Name(), nil
+}
+
+var unsafeFileNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// contextEndpoints returns the endpoints of the context
+func contextEndpoints(ctx *configtypes.Context) []string {
+	var endpoints []string
+	if ctx.ClusterOpts != nil && ctx.ClusterOpts.Endpoint != "" {
+		endpoints = append(endpoints, ctx.ClusterOpts.Endpoint)
+	}
+	if ctx.GlobalOpts != nil && ctx.GlobalOpts.Endpoint != "" {
+		endpoints = append(endpoints, ctx.GlobalOpts.Endpoint)
+	}
+	return endpoints
+}
+
+// matchingContextCerts returns the cert entries that best match the endpoints, without duplicates
+func matchingContextCerts(certs []*configtypes.Cert, endpoints []string) []*configtypes.Cert {
+	var matching []*configtypes.Cert
+	seen := make(map[string]bool)
+	for _, endpoint := range endpoints {
+		hostname, port, err := parseCertHost(endpoint)
+		if err != nil {
+			continue
+		}
+		if cert := matchCert(certs, hostname, port); cert != nil && !seen[cert.Host] {
+			seen[cert.Host] = true
+			matching = append(matching, cert)
+		}
+	}
+	return matching
+}
+
+// minifiedContextKubeconfig returns the kubeconfig of the cluster options minified to its kube context
+func minifiedContextKubeconfig(opts *configtypes.ClusterServer) (*kubeconfig.Config, error) {
+	kc, err := kubeconfig.ReadKubeConfig(opts.Path)
+	if err != nil {
+		return nil, err
+	}
+	kubeContext := opts.Context
+	if kubeContext == "" {
+		kubeContext = kc.CurrentContext
+	}
+	return kubeconfig.MinifyKubeConfig(kc, kubeContext)
+}
+
+// stripContextCredentials removes the tokens, the env values and the sensitive additional metadata of the context.
+// Secret references are kept as they do not hold the secret.
+func stripContextCredentials(ctx *configtypes.Context) {
+	if ctx.GlobalOpts != nil {
+		ctx.GlobalOpts.Auth.AccessToken = ""
+		ctx.GlobalOpts.Auth.IDToken = ""
+		ctx.GlobalOpts.Auth.RefreshToken = ""
+	}
+	for key, value := range ctx.Env {
+		if !IsSecretRef(value) {
+			delete(ctx.Env, key)
+		}
+	}
+	for key, value := range ctx.AdditionalMetadata {
+		if s, ok := value.(string); isSensitiveMetadataKey(key) && !(ok && IsSecretRef(s)) {
+			delete(ctx.AdditionalMetadata, key)
+		}
+	}
+}
+
+// stripKubeconfigCredentials removes the tokens, passwords and keys of the kubeconfig users
+func stripKubeconfigCredentials(kc *kubeconfig.Config) {
+	for _, authInfo := range kc.AuthInfos {
+		user, ok := authInfo.AuthInfo.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, key := range kubeconfigCredentialKeys {
+			delete(user, key)
+		}
+		if provider, ok := user["auth-provider"].(map[string]interface{}); ok {
+			if providerConfig, ok := provider["config"].(map[string]interface{}); ok {
+				for _, key := range kubeconfigAuthProviderCredentialKeys {
+					delete(providerConfig, key)
+				}
+			}
+		}
+	}
+}
+
+// writeContextBundleEntry writes the value as a yaml entry of the context bundle
+func writeContextBundleEntry(tw *tar.Writer, name string, value interface{}, modTime time.Time) error {
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: int64(configFileMode), Size: int64(len(data)), ModTime: modTime}); err != nil {
+		return err
+	}
+	_, err = tw.Write(data)
+	return err
+}
+
+// readContextBundleEntries reads the entries of the context bundle keyed on their name
+func readContextBundleEntries(r io.Reader) (map[string][]byte, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the context bundle")
+	}
+	defer gr.Close()
+	tr := tar.NewReader(gr)
+	entries := make(map[string][]byte)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read the context bundle")
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if header.Size > maxContextBundleEntrySize {
+			return nil, errors.Errorf("entry %s of the context bundle exceeds %d bytes", header.Name, maxContextBundleEntrySize)
+		}
+		var buf bytes.Buffer
+		if _, err := io.Copy(&buf, io.LimitReader(tr, maxContextBundleEntrySize)); err != nil {
+			return nil, errors.Wrap(err, "failed to read the context bundle")
+		}
+		entries[header.Name] = buf.Bytes()
+	}
+	return entries, nil
+}
+
+// decodeContextBundleEntry decodes the yaml entry of the context bundle into the value
+func decodeContextBundleEntry(entries map[string][]byte, name string, value interface{}, required bool) error {
+	data, ok := entries[name]
+	if !ok {
+		if required {
+			return errors.Errorf("context bundle is missing %s", name)
+		}
+		return nil
+	}
+	if err := yaml.Unmarshal(data, value); err != nil {
+		return errors.Wrapf(err, "failed to read %s of the context bundle", name)
+	}
+	return nil
+}
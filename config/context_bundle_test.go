@@ -0,0 +1,381 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/config/internal/kubeconfig"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+const testBundleKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+  - name: dev-cluster
+    cluster:
+      server: https://dev.example.com:6443
+      certificate-authority-data: Y2E=
+  - name: other-cluster
+    cluster:
+      server: https://other.example.com:6443
+users:
+  - name: dev-user
+    user:
+      token: secret-token
+      client-certificate-data: Y2VydA==
+  - name: other-user
+    user:
+      token: other-token
+contexts:
+  - name: dev
+    context:
+      cluster: dev-cluster
+      user: dev-user
+  - name: other
+    context:
+      cluster: other-cluster
+      user: other-user
+current-context: other
+`
+
+func setupTestBundleContext(t *testing.T) {
+	kubeconfigPath := filepath.Join(t.TempDir(), "kubeconfig")
+	require.NoError(t, os.WriteFile(kubeconfigPath, []byte(testBundleKubeconfig), 0600))
+	require.NoError(t, SetContext(&configtypes.Context{
+		Name:        "dev",
+		ContextType: configtypes.ContextTypeK8s,
+		ClusterOpts: &configtypes.ClusterServer{Endpoint: "https://dev.example.com:6443", Path: kubeconfigPath, Context: "dev"},
+		DiscoverySources: []configtypes.PluginDiscovery{
+			{OCI: &configtypes.OCIDiscovery{Name: "dev-source", Image: "example.com/dev:latest"}},
+		},
+	}, false))
+	require.NoError(t, SetContext(&configtypes.Context{
+		Name:        "hub",
+		ContextType: configtypes.ContextTypeTanzu,
+		GlobalOpts: &configtypes.GlobalServer{
+			Endpoint: "https://hub.example.com",
+			Auth:     configtypes.GlobalServerAuth{UserName: "user", AccessToken: "access", IDToken: "id", RefreshToken: "refresh"},
+		},
+	}, false))
+	require.NoError(t, SetCert(&configtypes.Cert{Host: "*.example.com:6443", CACertData: "ca", ClientKeyData: "key"}))
+	require.NoError(t, SetCert(&configtypes.Cert{Host: "hub.example.com", SkipCertVerify: "true"}))
+	require.NoError(t, SetCert(&configtypes.Cert{Host: "unrelated.example.org", Insecure: "true"}))
+}
+
+// readTestBundle returns the entries of the context bundle keyed on their name
+func readTestBundle(t *testing.T, data []byte) map[string][]byte {
+	gr, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+	entries := make(map[string][]byte)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		b, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		entries[header.Name] = b
+	}
+	return entries
+}
+
+func TestExportContext(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+	setupTestBundleContext(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, ExportContext("dev", &buf))
+	entries := readTestBundle(t, buf.Bytes())
+	require.Len(t, entries, 5)
+
+	var manifest ContextBundleManifest
+	require.NoError(t, yaml.Unmarshal(entries["manifest.yaml"], &manifest))
+	assert.Equal(t, ContextBundleKind, manifest.Kind)
+	assert.Equal(t, ContextBundleVersion, manifest.Version)
+	assert.Equal(t, "dev", manifest.Context)
+	assert.False(t, manifest.Credentials)
+
+	var ctx configtypes.Context
+	require.NoError(t, yaml.Unmarshal(entries["context.yaml"], &ctx))
+	assert.Equal(t, "dev", ctx.Name)
+	assert.Empty(t, ctx.DiscoverySources)
+
+	var certs []*configtypes.Cert
+	require.NoError(t, yaml.Unmarshal(entries["certs.yaml"], &certs))
+	assert.Equal(t, []*configtypes.Cert{{Host: "*.example.com:6443", CACertData: "ca"}}, certs)
+
+	var kc kubeconfig.Config
+	require.NoError(t, yaml.Unmarshal(entries["kubeconfig.yaml"], &kc))
+	require.Len(t, kc.Contexts, 1)
+	assert.Equal(t, "dev", kc.CurrentContext)
+	require.Len(t, kc.AuthInfos, 1)
+	assert.Equal(t, map[string]interface{}{"client-certificate-data": "Y2VydA=="}, kc.AuthInfos[0].AuthInfo)
+
+	var sources []configtypes.PluginDiscovery
+	require.NoError(t, yaml.Unmarshal(entries["discovery-sources.yaml"], &sources))
+	require.Len(t, sources, 1)
+	assert.Equal(t, "dev-source", sources[0].OCI.Name)
+
+	// Tokens are stripped by default and included on demand
+	buf.Reset()
+	require.NoError(t, ExportContext("hub", &buf))
+	entries = readTestBundle(t, buf.Bytes())
+	require.NoError(t, yaml.Unmarshal(entries["context.yaml"], &ctx))
+	assert.Equal(t, configtypes.GlobalServerAuth{UserName: "user"}, ctx.GlobalOpts.Auth)
+	require.NoError(t, yaml.Unmarshal(entries["certs.yaml"], &certs))
+	assert.Equal(t, []*configtypes.Cert{{Host: "hub.example.com", SkipCertVerify: "true"}}, certs)
+
+	buf.Reset()
+	require.NoError(t, ExportContext("hub", &buf, WithExportCredentials()))
+	entries = readTestBundle(t, buf.Bytes())
+	require.NoError(t, yaml.Unmarshal(entries["context.yaml"], &ctx))
+	assert.Equal(t, "refresh", ctx.GlobalOpts.Auth.RefreshToken)
+
+	assert.Error(t, ExportContext("missing", &buf))
+}
+
+func TestImportContext(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+	setupTestBundleContext(t)
+	var bundle bytes.Buffer
+	require.NoError(t, ExportContext("dev", &bundle))
+
+	// Import into a fresh config
+	_, cleanUpImport := setupTestConfig(t, &CfgTestData{})
+	defer cleanUpImport()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	ctx, err := ImportContext(bytes.NewReader(bundle.Bytes()), WithImportSetCurrent())
+	require.NoError(t, err)
+	assert.Equal(t, "dev", ctx.Name)
+	localDir, err := LocalDir()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(localDir, ImportedKubeconfigDir, "dev.yaml"), ctx.ClusterOpts.Path)
+
+	stored, err := GetContext("dev")
+	require.NoError(t, err)
+	assert.Equal(t, ctx.ClusterOpts, stored.ClusterOpts)
+	require.Len(t, stored.DiscoverySources, 1)
+	current, err := GetActiveContext(configtypes.ContextTypeK8s)
+	require.NoError(t, err)
+	assert.Equal(t, "dev", current.Name)
+	cert, err := GetCert("*.example.com:6443")
+	require.NoError(t, err)
+	assert.Equal(t, "ca", cert.CACertData)
+
+	kc, err := kubeconfig.ReadKubeConfig(ctx.ClusterOpts.Path)
+	require.NoError(t, err)
+	assert.NotNil(t, kubeconfig.GetContext(kc, "dev"))
+
+	// Name collisions
+	_, err = ImportContext(bytes.NewReader(bundle.Bytes()))
+	assert.EqualError(t, err, `context "dev" already exists`)
+
+	ctx, err = ImportContext(bytes.NewReader(bundle.Bytes()), WithImportCollisionPolicy(ImportCollisionRename))
+	require.NoError(t, err)
+	assert.Equal(t, "dev-2", ctx.Name)
+	assert.Equal(t, filepath.Join(localDir, ImportedKubeconfigDir, "dev-2.yaml"), ctx.ClusterOpts.Path)
+
+	require.NoError(t, SetContext(&configtypes.Context{Name: "dev", ContextType: configtypes.ContextTypeK8s, ClusterOpts: &configtypes.ClusterServer{Endpoint: "https://changed", Path: "changed", Context: "changed"}}, false))
+	ctx, err = ImportContext(bytes.NewReader(bundle.Bytes()), WithImportCollisionPolicy(ImportCollisionOverwrite))
+	require.NoError(t, err)
+	assert.Equal(t, "dev", ctx.Name)
+	stored, err = GetContext("dev")
+	require.NoError(t, err)
+	assert.Equal(t, "https://dev.example.com:6443", stored.ClusterOpts.Endpoint)
+
+	ctx, err = ImportContext(bytes.NewReader(bundle.Bytes()), WithImportName("staging/dev"))
+	require.NoError(t, err)
+	assert.Equal(t, "staging/dev", ctx.Name)
+	assert.Equal(t, filepath.Join(localDir, ImportedKubeconfigDir, "staging_dev.yaml"), ctx.ClusterOpts.Path)
+}
+
+func TestExportContextStripsEnvAndSensitiveMetadata(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+	require.NoError(t, SetContext(&configtypes.Context{
+		Name:        "ctx",
+		ContextType: configtypes.ContextTypeTanzu,
+		GlobalOpts:  &configtypes.GlobalServer{Endpoint: "https://hub.example.com"},
+		Env:         map[string]string{"API_TOKEN": "token", "PROXY_PASSWORD": "${secret:proxy}"},
+		AdditionalMetadata: map[string]interface{}{
+			"region":       "us-west",
+			"apiToken":     "token",
+			"clientSecret": "${secret:client}",
+		},
+	}, false))
+
+	var buf bytes.Buffer
+	require.NoError(t, ExportContext("ctx", &buf))
+	var ctx configtypes.Context
+	require.NoError(t, yaml.Unmarshal(readTestBundle(t, buf.Bytes())["context.yaml"], &ctx))
+	assert.Equal(t, map[string]string{"PROXY_PASSWORD": "${secret:proxy}"}, ctx.Env)
+	assert.Equal(t, map[string]interface{}{"region": "us-west", "clientSecret": "${secret:client}"}, ctx.AdditionalMetadata)
+
+	buf.Reset()
+	require.NoError(t, ExportContext("ctx", &buf, WithExportCredentials()))
+	require.NoError(t, yaml.Unmarshal(readTestBundle(t, buf.Bytes())["context.yaml"], &ctx))
+	assert.Equal(t, "token", ctx.Env["API_TOKEN"])
+	assert.Equal(t, "token", ctx.AdditionalMetadata["apiToken"])
+}
+
+func TestImportContextRejectedLeavesConfigUntouched(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+	kubeconfigPath := filepath.Join(t.TempDir(), "kubeconfig")
+	require.NoError(t, os.WriteFile(kubeconfigPath, []byte(testBundleKubeconfig), 0600))
+	require.NoError(t, SetContext(&configtypes.Context{
+		Name:        "dev",
+		ContextType: configtypes.ContextTypeK8s,
+		ClusterOpts: &configtypes.ClusterServer{Endpoint: "http://dev.example.com:6443", Path: kubeconfigPath, Context: "dev"},
+	}, false))
+	require.NoError(t, SetCert(&configtypes.Cert{Host: "dev.example.com:6443", SkipCertVerify: "true"}))
+	var bundle bytes.Buffer
+	require.NoError(t, ExportContext("dev", &bundle))
+
+	// Import into a config holding a context of the same name, whose policy rejects the imported context
+	_, cleanUpImport := setupTestConfig(t, &CfgTestData{})
+	defer cleanUpImport()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	existing := &configtypes.Context{
+		Name:        "dev",
+		ContextType: configtypes.ContextTypeK8s,
+		ClusterOpts: &configtypes.ClusterServer{Endpoint: "https://dev.example.com:6443", Path: kubeconfigPath, Context: "dev"},
+	}
+	require.NoError(t, SetContext(existing, true))
+	setupTestPolicy(t, testPolicy)
+
+	_, err := ImportContext(bytes.NewReader(bundle.Bytes()), WithImportCollisionPolicy(ImportCollisionOverwrite))
+	var violation *PolicyViolation
+	require.ErrorAs(t, err, &violation)
+
+	stored, err := GetContext("dev")
+	require.NoError(t, err)
+	assert.Equal(t, existing.ClusterOpts, stored.ClusterOpts)
+	current, err := GetActiveContext(configtypes.ContextTypeK8s)
+	require.NoError(t, err)
+	assert.Equal(t, "dev", current.Name)
+	exists, err := CertExists("dev.example.com:6443")
+	require.NoError(t, err)
+	assert.False(t, exists)
+	localDir, err := LocalDir()
+	require.NoError(t, err)
+	files, err := os.ReadDir(filepath.Join(localDir, ImportedKubeconfigDir))
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestImportContextCertCollisions(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+	kubeconfigPath := filepath.Join(t.TempDir(), "kubeconfig")
+	require.NoError(t, os.WriteFile(kubeconfigPath, []byte(testBundleKubeconfig), 0600))
+	require.NoError(t, SetContext(&configtypes.Context{
+		Name:        "dev",
+		ContextType: configtypes.ContextTypeK8s,
+		ClusterOpts: &configtypes.ClusterServer{Endpoint: "https://dev.example.com:6443", Path: kubeconfigPath, Context: "dev"},
+	}, false))
+	require.NoError(t, SetCert(&configtypes.Cert{Host: "dev.example.com:6443", CACertData: "dev-ca"}))
+	var bundle bytes.Buffer
+	require.NoError(t, ExportContext("dev", &bundle))
+
+	_, cleanUpImport := setupTestConfig(t, &CfgTestData{})
+	defer cleanUpImport()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	require.NoError(t, SetCert(&configtypes.Cert{Host: "*.example.com:6443", CACertData: "wildcard-ca"}))
+
+	// A wildcard cert matching the host does not prevent the import of the cert of the host
+	_, err := ImportContext(bytes.NewReader(bundle.Bytes()))
+	require.NoError(t, err)
+	cert, err := GetCert("dev.example.com:6443")
+	require.NoError(t, err)
+	assert.Equal(t, "dev-ca", cert.CACertData)
+	cert, err = GetCert("*.example.com:6443")
+	require.NoError(t, err)
+	assert.Equal(t, "wildcard-ca", cert.CACertData)
+
+	// The cert of the same host is kept unless overwritten
+	require.NoError(t, SetCert(&configtypes.Cert{Host: "dev.example.com:6443", CACertData: "existing-ca"}))
+	_, err = ImportContext(bytes.NewReader(bundle.Bytes()), WithImportCollisionPolicy(ImportCollisionRename))
+	require.NoError(t, err)
+	cert, err = GetCert("dev.example.com:6443")
+	require.NoError(t, err)
+	assert.Equal(t, "existing-ca", cert.CACertData)
+	_, err = ImportContext(bytes.NewReader(bundle.Bytes()), WithImportCollisionPolicy(ImportCollisionOverwrite))
+	require.NoError(t, err)
+	cert, err = GetCert("dev.example.com:6443")
+	require.NoError(t, err)
+	assert.Equal(t, "dev-ca", cert.CACertData)
+}
+
+func TestImportInvalidContextBundle(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+
+	writeBundle := func(entries ...contextBundleEntry) []byte {
+		var buf bytes.Buffer
+		gw := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gw)
+		for _, entry := range entries {
+			require.NoError(t, writeContextBundleEntry(tw, entry.name, entry.value, time.Time{}))
+		}
+		require.NoError(t, tw.Close())
+		require.NoError(t, gw.Close())
+		return buf.Bytes()
+	}
+	ctx := &configtypes.Context{Name: "ctx", ContextType: configtypes.ContextTypeK8s}
+
+	tests := []struct {
+		name   string
+		bundle []byte
+		errStr string
+	}{
+		{name: "not an archive", bundle: []byte("context"), errStr: "failed to read the context bundle"},
+		{name: "missing manifest", bundle: writeBundle(contextBundleEntry{contextBundleContextEntry, ctx}), errStr: "context bundle is missing manifest.yaml"},
+		{
+			name:   "future version",
+			bundle: writeBundle(contextBundleEntry{contextBundleManifestEntry, &ContextBundleManifest{Kind: ContextBundleKind, Version: 2}}, contextBundleEntry{contextBundleContextEntry, ctx}),
+			errStr: "unsupported context bundle version 2, supported versions are up to 1",
+		},
+		{
+			name:   "invalid kind",
+			bundle: writeBundle(contextBundleEntry{contextBundleManifestEntry, &ContextBundleManifest{Kind: "Config", Version: 1}}),
+			errStr: `invalid context bundle kind "Config"`,
+		},
+		{
+			name:   "missing context",
+			bundle: writeBundle(contextBundleEntry{contextBundleManifestEntry, &ContextBundleManifest{Kind: ContextBundleKind, Version: 1}}),
+			errStr: "context bundle is missing context.yaml",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ImportContext(bytes.NewReader(tc.bundle))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.errStr)
+		})
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/config/nodeutils"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+// IsFeatureEnabledForContext checks and returns whether specific plugin and key is true for the specified context.
+// The feature flag is evaluated in the following order and the first match wins:
+//  1. feature flag set on the context
+//  2. feature flag set on the context type of the context
+//  3. global feature flag
+func IsFeatureEnabledForContext(plugin, key, contextName string) (bool, error) {
+	// Retrieve client config node
+	node, err := getClientConfigNode()
+	if err != nil {
+		return false, err
+	}
+	val, err := getFeatureForContext(node, plugin, key, contextName)
+	if err != nil {
+		return false, err
+	}
+	return strings.EqualFold(val, "true"), nil
+}
+
+func getFeatureForContext(node *yaml.Node, plugin, key, contextName string) (string, error) {
+	if err := validateFeatureKey(plugin, key); err != nil {
+		return "", err
+	}
+
+	cfg, err := convertNodeToClientConfig(node)
+	if err != nil {
+		return "", err
+	}
+	ctx, err := findContext(cfg, contextName)
+	if err != nil {
+		return "", err
+	}
+	if val, ok := cfg.GetFeatureForContext(plugin, key, ctx); ok {
+		return val, nil
+	}
+	return "", errors.New("not found")
+}
+
+// SetContextFeature add or update plugin key value scoped to the specified context
+func SetContextFeature(contextName, plugin, key, value string) error {
+	// The context feature overrides the global feature, so it cannot be set if the global feature is locked
+	if err := checkSystemConfigLock(DiffSectionFeatures, plugin+"."+key); err != nil {
+		return err
+	}
+	// Retrieve client config node
+	AcquireTanzuConfigLock()
+	defer ReleaseTanzuConfigLock()
+	node, err := getClientConfigNodeNoLock()
+	if err != nil {
+		return err
+	}
+	persist, err := setContextFeature(node, contextName, plugin, key, value)
+	if err != nil {
+		return err
+	}
+	if persist {
+		return persistConfig(node)
+	}
+	return nil
+}
+
+func setContextFeature(node *yaml.Node, contextName, plugin, key, value string) (bool, error) {
+	if err := validateFeatureKey(plugin, key); err != nil {
+		return false, err
+	}
+	// check if value is empty
+	if value == "" {
+		return false, errors.New("value cannot be empty")
+	}
+	contextNode, err := getContextNode(node, contextName)
+	if err != nil {
+		return false, err
+	}
+	keys := []nodeutils.Key{
+		{Name: KeyFeatures, Type: yaml.MappingNode},
+		{Name: plugin, Type: yaml.MappingNode},
+	}
+	return setPluginFeature(contextNode, keys, key, value)
+}
+
+// DeleteContextFeature deletes the plugin key scoped to the specified context
+func DeleteContextFeature(contextName, plugin, key string) error {
+	// Retrieve client config node
+	AcquireTanzuConfigLock()
+	defer ReleaseTanzuConfigLock()
+	node, err := getClientConfigNodeNoLock()
+	if err != nil {
+		return err
+	}
+	if err := validateFeatureKey(plugin, key); err != nil {
+		return err
+	}
+	contextNode, err := getContextNode(node, contextName)
+	if err != nil {
+		return err
+	}
+	keys := []nodeutils.Key{
+		{Name: KeyFeatures},
+		{Name: plugin},
+	}
+	if err := deletePluginFeature(contextNode, keys, key); err != nil {
+		return err
+	}
+	return persistConfig(node)
+}
+
+// SetContextTypeFeature add or update plugin key value scoped to the specified context type
+func SetContextTypeFeature(contextType configtypes.ContextType, plugin, key, value string) error {
+	// The context type feature overrides the global feature, so it cannot be set if the global feature is locked
+	if err := checkSystemConfigLock(DiffSectionFeatures, plugin+"."+key); err != nil {
+		return err
+	}
+	// Retrieve client config node
+	AcquireTanzuConfigLock()
+	defer ReleaseTanzuConfigLock()
+	node, err := getClientConfigNodeNoLock()
+	if err != nil {
+		return err
+	}
+	persist, err := setContextTypeFeature(node, contextType, plugin, key, value)
+	if err != nil {
+		return err
+	}
+	if persist {
+		return persistConfig(node)
+	}
+	return nil
+}
+
+func setContextTypeFeature(node *yaml.Node, contextType configtypes.ContextType, plugin, key, value string) (bool, error) {
+	if err := validateContextType(contextType); err != nil {
+		return false, err
+	}
+	if err := validateFeatureKey(plugin, key); err != nil {
+		return false, err
+	}
+	// check if value is empty
+	if value == "" {
+		return false, errors.New("value cannot be empty")
+	}
+	keys := []nodeutils.Key{
+		{Name: KeyClientOptions, Type: yaml.MappingNode},
+		{Name: KeyContextTypeFeatures, Type: yaml.MappingNode},
+		{Name: string(contextType), Type: yaml.MappingNode},
+		{Name: plugin, Type: yaml.MappingNode},
+	}
+	return setPluginFeature(node.Content[0], keys, key, value)
+}
+
+// DeleteContextTypeFeature deletes the plugin key scoped to the specified context type
+func DeleteContextTypeFeature(contextType configtypes.ContextType, plugin, key string) error {
+	// Retrieve client config node
+	AcquireTanzuConfigLock()
+	defer ReleaseTanzuConfigLock()
+	node, err := getClientConfigNodeNoLock()
+	if err != nil {
+		return err
+	}
+	if err := validateContextType(contextType); err != nil {
+		return err
+	}
+	if err := validateFeatureKey(plugin, key); err != nil {
+		return err
+	}
+	keys := []nodeutils.Key{
+		{Name: KeyClientOptions},
+		{Name: KeyContextTypeFeatures},
+		{Name: string(contextType)},
+		{Name: plugin},
+	}
+	if err := deletePluginFeature(node.Content[0], keys, key); err != nil {
+		return err
+	}
+	return persistConfig(node)
+}
+
+func validateFeatureKey(plugin, key string) error {
+	// check if plugin is empty
+	if plugin == "" {
+		return errors.New("plugin cannot be empty")
+	}
+
+	// check if key is empty
+	if key == "" {
+		return errors.New("key cannot be empty")
+	}
+	return nil
+}
+
+func validateContextType(contextType configtypes.ContextType) error {
+	for _, supported := range configtypes.SupportedContextTypes {
+		if contextType == supported {
+			return nil
+		}
+	}
+	return errors.Errorf("unsupported context type %q", contextType)
+}
@@ -0,0 +1,155 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+func TestIsFeatureEnabledForContext(t *testing.T) {
+	// Setup config data
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+
+	defer func() {
+		cleanUp()
+	}()
+
+	err := SetContext(&configtypes.Context{
+		Name:        "test-tanzu",
+		ContextType: configtypes.ContextTypeTanzu,
+		ClusterOpts: &configtypes.ClusterServer{Endpoint: "test-endpoint"},
+	}, false)
+	assert.NoError(t, err)
+	err = SetContext(&configtypes.Context{
+		Name:        "test-tanzu-2",
+		ContextType: configtypes.ContextTypeTanzu,
+		ClusterOpts: &configtypes.ClusterServer{Endpoint: "test-endpoint"},
+	}, false)
+	assert.NoError(t, err)
+	err = SetContext(&configtypes.Context{
+		Name:        "test-k8s",
+		ContextType: configtypes.ContextTypeK8s,
+		ClusterOpts: &configtypes.ClusterServer{Endpoint: "test-endpoint"},
+	}, false)
+	assert.NoError(t, err)
+
+	// Only global flag set
+	assert.NoError(t, SetFeature("global", "hub", "false"))
+	ok, err := IsFeatureEnabledForContext("global", "hub", "test-tanzu")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// Context type flag overrides global flag
+	assert.NoError(t, SetContextTypeFeature(configtypes.ContextTypeTanzu, "global", "hub", "true"))
+	ok, err = IsFeatureEnabledForContext("global", "hub", "test-tanzu")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = IsFeatureEnabledForContext("global", "hub", "test-k8s")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// Context flag overrides context type flag
+	assert.NoError(t, SetContextFeature("test-tanzu", "global", "hub", "false"))
+	ok, err = IsFeatureEnabledForContext("global", "hub", "test-tanzu")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	ok, err = IsFeatureEnabledForContext("global", "hub", "test-tanzu-2")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// Global flag keeps its meaning
+	ok, err = IsFeatureEnabled("global", "hub")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// Context features are retained when the context is updated
+	ctx, err := GetContext("test-tanzu")
+	assert.NoError(t, err)
+	assert.Equal(t, configtypes.FeatureMap{"hub": "false"}, ctx.Features["global"])
+	ctx.ClusterOpts.Endpoint = "updated-endpoint"
+	assert.NoError(t, SetContext(ctx, false))
+	ok, err = IsFeatureEnabledForContext("global", "hub", "test-tanzu")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// Deleting the context and context type flags falls back to the global flag
+	assert.NoError(t, DeleteContextFeature("test-tanzu", "global", "hub"))
+	assert.NoError(t, DeleteContextTypeFeature(configtypes.ContextTypeTanzu, "global", "hub"))
+	ok, err = IsFeatureEnabledForContext("global", "hub", "test-tanzu")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	_, err = IsFeatureEnabledForContext("global", "unknown", "test-tanzu")
+	assert.EqualError(t, err, "not found")
+	_, err = IsFeatureEnabledForContext("global", "hub", "unknown")
+	assert.EqualError(t, err, "context unknown not found")
+}
+
+func TestSetContextFeatureErrors(t *testing.T) {
+	// Setup config data
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+
+	defer func() {
+		cleanUp()
+	}()
+
+	err := SetContext(&configtypes.Context{
+		Name:        "test-k8s",
+		ContextType: configtypes.ContextTypeK8s,
+		ClusterOpts: &configtypes.ClusterServer{Endpoint: "test-endpoint"},
+	}, false)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		context string
+		plugin  string
+		key     string
+		value   string
+		errStr  string
+	}{
+		{
+			name:    "empty plugin",
+			context: "test-k8s",
+			key:     "feature1",
+			value:   "true",
+			errStr:  "plugin cannot be empty",
+		},
+		{
+			name:    "empty key",
+			context: "test-k8s",
+			plugin:  "global",
+			value:   "true",
+			errStr:  "key cannot be empty",
+		},
+		{
+			name:    "empty value",
+			context: "test-k8s",
+			plugin:  "global",
+			key:     "feature1",
+			errStr:  "value cannot be empty",
+		},
+		{
+			name:    "missing context",
+			context: "unknown",
+			plugin:  "global",
+			key:     "feature1",
+			value:   "true",
+			errStr:  "context unknown not found",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := SetContextFeature(tc.context, tc.plugin, tc.key, tc.value)
+			assert.EqualError(t, err, tc.errStr)
+		})
+	}
+
+	err = SetContextTypeFeature("unknown", "global", "feature1", "true")
+	assert.EqualError(t, err, `unsupported context type "unknown"`)
+}
@@ -0,0 +1,177 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/config/nodeutils"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+// RenameContext renames the context along with the references to it i.e. the current context, the context
+// sets and the legacy server and current server. The kubeconfig of an imported context, stored under
+// LocalDir()/kubeconfigs, is renamed along with it. All the other fields of the context are kept as is.
+func RenameContext(oldName, newName string) error {
+	if oldName == "" || newName == "" {
+		return errors.New("context name cannot be empty")
+	}
+	if oldName == newName {
+		return nil
+	}
+	AcquireTanzuConfigLock()
+	defer ReleaseTanzuConfigLock()
+	node, err := getClientConfigNodeNoLock()
+	if err != nil {
+		return err
+	}
+	ctx, err := getContext(node, oldName)
+	if err != nil {
+		return err
+	}
+	if _, err := getContext(node, newName); err == nil {
+		return errors.Errorf("context %q already exists", newName)
+	}
+	oldKubeconfigPath, newKubeconfigPath, err := renamedImportedKubeconfigPaths(ctx, newName)
+	if err != nil {
+		return err
+	}
+
+	renameSequenceItem(node, KeyContexts, oldName, newName)
+	renameSequenceItem(node, KeyServers, oldName, newName)
+	if currentContextNode := nodeutils.FindNode(node.Content[0], nodeutils.WithKeys([]nodeutils.Key{{Name: KeyCurrentContext}})); currentContextNode != nil {
+		for i := 1; i < len(currentContextNode.Content); i += 2 {
+			if currentContextNode.Content[i].Value == oldName {
+				currentContextNode.Content[i].Value = newName
+			}
+		}
+	}
+	if currentServerNode := nodeutils.FindNode(node.Content[0], nodeutils.WithKeys([]nodeutils.Key{{Name: KeyCurrentServer}})); currentServerNode != nil && currentServerNode.Value == oldName {
+		currentServerNode.Value = newName
+	}
+	if contextSetsNode := nodeutils.FindNode(node.Content[0], nodeutils.WithKeys([]nodeutils.Key{{Name: KeyContextSets}})); contextSetsNode != nil {
+		for _, contextSetNode := range contextSetsNode.Content {
+			membersNode := nodeutils.FindNode(contextSetNode, nodeutils.WithKeys([]nodeutils.Key{{Name: "contexts"}}))
+			if membersNode == nil {
+				continue
+			}
+			for i := 1; i < len(membersNode.Content); i += 2 {
+				if membersNode.Content[i].Value == oldName {
+					membersNode.Content[i].Value = newName
+				}
+			}
+		}
+	}
+	if oldKubeconfigPath == "" {
+		return persistConfig(node)
+	}
+
+	// The kubeconfig is renamed before the config is written and renamed back if the write fails,
+	// so that the context never refers to a missing kubeconfig
+	setImportedKubeconfigPath(node, newName, oldKubeconfigPath, newKubeconfigPath)
+	if err := os.Rename(oldKubeconfigPath, newKubeconfigPath); err != nil {
+		return errors.Wrap(err, "failed to rename the kubeconfig of the imported context")
+	}
+	if err := persistConfig(node); err != nil {
+		_ = os.Rename(newKubeconfigPath, oldKubeconfigPath)
+		return err
+	}
+	return nil
+}
+
+// renamedImportedKubeconfigPaths returns the current and new path of the kubeconfig of the context if it was
+// stored by ImportContext, or empty paths if the context does not refer to the kubeconfig of an imported context
+func renamedImportedKubeconfigPaths(ctx *configtypes.Context, newName string) (oldPath, newPath string, err error) {
+	if ctx.ClusterOpts == nil || ctx.ClusterOpts.Path == "" {
+		return "", "", nil
+	}
+	oldPath, err = importedKubeconfigPath(ctx.Name)
+	if err != nil {
+		return "", "", err
+	}
+	if filepath.Clean(ctx.ClusterOpts.Path) != oldPath {
+		return "", "", nil
+	}
+	if exists, err := fileExists(oldPath); err != nil || !exists {
+		return "", "", err
+	}
+	newPath, err = importedKubeconfigPath(newName)
+	if err != nil {
+		return "", "", err
+	}
+	exists, err := fileExists(newPath)
+	if err != nil {
+		return "", "", err
+	}
+	if exists {
+		return "", "", errors.Errorf("the kubeconfig %s of the imported context already exists", newPath)
+	}
+	return oldPath, newPath, nil
+}
+
+// setImportedKubeconfigPath updates the kubeconfig path of the context and of its legacy server mirror
+func setImportedKubeconfigPath(node *yaml.Node, name, oldPath, newPath string) {
+	for key, optsKey := range map[string]string{KeyContexts: "clusterOpts", KeyServers: "managementClusterOpts"} {
+		sequenceNode := nodeutils.FindNode(node.Content[0], nodeutils.WithKeys([]nodeutils.Key{{Name: key}}))
+		if sequenceNode == nil {
+			continue
+		}
+		for _, itemNode := range sequenceNode.Content {
+			if index := nodeutils.GetNodeIndex(itemNode.Content, "name"); index == -1 || itemNode.Content[index].Value != name {
+				continue
+			}
+			pathNode := nodeutils.FindNode(itemNode, nodeutils.WithKeys([]nodeutils.Key{{Name: optsKey}, {Name: "path"}}))
+			if pathNode != nil && filepath.Clean(pathNode.Value) == oldPath {
+				pathNode.Value = newPath
+			}
+		}
+	}
+}
+
+// CloneContext adds a copy of the source context with the destination name. The mutate function, if not nil,
+// is applied to the copy before it is added, e.g. to point it to another kube context. The copy is not set as current.
+func CloneContext(srcName, dstName string, mutate func(c *configtypes.Context)) error {
+	if srcName == "" || dstName == "" {
+		return errors.New("context name cannot be empty")
+	}
+	AcquireTanzuConfigLock()
+	defer ReleaseTanzuConfigLock()
+	node, err := getClientConfigNodeNoLock()
+	if err != nil {
+		return err
+	}
+	// The context is decoded from the node, so it does not share any data with the source context
+	ctx, err := getContext(node, srcName)
+	if err != nil {
+		return err
+	}
+	if _, err := getContext(node, dstName); err == nil {
+		return errors.Errorf("context %q already exists", dstName)
+	}
+	ctx.Name = dstName
+	if mutate != nil {
+		mutate(ctx)
+		if ctx.Name != dstName {
+			return errors.Errorf("context name cannot be changed from %q to %q", dstName, ctx.Name)
+		}
+	}
+	return setContextNoLock(ctx, false)
+}
+
+// renameSequenceItem renames the item of the sequence of the key that has the old name
+func renameSequenceItem(node *yaml.Node, key, oldName, newName string) {
+	sequenceNode := nodeutils.FindNode(node.Content[0], nodeutils.WithKeys([]nodeutils.Key{{Name: key}}))
+	if sequenceNode == nil {
+		return
+	}
+	for _, itemNode := range sequenceNode.Content {
+		if index := nodeutils.GetNodeIndex(itemNode.Content, "name"); index != -1 && itemNode.Content[index].Value == oldName {
+			itemNode.Content[index].Value = newName
+		}
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+func setupTestRenameContexts(t *testing.T) {
+	require.NoError(t, SetContext(&configtypes.Context{
+		Name:               "mgmt",
+		ContextType:        configtypes.ContextTypeK8s,
+		ClusterOpts:        &configtypes.ClusterServer{Endpoint: "https://mgmt", Path: "kubeconfig", Context: "mgmt-admin", IsManagementCluster: true},
+		AdditionalMetadata: map[string]interface{}{"owner": "team-a"},
+		Env:                map[string]string{"FOO": "bar"},
+	}, true))
+	require.NoError(t, SetContext(&configtypes.Context{
+		Name:        "tmc",
+		ContextType: configtypes.ContextTypeTMC,
+		GlobalOpts:  &configtypes.GlobalServer{Endpoint: "tmc.example.com"},
+	}, true))
+}
+
+func TestRenameContext(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+	setupTestRenameContexts(t)
+
+	require.NoError(t, RenameContext("mgmt", "management"))
+
+	_, err := GetContext("mgmt")
+	assert.Error(t, err)
+	ctx, err := GetContext("management")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"owner": "team-a"}, ctx.AdditionalMetadata)
+	assert.Equal(t, map[string]string{"FOO": "bar"}, ctx.Env)
+	assert.Equal(t, "mgmt-admin", ctx.ClusterOpts.Context)
+
+	current, err := GetActiveContext(configtypes.ContextTypeK8s)
+	require.NoError(t, err)
+	assert.Equal(t, "management", current.Name)
+	current, err = GetActiveContext(configtypes.ContextTypeTMC)
+	require.NoError(t, err)
+	assert.Equal(t, "tmc", current.Name)
+
+	// The legacy server mirror is renamed as well
+	_, err = GetServer("mgmt") //nolint:staticcheck
+	assert.Error(t, err)
+	server, err := GetServer("management") //nolint:staticcheck
+	require.NoError(t, err)
+	assert.Equal(t, "kubeconfig", server.ManagementClusterOpts.Path)
+	currentServer, err := GetCurrentServer() //nolint:staticcheck
+	require.NoError(t, err)
+	assert.Equal(t, "management", currentServer.Name)
+
+	assert.EqualError(t, RenameContext("missing", "other"), "context missing not found")
+	assert.EqualError(t, RenameContext("management", "tmc"), `context "tmc" already exists`)
+	assert.EqualError(t, RenameContext("management", ""), "context name cannot be empty")
+	assert.NoError(t, RenameContext("tmc", "tmc"))
+}
+
+func TestRenameImportedContext(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+	kubeconfigPath := filepath.Join(t.TempDir(), "kubeconfig")
+	require.NoError(t, os.WriteFile(kubeconfigPath, []byte(testBundleKubeconfig), 0600))
+	require.NoError(t, SetContext(&configtypes.Context{
+		Name:        "dev",
+		ContextType: configtypes.ContextTypeK8s,
+		ClusterOpts: &configtypes.ClusterServer{Endpoint: "https://dev.example.com:6443", Path: kubeconfigPath, Context: "dev"},
+	}, false))
+	var bundle bytes.Buffer
+	require.NoError(t, ExportContext("dev", &bundle))
+
+	_, cleanUpImport := setupTestConfig(t, &CfgTestData{})
+	defer cleanUpImport()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	imported, err := ImportContext(bytes.NewReader(bundle.Bytes()))
+	require.NoError(t, err)
+
+	// The kubeconfig of the imported context is renamed along with it
+	require.NoError(t, RenameContext("dev", "staging"))
+	localDir, err := LocalDir()
+	require.NoError(t, err)
+	renamedPath := filepath.Join(localDir, ImportedKubeconfigDir, "staging.yaml")
+	ctx, err := GetContext("staging")
+	require.NoError(t, err)
+	assert.Equal(t, renamedPath, ctx.ClusterOpts.Path)
+	assert.FileExists(t, renamedPath)
+	assert.NoFileExists(t, imported.ClusterOpts.Path)
+
+	// A context imported again under the former name does not overwrite the kubeconfig of the renamed context
+	renamed, err := os.ReadFile(renamedPath)
+	require.NoError(t, err)
+	_, err = ImportContext(bytes.NewReader(bundle.Bytes()))
+	require.NoError(t, err)
+	assert.FileExists(t, imported.ClusterOpts.Path)
+	data, err := os.ReadFile(renamedPath)
+	require.NoError(t, err)
+	assert.Equal(t, renamed, data)
+
+	// The kubeconfig of another imported context is not overwritten by a rename
+	require.NoError(t, os.WriteFile(filepath.Join(localDir, ImportedKubeconfigDir, "other.yaml"), nil, 0600))
+	assert.EqualError(t, RenameContext("dev", "other"), fmt.Sprintf("the kubeconfig %s of the imported context already exists", filepath.Join(localDir, ImportedKubeconfigDir, "other.yaml")))
+	_, err = GetContext("dev")
+	assert.NoError(t, err)
+}
+
+func TestCloneContext(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+	setupTestRenameContexts(t)
+
+	require.NoError(t, CloneContext("mgmt", "mgmt-readonly", func(c *configtypes.Context) {
+		c.ClusterOpts.Context = "mgmt-viewer"
+		c.AdditionalMetadata["owner"] = "team-b"
+	}))
+
+	clone, err := GetContext("mgmt-readonly")
+	require.NoError(t, err)
+	assert.Equal(t, "mgmt-viewer", clone.ClusterOpts.Context)
+	assert.Equal(t, "https://mgmt", clone.ClusterOpts.Endpoint)
+	assert.Equal(t, map[string]interface{}{"owner": "team-b"}, clone.AdditionalMetadata)
+	assert.Equal(t, map[string]string{"FOO": "bar"}, clone.Env)
+
+	// The source context and the current context are unchanged
+	src, err := GetContext("mgmt")
+	require.NoError(t, err)
+	assert.Equal(t, "mgmt-admin", src.ClusterOpts.Context)
+	assert.Equal(t, map[string]interface{}{"owner": "team-a"}, src.AdditionalMetadata)
+	current, err := GetActiveContext(configtypes.ContextTypeK8s)
+	require.NoError(t, err)
+	assert.Equal(t, "mgmt", current.Name)
+
+	require.NoError(t, CloneContext("tmc", "tmc-copy", nil))
+	_, err = GetContext("tmc-copy")
+	require.NoError(t, err)
+
+	assert.EqualError(t, CloneContext("mgmt", "tmc", nil), `context "tmc" already exists`)
+	assert.EqualError(t, CloneContext("missing", "other", nil), "context missing not found")
+	assert.EqualError(t, CloneContext("mgmt", "other", func(c *configtypes.Context) { c.Name = "renamed" }), `context name cannot be changed from "other" to "renamed"`)
+}
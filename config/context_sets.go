@@ -0,0 +1,222 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/config/nodeutils"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+// GetContextSet retrieves the context set by name
+func GetContextSet(name string) (*configtypes.ContextSet, error) {
+	node, err := getClientConfigNode()
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := convertNodeToClientConfig(node)
+	if err != nil {
+		return nil, err
+	}
+	return getContextSet(cfg, name)
+}
+
+// SetContextSet adds or replaces the context set with the contexts keyed on their context type.
+// Every context must exist and be of the type it is keyed on. As only one context of a type other than
+// mission-control can be active at a time, the set can hold at most one context besides the mission-control one,
+// so a set of a tanzu, a kubernetes and a mission-control context is rejected.
+func SetContextSet(name string, contexts map[configtypes.ContextType]string) error {
+	if name == "" {
+		return errors.New("context set name cannot be empty")
+	}
+	if len(contexts) == 0 {
+		return errors.New("context set must hold at least one context")
+	}
+	AcquireTanzuConfigLock()
+	defer ReleaseTanzuConfigLock()
+	node, err := getClientConfigNodeNoLock()
+	if err != nil {
+		return err
+	}
+	cfg, err := convertNodeToClientConfig(node)
+	if err != nil {
+		return err
+	}
+	contextSet := &configtypes.ContextSet{Name: name, Contexts: contexts}
+	if err := validateContextSet(cfg, contextSet); err != nil {
+		return err
+	}
+	contextSetNode, err := convertObjectToNode(contextSet)
+	if err != nil {
+		return err
+	}
+
+	contextSetsNode := nodeutils.FindNode(node.Content[0], nodeutils.WithForceCreate(), nodeutils.WithKeys([]nodeutils.Key{{Name: KeyContextSets, Type: yaml.SequenceNode}}))
+	if contextSetsNode == nil {
+		return nodeutils.ErrNodeNotFound
+	}
+	exists := false
+	for i, itemNode := range contextSetsNode.Content {
+		if index := nodeutils.GetNodeIndex(itemNode.Content, "name"); index != -1 && itemNode.Content[index].Value == name {
+			equal, err := nodeutils.Equal(itemNode, contextSetNode.Content[0])
+			if err != nil {
+				return err
+			}
+			if equal {
+				return nil
+			}
+			contextSetsNode.Content[i] = contextSetNode.Content[0]
+			exists = true
+			break
+		}
+	}
+	if !exists {
+		contextSetsNode.Content = append(contextSetsNode.Content, contextSetNode.Content[0])
+	}
+	return persistConfig(node)
+}
+
+// DeleteContextSet deletes the context set by name. The contexts of the set are kept.
+func DeleteContextSet(name string) error {
+	AcquireTanzuConfigLock()
+	defer ReleaseTanzuConfigLock()
+	node, err := getClientConfigNodeNoLock()
+	if err != nil {
+		return err
+	}
+	contextSetsNode := nodeutils.FindNode(node.Content[0], nodeutils.WithKeys([]nodeutils.Key{{Name: KeyContextSets}}))
+	if contextSetsNode == nil {
+		return errors.Errorf("context set %q not found", name)
+	}
+	var result []*yaml.Node
+	for _, itemNode := range contextSetsNode.Content {
+		if index := nodeutils.GetNodeIndex(itemNode.Content, "name"); index != -1 && itemNode.Content[index].Value == name {
+			continue
+		}
+		result = append(result, itemNode)
+	}
+	if len(result) == len(contextSetsNode.Content) {
+		return errors.Errorf("context set %q not found", name)
+	}
+	contextSetsNode.Content = result
+	if currentNode := nodeutils.FindNode(node.Content[0], nodeutils.WithKeys([]nodeutils.Key{{Name: KeyCurrentContextSet}})); currentNode != nil && currentNode.Value == name {
+		currentNode.Value = ""
+	}
+	return persistConfig(node)
+}
+
+// ActivateContextSet sets every context of the context set as the active context of its type in a single write.
+// Nothing is activated if any context of the set no longer exists or no longer has the type it is keyed on.
+func ActivateContextSet(name string) error {
+	AcquireTanzuConfigLock()
+	defer ReleaseTanzuConfigLock()
+	node, err := getClientConfigNodeNoLock()
+	if err != nil {
+		return err
+	}
+	cfg, err := convertNodeToClientConfig(node)
+	if err != nil {
+		return err
+	}
+	contextSet, err := getContextSet(cfg, name)
+	if err != nil {
+		return err
+	}
+	if err := validateContextSet(cfg, contextSet); err != nil {
+		return err
+	}
+
+	for _, contextType := range sortedContextSetTypes(contextSet) {
+		contextName := contextSet.Contexts[contextType]
+		if _, err := setCurrentContext(node, contextName, contextType); err != nil {
+			return err
+		}
+		if contextType == configtypes.ContextTypeK8s {
+			if _, err := setCurrentServer(node, contextName); err != nil {
+				return err
+			}
+		}
+	}
+	currentNode := nodeutils.FindNode(node.Content[0], nodeutils.WithForceCreate(), nodeutils.WithKeys([]nodeutils.Key{{Name: KeyCurrentContextSet, Type: yaml.ScalarNode}}))
+	if currentNode == nil {
+		return nodeutils.ErrNodeNotFound
+	}
+	currentNode.Value = name
+	return persistConfig(node)
+}
+
+// GetActiveContextSet retrieves the context set activated last, as long as all its contexts are still active
+func GetActiveContextSet() (*configtypes.ContextSet, error) {
+	node, err := getClientConfigNode()
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := convertNodeToClientConfig(node)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.CurrentContextSet == "" {
+		return nil, errors.New("no active context set")
+	}
+	contextSet, err := getContextSet(cfg, cfg.CurrentContextSet)
+	if err != nil {
+		return nil, errors.New("no active context set")
+	}
+	for contextType, contextName := range contextSet.Contexts {
+		if cfg.CurrentContext[contextType] != contextName {
+			return nil, errors.Errorf("context set %q is no longer active: the active %s context is not %q", contextSet.Name, contextType, contextName)
+		}
+	}
+	return contextSet, nil
+}
+
+func getContextSet(cfg *configtypes.ClientConfig, name string) (*configtypes.ContextSet, error) {
+	if name == "" {
+		return nil, errors.New("context set name cannot be empty")
+	}
+	for _, contextSet := range cfg.ContextSets {
+		if contextSet.Name == name {
+			return contextSet, nil
+		}
+	}
+	return nil, errors.Errorf("context set %q not found", name)
+}
+
+// validateContextSet validates every context of the set exists with the type it is keyed on and that
+// the contexts can be active together
+func validateContextSet(cfg *configtypes.ClientConfig, contextSet *configtypes.ContextSet) error {
+	var exclusiveType configtypes.ContextType
+	for _, contextType := range sortedContextSetTypes(contextSet) {
+		contextName := contextSet.Contexts[contextType]
+		ctx, err := cfg.GetContext(contextName)
+		if err != nil {
+			return errors.Errorf("context set %q: context %q not found", contextSet.Name, contextName)
+		}
+		if ctx.ContextType != contextType {
+			return errors.Errorf("context set %q: context %q is of type %s, not %s", contextSet.Name, contextName, ctx.ContextType, contextType)
+		}
+		if contextType == configtypes.ContextTypeTMC {
+			continue
+		}
+		if exclusiveType != "" {
+			return errors.Errorf("context set %q: %s and %s contexts cannot be active together, as activating a context other than a mission-control one deactivates the current contexts of the other types; a set holds a single tanzu or kubernetes context along with a mission-control context", contextSet.Name, exclusiveType, contextType)
+		}
+		exclusiveType = contextType
+	}
+	return nil
+}
+
+// sortedContextSetTypes returns the context types of the set in a stable order
+func sortedContextSetTypes(contextSet *configtypes.ContextSet) []configtypes.ContextType {
+	contextTypes := make([]configtypes.ContextType, 0, len(contextSet.Contexts))
+	for contextType := range contextSet.Contexts {
+		contextTypes = append(contextTypes, contextType)
+	}
+	sort.Slice(contextTypes, func(i, j int) bool { return contextTypes[i] < contextTypes[j] })
+	return contextTypes
+}
@@ -0,0 +1,133 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+func setupTestContextSetContexts(t *testing.T) {
+	for _, ctx := range []*configtypes.Context{
+		{Name: "dev-k8s", ContextType: configtypes.ContextTypeK8s, ClusterOpts: &configtypes.ClusterServer{Endpoint: "https://dev:6443", Path: "path", Context: "dev", IsManagementCluster: true}},
+		{Name: "prod-k8s", ContextType: configtypes.ContextTypeK8s, ClusterOpts: &configtypes.ClusterServer{Endpoint: "https://prod:6443", Path: "path", Context: "prod", IsManagementCluster: true}},
+		{Name: "dev-tmc", ContextType: configtypes.ContextTypeTMC, GlobalOpts: &configtypes.GlobalServer{Endpoint: "dev.tmc.example.com"}},
+		{Name: "prod-tmc", ContextType: configtypes.ContextTypeTMC, GlobalOpts: &configtypes.GlobalServer{Endpoint: "prod.tmc.example.com"}},
+		{Name: "tanzu", ContextType: configtypes.ContextTypeTanzu, GlobalOpts: &configtypes.GlobalServer{Endpoint: "https://api.tanzu.example.com"}},
+	} {
+		require.NoError(t, SetContext(ctx, false))
+	}
+}
+
+func TestContextSets(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+	setupTestContextSetContexts(t)
+
+	dev := map[configtypes.ContextType]string{configtypes.ContextTypeK8s: "dev-k8s", configtypes.ContextTypeTMC: "dev-tmc"}
+	prod := map[configtypes.ContextType]string{configtypes.ContextTypeK8s: "prod-k8s", configtypes.ContextTypeTMC: "prod-tmc"}
+	require.NoError(t, SetContextSet("dev", dev))
+	require.NoError(t, SetContextSet("prod", prod))
+	contextSet, err := GetContextSet("dev")
+	require.NoError(t, err)
+	assert.Equal(t, &configtypes.ContextSet{Name: "dev", Contexts: dev}, contextSet)
+
+	_, err = GetActiveContextSet()
+	assert.EqualError(t, err, "no active context set")
+
+	require.NoError(t, ActivateContextSet("prod"))
+	contextSet, err = GetActiveContextSet()
+	require.NoError(t, err)
+	assert.Equal(t, "prod", contextSet.Name)
+	for contextType, name := range prod {
+		ctx, err := GetActiveContext(contextType)
+		require.NoError(t, err)
+		assert.Equal(t, name, ctx.Name)
+	}
+	server, err := GetCurrentServer() //nolint:staticcheck
+	require.NoError(t, err)
+	assert.Equal(t, "prod-k8s", server.Name)
+
+	require.NoError(t, ActivateContextSet("dev"))
+	contextSet, err = GetActiveContextSet()
+	require.NoError(t, err)
+	assert.Equal(t, "dev", contextSet.Name)
+
+	// Switching a context of the set deactivates it
+	require.NoError(t, SetActiveContext("prod-tmc"))
+	_, err = GetActiveContextSet()
+	assert.EqualError(t, err, `context set "dev" is no longer active: the active mission-control context is not "dev-tmc"`)
+
+	// Renaming a context of a set keeps the set consistent
+	require.NoError(t, RenameContext("prod-k8s", "production-k8s"))
+	contextSet, err = GetContextSet("prod")
+	require.NoError(t, err)
+	assert.Equal(t, "production-k8s", contextSet.Contexts[configtypes.ContextTypeK8s])
+	require.NoError(t, ActivateContextSet("prod"))
+
+	require.NoError(t, DeleteContextSet("prod"))
+	_, err = GetContextSet("prod")
+	assert.EqualError(t, err, `context set "prod" not found`)
+	_, err = GetActiveContextSet()
+	assert.EqualError(t, err, "no active context set")
+	assert.EqualError(t, DeleteContextSet("prod"), `context set "prod" not found`)
+	assert.EqualError(t, ActivateContextSet("prod"), `context set "prod" not found`)
+}
+
+func TestContextSetValidation(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+	setupTestContextSetContexts(t)
+
+	tests := []struct {
+		name     string
+		contexts map[configtypes.ContextType]string
+		errStr   string
+	}{
+		{
+			name:     "missing context",
+			contexts: map[configtypes.ContextType]string{configtypes.ContextTypeK8s: "missing"},
+			errStr:   `context set "set": context "missing" not found`,
+		},
+		{
+			name:     "wrong type",
+			contexts: map[configtypes.ContextType]string{configtypes.ContextTypeK8s: "dev-tmc"},
+			errStr:   `context set "set": context "dev-tmc" is of type mission-control, not kubernetes`,
+		},
+		{
+			name:     "mutually exclusive types",
+			contexts: map[configtypes.ContextType]string{configtypes.ContextTypeK8s: "dev-k8s", configtypes.ContextTypeTanzu: "tanzu"},
+			errStr:   `context set "set": kubernetes and tanzu contexts cannot be active together, as activating a context other than a mission-control one deactivates the current contexts of the other types; a set holds a single tanzu or kubernetes context along with a mission-control context`,
+		},
+		{
+			name: "tanzu, kubernetes and mission-control",
+			contexts: map[configtypes.ContextType]string{
+				configtypes.ContextTypeK8s:   "dev-k8s",
+				configtypes.ContextTypeTanzu: "tanzu",
+				configtypes.ContextTypeTMC:   "dev-tmc",
+			},
+			errStr: `context set "set": kubernetes and tanzu contexts cannot be active together, as activating a context other than a mission-control one deactivates the current contexts of the other types; a set holds a single tanzu or kubernetes context along with a mission-control context`,
+		},
+		{
+			name:   "empty",
+			errStr: "context set must hold at least one context",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.EqualError(t, SetContextSet("set", tc.contexts), tc.errStr)
+		})
+	}
+
+	// Activation fails without any change if a context of the set was deleted in the meantime
+	require.NoError(t, SetContextSet("set", map[configtypes.ContextType]string{configtypes.ContextTypeTanzu: "tanzu", configtypes.ContextTypeTMC: "dev-tmc"}))
+	require.NoError(t, DeleteContext("tanzu"))
+	assert.EqualError(t, ActivateContextSet("set"), `context set "set": context "tanzu" not found`)
+	_, err := GetActiveContext(configtypes.ContextTypeTMC)
+	assert.Error(t, err)
+}
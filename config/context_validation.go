@@ -0,0 +1,241 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/config/internal/kubeconfig"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+// ContextCheck is the name of a check performed by ValidateContext
+type ContextCheck string
+
+const (
+	// ContextCheckKubeconfig checks the kubeconfig file exists and contains the kube context
+	ContextCheckKubeconfig ContextCheck = "kubeconfig"
+	// ContextCheckEndpoint checks the endpoints of the context parse
+	ContextCheckEndpoint ContextCheck = "endpoint"
+	// ContextCheckToken checks the access token of the context has not expired
+	ContextCheckToken ContextCheck = "token"
+	// ContextCheckMetadata checks the additional metadata required by the context type is present
+	ContextCheckMetadata ContextCheck = "metadata"
+	// ContextCheckReachability checks the endpoints of the context respond. It is only performed with WithNetworkProbe.
+	ContextCheckReachability ContextCheck = "reachability"
+)
+
+// ContextProblemSeverity is the severity of a context problem
+type ContextProblemSeverity string
+
+const (
+	// ContextProblemError indicates the context cannot be used
+	ContextProblemError ContextProblemSeverity = "error"
+	// ContextProblemWarning indicates the context can be used but may need attention e.g. its token has to be refreshed
+	ContextProblemWarning ContextProblemSeverity = "warning"
+)
+
+// defaultNetworkProbeTimeout is the timeout of each network probe when none is specified
+const defaultNetworkProbeTimeout = 5 * time.Second
+
+// requiredTanzuContextMetadataKeys are the AdditionalMetadata keys that a Tanzu context cannot be used without
+var requiredTanzuContextMetadataKeys = []string{OrgIDKey, TanzuHubEndpointKey}
+
+// ContextProblem is a problem found by ValidateContext
+type ContextProblem struct {
+	// Check that found the problem
+	Check ContextCheck `json:"check" yaml:"check"`
+	// Severity of the problem
+	Severity ContextProblemSeverity `json:"severity" yaml:"severity"`
+	// Message describes the problem
+	Message string `json:"message" yaml:"message"`
+}
+
+// ValidateContextOptions are the options of ValidateContext
+type ValidateContextOptions struct {
+	// NetworkProbe enables probing the endpoints of the context over the network
+	NetworkProbe bool
+	// NetworkProbeTimeout is the timeout of each network probe
+	NetworkProbeTimeout time.Duration
+	// Now is the time the token expiration is checked against
+	Now time.Time
+}
+
+type ValidateContextOpts func(o *ValidateContextOptions)
+
+// WithNetworkProbe enables probing the endpoints of the context with a HTTP request. Any HTTP response, whatever
+// its status code, means the endpoint is reachable. A timeout of zero uses the default of 5 seconds.
+func WithNetworkProbe(timeout time.Duration) ValidateContextOpts {
+	return func(o *ValidateContextOptions) {
+		o.NetworkProbe = true
+		o.NetworkProbeTimeout = timeout
+	}
+}
+
+// WithValidationTime checks the token expiration against the specified time instead of the current time
+func WithValidationTime(now time.Time) ValidateContextOpts {
+	return func(o *ValidateContextOptions) {
+		o.Now = now
+	}
+}
+
+// ValidateContext reports the problems of the context that would otherwise only surface when a command uses it.
+// It checks that the kubeconfig exists and contains the kube context, that the endpoints parse, that the token
+// has not expired and, for Tanzu contexts, that the required additional metadata is present.
+// An empty list means no problem was found; an error is only returned if the context cannot be read.
+func ValidateContext(name string, opts ...ValidateContextOpts) ([]ContextProblem, error) {
+	options := &ValidateContextOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if options.Now.IsZero() {
+		options.Now = time.Now()
+	}
+	if options.NetworkProbeTimeout <= 0 {
+		options.NetworkProbeTimeout = defaultNetworkProbeTimeout
+	}
+
+	ctx, err := GetContext(name)
+	if err != nil {
+		return nil, err
+	}
+	problems := make([]ContextProblem, 0)
+	problems = append(problems, validateContextKubeconfig(ctx)...)
+	problems = append(problems, validateContextEndpoints(ctx)...)
+	problems = append(problems, validateContextToken(ctx, options.Now)...)
+	problems = append(problems, validateContextMetadata(ctx)...)
+	if options.NetworkProbe {
+		problems = append(problems, probeContextEndpoints(ctx, options.NetworkProbeTimeout)...)
+	}
+	return problems, nil
+}
+
+func newContextProblem(check ContextCheck, severity ContextProblemSeverity, format string, args ...interface{}) ContextProblem {
+	return ContextProblem{Check: check, Severity: severity, Message: fmt.Sprintf(format, args...)}
+}
+
+func validateContextKubeconfig(ctx *configtypes.Context) []ContextProblem {
+	if ctx.ClusterOpts == nil {
+		if ctx.ContextType == configtypes.ContextTypeK8s {
+			return []ContextProblem{newContextProblem(ContextCheckKubeconfig, ContextProblemError, "cluster options are not set")}
+		}
+		return nil
+	}
+	if ctx.ClusterOpts.Path == "" {
+		return []ContextProblem{newContextProblem(ContextCheckKubeconfig, ContextProblemError, "kubeconfig path is not set")}
+	}
+	if _, err := os.Stat(ctx.ClusterOpts.Path); err != nil {
+		return []ContextProblem{newContextProblem(ContextCheckKubeconfig, ContextProblemError, "kubeconfig %q cannot be read: %v", ctx.ClusterOpts.Path, err)}
+	}
+	kc, err := kubeconfig.ReadKubeConfig(ctx.ClusterOpts.Path)
+	if err != nil {
+		return []ContextProblem{newContextProblem(ContextCheckKubeconfig, ContextProblemError, "kubeconfig %q is invalid: %v", ctx.ClusterOpts.Path, err)}
+	}
+	if ctx.ClusterOpts.Context == "" {
+		return []ContextProblem{newContextProblem(ContextCheckKubeconfig, ContextProblemError, "kube context is not set")}
+	}
+	if kubeconfig.GetContext(kc, ctx.ClusterOpts.Context) == nil {
+		return []ContextProblem{newContextProblem(ContextCheckKubeconfig, ContextProblemError, "kubeconfig %q does not contain the kube context %q", ctx.ClusterOpts.Path, ctx.ClusterOpts.Context)}
+	}
+	return nil
+}
+
+func validateContextEndpoints(ctx *configtypes.Context) []ContextProblem {
+	var problems []ContextProblem
+	// The cluster endpoint is informational for kubernetes contexts, the kubeconfig holds the server actually used
+	if ctx.ClusterOpts != nil && ctx.ClusterOpts.Endpoint != "" {
+		if err := validateRESTEndpoint(ctx.ClusterOpts.Endpoint); err != nil {
+			problems = append(problems, newContextProblem(ContextCheckEndpoint, ContextProblemError, "cluster %v", err))
+		}
+	}
+	if ctx.GlobalOpts != nil {
+		if err := validateRESTEndpoint(ctx.GlobalOpts.Endpoint); err != nil {
+			problems = append(problems, newContextProblem(ContextCheckEndpoint, ContextProblemError, "global %v", err))
+		}
+	} else if ctx.ContextType == configtypes.ContextTypeTMC || ctx.ContextType == configtypes.ContextTypeTanzu {
+		problems = append(problems, newContextProblem(ContextCheckEndpoint, ContextProblemError, "global options are not set"))
+	}
+	return problems
+}
+
+func validateContextToken(ctx *configtypes.Context, now time.Time) []ContextProblem {
+	if ctx.GlobalOpts == nil || ctx.GlobalOpts.Auth.Expiration.IsZero() || now.Before(ctx.GlobalOpts.Auth.Expiration) {
+		return nil
+	}
+	expiration := ctx.GlobalOpts.Auth.Expiration.Format(time.RFC3339)
+	// An expired access token is refreshed on use if a refresh token is available
+	if ctx.GlobalOpts.Auth.RefreshToken != "" {
+		return []ContextProblem{newContextProblem(ContextCheckToken, ContextProblemWarning, "access token expired at %s and has to be refreshed", expiration)}
+	}
+	return []ContextProblem{newContextProblem(ContextCheckToken, ContextProblemError, "access token expired at %s", expiration)}
+}
+
+func validateContextMetadata(ctx *configtypes.Context) []ContextProblem {
+	if ctx.ContextType != configtypes.ContextTypeTanzu {
+		return nil
+	}
+	if _, err := NewTanzuContextMetadata(ctx); err != nil {
+		return []ContextProblem{newContextProblem(ContextCheckMetadata, ContextProblemError, "%v", err)}
+	}
+	var problems []ContextProblem
+	for _, key := range requiredTanzuContextMetadataKeys {
+		if stringValue(ctx.AdditionalMetadata[key]) == "" {
+			problems = append(problems, newContextProblem(ContextCheckMetadata, ContextProblemError, "additional metadata %q is not set", key))
+		}
+	}
+	return problems
+}
+
+// probeContextEndpoints sends a HTTP request to the parseable endpoints of the context
+func probeContextEndpoints(ctx *configtypes.Context, timeout time.Duration) []ContextProblem {
+	var endpoints []string
+	if ctx.ClusterOpts != nil && ctx.ClusterOpts.Endpoint != "" && validateRESTEndpoint(ctx.ClusterOpts.Endpoint) == nil {
+		endpoints = append(endpoints, ctx.ClusterOpts.Endpoint)
+	}
+	if ctx.GlobalOpts != nil && validateRESTEndpoint(ctx.GlobalOpts.Endpoint) == nil {
+		endpoints = append(endpoints, ctx.GlobalOpts.Endpoint)
+	}
+	var problems []ContextProblem
+	for _, endpoint := range endpoints {
+		if err := probeEndpoint(endpoint, timeout); err != nil {
+			problems = append(problems, newContextProblem(ContextCheckReachability, ContextProblemError, "endpoint %q is not reachable: %v", endpoint, err))
+		}
+	}
+	return problems
+}
+
+// probeEndpoint sends a HEAD request to the endpoint using the TLS configuration of the matching cert
+func probeEndpoint(endpoint string, timeout time.Duration) error {
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+	tlsConfig, err := TLSConfigForHost(u.Host)
+	if err != nil {
+		return errors.Wrap(err, "failed to get the TLS configuration")
+	}
+	client := &http.Client{
+		Timeout:   timeout,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig, Proxy: http.ProxyFromEnvironment},
+	}
+	req, err := http.NewRequest(http.MethodHead, endpoint, http.NoBody)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
@@ -0,0 +1,159 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+func TestValidateContext(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+	kubeconfigPath := filepath.Join(t.TempDir(), "kubeconfig")
+	require.NoError(t, os.WriteFile(kubeconfigPath, []byte(testBundleKubeconfig), 0600))
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		ctx      *configtypes.Context
+		problems []ContextProblem
+	}{
+		{
+			name: "valid k8s context",
+			ctx: &configtypes.Context{
+				Name:        "k8s",
+				ContextType: configtypes.ContextTypeK8s,
+				ClusterOpts: &configtypes.ClusterServer{Endpoint: "https://dev.example.com:6443", Path: kubeconfigPath, Context: "dev"},
+			},
+			problems: []ContextProblem{},
+		},
+		{
+			name: "missing kubeconfig",
+			ctx: &configtypes.Context{
+				Name:        "k8s",
+				ContextType: configtypes.ContextTypeK8s,
+				ClusterOpts: &configtypes.ClusterServer{Path: filepath.Join(t.TempDir(), "missing"), Context: "dev"},
+			},
+			problems: []ContextProblem{{Check: ContextCheckKubeconfig, Severity: ContextProblemError}},
+		},
+		{
+			name: "missing kube context and invalid endpoint",
+			ctx: &configtypes.Context{
+				Name:        "k8s",
+				ContextType: configtypes.ContextTypeK8s,
+				ClusterOpts: &configtypes.ClusterServer{Endpoint: "https://dev example", Path: kubeconfigPath, Context: "missing"},
+			},
+			problems: []ContextProblem{
+				{Check: ContextCheckKubeconfig, Severity: ContextProblemError, Message: `kubeconfig "` + kubeconfigPath + `" does not contain the kube context "missing"`},
+				{Check: ContextCheckEndpoint, Severity: ContextProblemError},
+			},
+		},
+		{
+			name: "valid tanzu context",
+			ctx: &configtypes.Context{
+				Name:               "tanzu",
+				ContextType:        configtypes.ContextTypeTanzu,
+				GlobalOpts:         &configtypes.GlobalServer{Endpoint: "https://api.tanzu.example.com", Auth: configtypes.GlobalServerAuth{Expiration: now.Add(time.Hour)}},
+				ClusterOpts:        &configtypes.ClusterServer{Path: kubeconfigPath, Context: "dev"},
+				AdditionalMetadata: map[string]interface{}{OrgIDKey: "org", TanzuHubEndpointKey: "https://hub.example.com"},
+			},
+			problems: []ContextProblem{},
+		},
+		{
+			name: "tanzu context with expired token and missing metadata",
+			ctx: &configtypes.Context{
+				Name:               "tanzu",
+				ContextType:        configtypes.ContextTypeTanzu,
+				GlobalOpts:         &configtypes.GlobalServer{Endpoint: "https://api.tanzu.example.com", Auth: configtypes.GlobalServerAuth{Expiration: now.Add(-time.Hour)}},
+				ClusterOpts:        &configtypes.ClusterServer{Path: kubeconfigPath, Context: "dev"},
+				AdditionalMetadata: map[string]interface{}{OrgIDKey: "org"},
+			},
+			problems: []ContextProblem{
+				{Check: ContextCheckToken, Severity: ContextProblemError, Message: "access token expired at 2024-05-31T23:00:00Z"},
+				{Check: ContextCheckMetadata, Severity: ContextProblemError, Message: `additional metadata "tanzuHubEndpoint" is not set`},
+			},
+		},
+		{
+			name: "expired token with refresh token",
+			ctx: &configtypes.Context{
+				Name:        "tmc",
+				ContextType: configtypes.ContextTypeTMC,
+				GlobalOpts:  &configtypes.GlobalServer{Endpoint: "tmc.example.com:443", Auth: configtypes.GlobalServerAuth{RefreshToken: "refresh", Expiration: now.Add(-time.Hour)}},
+			},
+			problems: []ContextProblem{{Check: ContextCheckToken, Severity: ContextProblemWarning, Message: "access token expired at 2024-05-31T23:00:00Z and has to be refreshed"}},
+		},
+		{
+			name: "tmc context without global options",
+			ctx: &configtypes.Context{
+				Name:        "tmc",
+				ContextType: configtypes.ContextTypeTMC,
+			},
+			problems: []ContextProblem{{Check: ContextCheckEndpoint, Severity: ContextProblemError, Message: "global options are not set"}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, SetContext(tc.ctx, false))
+			defer func() { require.NoError(t, DeleteContext(tc.ctx.Name)) }()
+
+			problems, err := ValidateContext(tc.ctx.Name, WithValidationTime(now))
+			require.NoError(t, err)
+			require.Len(t, problems, len(tc.problems), problems)
+			for i := range tc.problems {
+				assert.Equal(t, tc.problems[i].Check, problems[i].Check)
+				assert.Equal(t, tc.problems[i].Severity, problems[i].Severity)
+				if tc.problems[i].Message != "" {
+					assert.Equal(t, tc.problems[i].Message, problems[i].Message)
+				}
+			}
+		})
+	}
+
+	_, err := ValidateContext("missing")
+	assert.Error(t, err)
+}
+
+func TestValidateContextNetworkProbe(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+	closedServer := httptest.NewServer(http.NotFoundHandler())
+	closedServer.Close()
+
+	require.NoError(t, SetContext(&configtypes.Context{
+		Name:        "tmc",
+		ContextType: configtypes.ContextTypeTMC,
+		GlobalOpts:  &configtypes.GlobalServer{Endpoint: server.URL},
+	}, false))
+	problems, err := ValidateContext("tmc", WithNetworkProbe(time.Second))
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	require.NoError(t, SetContext(&configtypes.Context{
+		Name:        "tmc",
+		ContextType: configtypes.ContextTypeTMC,
+		GlobalOpts:  &configtypes.GlobalServer{Endpoint: closedServer.URL},
+	}, false))
+	problems, err = ValidateContext("tmc")
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+	problems, err = ValidateContext("tmc", WithNetworkProbe(time.Second))
+	require.NoError(t, err)
+	require.Len(t, problems, 1)
+	assert.Equal(t, ContextCheckReachability, problems[0].Check)
+	assert.Contains(t, problems[0].Message, closedServer.URL)
+}
@@ -16,12 +16,20 @@ import (
 
 // GetContext retrieves the context by name
 func GetContext(name string) (*configtypes.Context, error) {
-	// Retrieve client config node
-	node, err := getClientConfigNode()
+	// check if context name is empty
+	if name == "" {
+		return nil, errors.New("context name cannot be empty")
+	}
+	// Retrieve the client config, which is cached while the config files are unchanged
+	cfg, err := readClientConfig()
 	if err != nil {
 		return nil, err
 	}
-	return getContext(node, name)
+	ctx, err := findContext(cfg, name)
+	if err != nil {
+		return nil, err
+	}
+	return deepCopy(ctx), nil
 }
 
 // AddContext add or update context and currentContext
@@ -30,73 +38,69 @@ func AddContext(c *configtypes.Context, setCurrent bool) error {
 }
 
 // SetContext add or update context and currentContext
-//
-//nolint:gocyclo
 func SetContext(c *configtypes.Context, setCurrent bool) error {
-	// Retrieve client config node
 	AcquireTanzuConfigLock()
 	defer ReleaseTanzuConfigLock()
+	return setContextNoLock(c, setCurrent)
+}
+
+// setContextNoLock add or update context and currentContext without acquiring the lock
+func setContextNoLock(c *configtypes.Context, setCurrent bool) error {
+	// Retrieve client config node
 	node, err := getClientConfigNodeNoLock()
 	if err != nil {
 		return err
 	}
-	// Add or update the context
-	persist, err := setContext(node, c)
+	persist, err := setContextAndServer(node, c, setCurrent)
 	if err != nil {
 		return err
 	}
 	if persist {
-		err = persistConfig(node)
-		if err != nil {
-			return err
-		}
+		return persistConfig(node)
+	}
+	return nil
+}
+
+// setContextAndServer add or update context and currentContext along with the back-filled server in the node
+//
+//nolint:gocyclo
+func setContextAndServer(node *yaml.Node, c *configtypes.Context, setCurrent bool) (bool, error) {
+	// Add or update the context
+	persist, err := setContext(node, c)
+	if err != nil {
+		return false, err
 	}
 	// Set current context
 	if setCurrent {
-		persist, err = setCurrentContext(node, c.Name, c.ContextType)
+		persistCurrent, err := setCurrentContext(node, c.Name, c.ContextType)
 		if err != nil {
-			return err
-		}
-		if persist {
-			err = persistConfig(node)
-			if err != nil {
-				return err
-			}
+			return false, err
 		}
+		persist = persist || persistCurrent
 	}
 
-	// Back-fill servers based on contexts
-	if c.ContextType == configtypes.ContextTypeTanzu {
-		return nil
+	// Back-fill servers based on contexts, unless the migration off servers has been completed
+	if c.ContextType == configtypes.ContextTypeTanzu || legacyServersMigrated() {
+		return persist, nil
 	}
 	s := convertContextToServer(c)
 
 	// Add or update server
-	persist, err = setServer(node, s)
+	persistServer, err := setServer(node, s)
 	if err != nil {
-		return err
-	}
-	if persist {
-		err = persistConfig(node)
-		if err != nil {
-			return err
-		}
+		return false, err
 	}
+	persist = persist || persistServer
 
 	// Set current server
 	if setCurrent && s.Type == configtypes.ManagementClusterServerType { //nolint:staticcheck
-		persist, err = setCurrentServer(node, s.Name)
+		persistServer, err = setCurrentServer(node, s.Name)
 		if err != nil {
-			return err
-		}
-		if persist {
-			err = persistConfig(node)
-			if err != nil {
-				return err
-			}
+			return false, err
 		}
+		persist = persist || persistServer
 	}
-	return err
+	return persist, nil
 }
 
 // DeleteContext delete a context by name
@@ -113,6 +117,14 @@ func RemoveContext(name string) error {
 	if err != nil {
 		return err
 	}
+	if err := removeContextAndServer(node, name); err != nil {
+		return err
+	}
+	return persistConfig(node)
+}
+
+// removeContextAndServer removes the context by name along with its server and their current entries from the node
+func removeContextAndServer(node *yaml.Node, name string) error {
 	ctx, err := getContext(node, name)
 	if err != nil {
 		return err
@@ -129,11 +141,7 @@ func RemoveContext(name string) error {
 	if err != nil {
 		return err
 	}
-	err = removeCurrentServer(node, name)
-	if err != nil {
-		return err
-	}
-	return persistConfig(node)
+	return removeCurrentServer(node, name)
 }
 
 // ContextExists checks if context by name already exists
@@ -342,12 +350,22 @@ func getContext(node *yaml.Node, name string) (*configtypes.Context, error) {
 	if err != nil {
 		return nil, err
 	}
+	return findContext(cfg, name)
+}
+
+// findContext returns the context with the specified name from the client config
+func findContext(cfg *configtypes.ClientConfig, name string) (*configtypes.Context, error) {
 	for _, ctx := range cfg.KnownContexts {
 		if ctx.Name == name {
 			return ctx, nil
 		}
 	}
-	return nil, fmt.Errorf("context %v not found", name)
+	return nil, contextNotFoundError(name)
+}
+
+// contextNotFoundError returns the error reported when the context with the specified name does not exist
+func contextNotFoundError(name string) error {
+	return fmt.Errorf("context %v not found", name)
 }
 
 func getActiveContext(node *yaml.Node, contextType configtypes.ContextType) (*configtypes.Context, error) {
@@ -578,3 +596,23 @@ func updateMutualExclusiveCurrentContexts(node *yaml.Node, setterCtxType configt
 	}
 	return nil
 }
+
+// getContextNode returns the yaml node of the context with the specified name
+func getContextNode(node *yaml.Node, name string) (*yaml.Node, error) {
+	// check if context name is empty
+	if name == "" {
+		return nil, errors.New("context name cannot be empty")
+	}
+	keys := []nodeutils.Key{
+		{Name: KeyContexts},
+	}
+	contextsNode := nodeutils.FindNode(node.Content[0], nodeutils.WithKeys(keys))
+	if contextsNode != nil {
+		for _, contextNode := range contextsNode.Content {
+			if index := nodeutils.GetNodeIndex(contextNode.Content, "name"); index != -1 && contextNode.Content[index].Value == name {
+				return contextNode, nil
+			}
+		}
+	}
+	return nil, contextNotFoundError(name)
+}
@@ -205,6 +205,7 @@ func convertObjectToNode[
 		*configtypes.Server |
 		*configtypes.PluginRepository |
 		*configtypes.Context |
+		*configtypes.ContextSet |
 		*configtypes.Cert |
 		*configtypes.TelemetryOptions |
 		*configtypes.PluginDiscovery](obj T) (*yaml.Node, error) {
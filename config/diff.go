@@ -0,0 +1,546 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+// DiffSection is the section of the ClientConfig a change belongs to
+type DiffSection string
+
+const (
+	DiffSectionContexts         DiffSection = "contexts"
+	DiffSectionActiveContexts   DiffSection = "activeContexts"
+	DiffSectionFeatures         DiffSection = "features"
+	DiffSectionEnvs             DiffSection = "envs"
+	DiffSectionCerts            DiffSection = "certs"
+	DiffSectionDiscoverySources DiffSection = "discoverySources"
+)
+
+// ChangeType is the type of a change
+type ChangeType string
+
+const (
+	ChangeTypeAdded    ChangeType = "added"
+	ChangeTypeRemoved  ChangeType = "removed"
+	ChangeTypeModified ChangeType = "modified"
+)
+
+// DiffFormat is the format used to render a ConfigDiff
+type DiffFormat string
+
+const (
+	DiffFormatText    DiffFormat = "text"
+	DiffFormatJSON    DiffFormat = "json"
+	DiffFormatUnified DiffFormat = "unified"
+)
+
+// redactedValue replaces the sensitive values in a ConfigDiff
+const redactedValue = "<redacted>"
+
+// sensitiveMetadataKeyFragments are the fragments of the additional metadata keys of a context holding credentials
+var sensitiveMetadataKeyFragments = []string{"token", "secret", "password", "credential", "apikey", "privatekey"}
+
+// FieldChange is a change of a single field of a modified item, identified by its dotted path within the item
+type FieldChange struct {
+	Path string      `json:"path" yaml:"path"`
+	Old  interface{} `json:"old,omitempty" yaml:"old,omitempty"`
+	New  interface{} `json:"new,omitempty" yaml:"new,omitempty"`
+}
+
+// Change is a change of a single item of a section of the ClientConfig.
+// The item is identified by its key within the section i.e. the context name for contexts,
+// the context type for active contexts, the plugin and feature name for features,
+// the variable name for envs, the host for certs and the source name for discovery sources.
+// Context type scoped features and envs are keyed as "<contextType>:<key>".
+type Change struct {
+	Section DiffSection `json:"section" yaml:"section"`
+	Type    ChangeType  `json:"type" yaml:"type"`
+	Key     string      `json:"key" yaml:"key"`
+	Old     interface{} `json:"old,omitempty" yaml:"old,omitempty"`
+	New     interface{} `json:"new,omitempty" yaml:"new,omitempty"`
+	// Fields are the changed fields of a modified context, cert or discovery source
+	Fields []FieldChange `json:"fields,omitempty" yaml:"fields,omitempty"`
+}
+
+// ConfigDiff is the semantic difference between two ClientConfig snapshots
+type ConfigDiff struct {
+	Changes []Change `json:"changes" yaml:"changes"`
+}
+
+// DiffRenderOptions are the options used to render a ConfigDiff
+type DiffRenderOptions struct {
+	// Color enables colored unified output
+	Color bool
+}
+
+// DiffRenderOpts is a function type that applies configuration options to DiffRenderOptions
+type DiffRenderOpts func(opts *DiffRenderOptions)
+
+// WithDiffColor enables or disables colored unified output
+func WithDiffColor(enabled bool) DiffRenderOpts {
+	return func(opts *DiffRenderOptions) {
+		opts.Color = enabled
+	}
+}
+
+// Diff returns the semantic difference from ClientConfig a to ClientConfig b, per section:
+// contexts, active contexts, features, envs, certs and cli discovery sources.
+// A nil ClientConfig is treated as an empty ClientConfig. The client key data of certs and the auth tokens,
+// env values and sensitive additional metadata of contexts are redacted.
+func Diff(a, b *configtypes.ClientConfig) (*ConfigDiff, error) {
+	if a == nil {
+		a = &configtypes.ClientConfig{}
+	}
+	if b == nil {
+		b = &configtypes.ClientConfig{}
+	}
+	d := &ConfigDiff{Changes: make([]Change, 0)}
+
+	if err := d.diffItems(DiffSectionContexts, contextsByName(a), contextsByName(b)); err != nil {
+		return nil, err
+	}
+	d.diffScalars(DiffSectionActiveContexts, activeContextsByType(a), activeContextsByType(b))
+	d.diffScalars(DiffSectionFeatures, featuresByKey(a), featuresByKey(b))
+	d.diffScalars(DiffSectionEnvs, envsByKey(a), envsByKey(b))
+	if err := d.diffItems(DiffSectionCerts, certsByHost(a), certsByHost(b)); err != nil {
+		return nil, err
+	}
+	if err := d.diffItems(DiffSectionDiscoverySources, discoverySourcesByName(a), discoverySourcesByName(b)); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// IsEmpty returns true if there are no changes
+func (d *ConfigDiff) IsEmpty() bool {
+	return len(d.Changes) == 0
+}
+
+// BySection returns the changes of the specified section
+func (d *ConfigDiff) BySection(section DiffSection) []Change {
+	var changes []Change
+	for _, c := range d.Changes {
+		if c.Section == section {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// String returns the text rendering of the diff
+func (d *ConfigDiff) String() string {
+	var sb strings.Builder
+	_ = d.Render(&sb, DiffFormatText)
+	return sb.String()
+}
+
+// Render writes the diff to the writer in the specified format
+func (d *ConfigDiff) Render(w io.Writer, format DiffFormat, opts ...DiffRenderOpts) error {
+	options := &DiffRenderOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	switch format {
+	case DiffFormatText, "":
+		return d.renderText(w)
+	case DiffFormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(d)
+	case DiffFormatUnified:
+		return d.renderUnified(w, options.Color)
+	default:
+		return errors.Errorf("unsupported diff format %q", format)
+	}
+}
+
+func (d *ConfigDiff) renderText(w io.Writer) error {
+	for _, c := range d.Changes {
+		var err error
+		switch {
+		case c.Type == ChangeTypeAdded && isScalar(c.New):
+			_, err = fmt.Fprintf(w, "%s: added %q = %s\n", c.Section, c.Key, formatDiffValue(c.New))
+		case c.Type == ChangeTypeAdded:
+			_, err = fmt.Fprintf(w, "%s: added %q\n", c.Section, c.Key)
+		case c.Type == ChangeTypeRemoved && isScalar(c.Old):
+			_, err = fmt.Fprintf(w, "%s: removed %q (was %s)\n", c.Section, c.Key, formatDiffValue(c.Old))
+		case c.Type == ChangeTypeRemoved:
+			_, err = fmt.Fprintf(w, "%s: removed %q\n", c.Section, c.Key)
+		case len(c.Fields) == 0:
+			_, err = fmt.Fprintf(w, "%s: modified %q: %s -> %s\n", c.Section, c.Key, formatDiffValue(c.Old), formatDiffValue(c.New))
+		default:
+			_, err = fmt.Fprintf(w, "%s: modified %q\n", c.Section, c.Key)
+			for _, f := range c.Fields {
+				if err != nil {
+					break
+				}
+				_, err = fmt.Fprintf(w, "    %s: %s -> %s\n", f.Path, formatDiffValue(f.Old), formatDiffValue(f.New))
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *ConfigDiff) renderUnified(w io.Writer, colored bool) error {
+	header := color.New(color.FgCyan)
+	added := color.New(color.FgGreen)
+	removed := color.New(color.FgRed)
+	for _, c := range []*color.Color{header, added, removed} {
+		if colored {
+			c.EnableColor()
+		} else {
+			c.DisableColor()
+		}
+	}
+
+	var lines []string
+	var section DiffSection
+	for _, c := range d.Changes {
+		if c.Section != section {
+			section = c.Section
+			lines = append(lines, header.Sprintf("@@ %s @@", section))
+		}
+		switch {
+		case c.Type == ChangeTypeAdded:
+			for _, l := range unifiedLines(c.Key, c.New) {
+				lines = append(lines, added.Sprint("+"+l))
+			}
+		case c.Type == ChangeTypeRemoved:
+			for _, l := range unifiedLines(c.Key, c.Old) {
+				lines = append(lines, removed.Sprint("-"+l))
+			}
+		case len(c.Fields) == 0:
+			lines = append(lines, removed.Sprintf("-%s: %s", c.Key, formatDiffValue(c.Old)), added.Sprintf("+%s: %s", c.Key, formatDiffValue(c.New)))
+		default:
+			lines = append(lines, " "+c.Key+":")
+			for _, f := range c.Fields {
+				if f.Old != nil {
+					lines = append(lines, removed.Sprintf("-  %s: %s", f.Path, formatDiffValue(f.Old)))
+				}
+				if f.New != nil {
+					lines = append(lines, added.Sprintf("+  %s: %s", f.Path, formatDiffValue(f.New)))
+				}
+			}
+		}
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	_, err := fmt.Fprintf(w, "%s\n%s\n%s\n", removed.Sprint("--- a"), added.Sprint("+++ b"), strings.Join(lines, "\n"))
+	return err
+}
+
+// unifiedLines renders the key and value of an added or removed item as yaml lines
+func unifiedLines(key string, value interface{}) []string {
+	if isScalar(value) {
+		return []string{fmt.Sprintf("%s: %s", key, formatDiffValue(value))}
+	}
+	lines := []string{key + ":"}
+	b, err := yaml.Marshal(value)
+	if err != nil {
+		return lines
+	}
+	for _, l := range strings.Split(strings.TrimRight(string(b), "\n"), "\n") {
+		lines = append(lines, "  "+l)
+	}
+	return lines
+}
+
+// redactValue replaces the sensitive value with a placeholder that includes a short digest of the value,
+// so that changes of the value remain detectable
+func redactValue(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return fmt.Sprintf("%s(sha256:%x)", redactedValue, sum[:4])
+}
+
+func isScalar(value interface{}) bool {
+	switch value.(type) {
+	case string, bool, int, int64, float64:
+		return true
+	}
+	return false
+}
+
+func formatDiffValue(value interface{}) string {
+	if value == nil {
+		return "<none>"
+	}
+	if isScalar(value) {
+		return fmt.Sprintf("%q", fmt.Sprint(value))
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(b)
+}
+
+// diffItems compares the items of a section keyed by name and records the added, removed and modified items.
+// The fields of modified items are compared through their generic yaml representation.
+func (d *ConfigDiff) diffItems(section DiffSection, a, b map[string]interface{}) error {
+	for _, key := range unionKeys(a, b) {
+		oldItem, inA := a[key]
+		newItem, inB := b[key]
+		switch {
+		case !inA:
+			d.Changes = append(d.Changes, Change{Section: section, Type: ChangeTypeAdded, Key: key, New: newItem})
+		case !inB:
+			d.Changes = append(d.Changes, Change{Section: section, Type: ChangeTypeRemoved, Key: key, Old: oldItem})
+		default:
+			oldGeneric, err := toGeneric(oldItem)
+			if err != nil {
+				return err
+			}
+			newGeneric, err := toGeneric(newItem)
+			if err != nil {
+				return err
+			}
+			var fields []FieldChange
+			diffGeneric("", oldGeneric, newGeneric, &fields)
+			if len(fields) > 0 {
+				d.Changes = append(d.Changes, Change{Section: section, Type: ChangeTypeModified, Key: key, Old: oldItem, New: newItem, Fields: fields})
+			}
+		}
+	}
+	return nil
+}
+
+// diffScalars compares the scalar values of a section keyed by name
+func (d *ConfigDiff) diffScalars(section DiffSection, a, b map[string]string) {
+	keys := make(map[string]interface{})
+	for k := range a {
+		keys[k] = nil
+	}
+	for k := range b {
+		keys[k] = nil
+	}
+	for _, key := range unionKeys(keys, nil) {
+		oldValue, inA := a[key]
+		newValue, inB := b[key]
+		switch {
+		case !inA:
+			d.Changes = append(d.Changes, Change{Section: section, Type: ChangeTypeAdded, Key: key, New: newValue})
+		case !inB:
+			d.Changes = append(d.Changes, Change{Section: section, Type: ChangeTypeRemoved, Key: key, Old: oldValue})
+		case oldValue != newValue:
+			d.Changes = append(d.Changes, Change{Section: section, Type: ChangeTypeModified, Key: key, Old: oldValue, New: newValue})
+		}
+	}
+}
+
+// diffGeneric recursively compares the generic yaml representations and records the changed leaf fields
+func diffGeneric(path string, a, b interface{}, fields *[]FieldChange) {
+	aMap, aIsMap := a.(map[string]interface{})
+	bMap, bIsMap := b.(map[string]interface{})
+	if aIsMap && bIsMap {
+		for _, key := range unionKeys(aMap, bMap) {
+			diffGeneric(joinDiffPath(path, key), aMap[key], bMap[key], fields)
+		}
+		return
+	}
+	aSlice, aIsSlice := a.([]interface{})
+	bSlice, bIsSlice := b.([]interface{})
+	if aIsSlice && bIsSlice {
+		for i := 0; i < len(aSlice) || i < len(bSlice); i++ {
+			var aItem, bItem interface{}
+			if i < len(aSlice) {
+				aItem = aSlice[i]
+			}
+			if i < len(bSlice) {
+				bItem = bSlice[i]
+			}
+			diffGeneric(fmt.Sprintf("%s[%d]", path, i), aItem, bItem, fields)
+		}
+		return
+	}
+	if !reflect.DeepEqual(a, b) {
+		*fields = append(*fields, FieldChange{Path: path, Old: a, New: b})
+	}
+}
+
+func joinDiffPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// toGeneric converts the object to its generic yaml representation of maps, slices and scalars
+func toGeneric(obj interface{}) (interface{}, error) {
+	b, err := yaml.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	if err := yaml.Unmarshal(b, &generic); err != nil {
+		return nil, err
+	}
+	return generic, nil
+}
+
+func unionKeys(a, b map[string]interface{}) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func contextsByName(cfg *configtypes.ClientConfig) map[string]interface{} {
+	items := make(map[string]interface{})
+	for _, ctx := range cfg.KnownContexts {
+		if ctx != nil {
+			items[ctx.Name] = redactContext(ctx)
+		}
+	}
+	return items
+}
+
+// redactContext returns a copy of the context with the auth tokens, the env values and the sensitive
+// additional metadata redacted. Secret references are kept as they do not hold the secret.
+func redactContext(ctx *configtypes.Context) *configtypes.Context {
+	redacted := *ctx
+	if ctx.GlobalOpts != nil {
+		globalOpts := *ctx.GlobalOpts
+		for _, token := range []*string{&globalOpts.Auth.AccessToken, &globalOpts.Auth.IDToken, &globalOpts.Auth.RefreshToken} {
+			if *token != "" {
+				*token = redactValue(*token)
+			}
+		}
+		redacted.GlobalOpts = &globalOpts
+	}
+	if ctx.Env != nil {
+		redacted.Env = make(map[string]string, len(ctx.Env))
+		for key, value := range ctx.Env {
+			redacted.Env[key] = redactEnvValue(value)
+		}
+	}
+	if ctx.AdditionalMetadata != nil {
+		redacted.AdditionalMetadata = make(map[string]interface{}, len(ctx.AdditionalMetadata))
+		for key, value := range ctx.AdditionalMetadata {
+			if s, ok := value.(string); ok && s != "" && !IsSecretRef(s) && isSensitiveMetadataKey(key) {
+				value = redactValue(s)
+			}
+			redacted.AdditionalMetadata[key] = value
+		}
+	}
+	return &redacted
+}
+
+// isSensitiveMetadataKey returns true if the additional metadata key names a credential
+func isSensitiveMetadataKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, fragment := range sensitiveMetadataKeyFragments {
+		if strings.Contains(key, fragment) {
+			return true
+		}
+	}
+	return false
+}
+
+func activeContextsByType(cfg *configtypes.ClientConfig) map[string]string {
+	items := make(map[string]string)
+	for contextType, name := range cfg.CurrentContext {
+		if name != "" {
+			items[string(contextType)] = name
+		}
+	}
+	return items
+}
+
+func featuresByKey(cfg *configtypes.ClientConfig) map[string]string {
+	items := make(map[string]string)
+	if cfg.ClientOptions == nil {
+		return items
+	}
+	addFeatures := func(prefix string, features map[string]configtypes.FeatureMap) {
+		for plugin, featureMap := range features {
+			for key, value := range featureMap {
+				items[prefix+plugin+"."+key] = value
+			}
+		}
+	}
+	addFeatures("", cfg.ClientOptions.Features)
+	for contextType, features := range cfg.ClientOptions.ContextTypeFeatures {
+		addFeatures(string(contextType)+":", features)
+	}
+	return items
+}
+
+// envsByKey returns the env values redacted like the env values of the contexts, except the secret references
+func envsByKey(cfg *configtypes.ClientConfig) map[string]string {
+	items := make(map[string]string)
+	if cfg.ClientOptions == nil {
+		return items
+	}
+	for key, value := range cfg.ClientOptions.Env {
+		items[key] = redactEnvValue(value)
+	}
+	for contextType, envs := range cfg.ClientOptions.ContextTypeEnv {
+		for key, value := range envs {
+			items[string(contextType)+":"+key] = redactEnvValue(value)
+		}
+	}
+	return items
+}
+
+func redactEnvValue(value string) string {
+	if value == "" || IsSecretRef(value) {
+		return value
+	}
+	return redactValue(value)
+}
+
+func certsByHost(cfg *configtypes.ClientConfig) map[string]interface{} {
+	items := make(map[string]interface{})
+	for _, cert := range cfg.Certs {
+		if cert == nil {
+			continue
+		}
+		redacted := *cert
+		if redacted.ClientKeyData != "" && !IsSecretRef(redacted.ClientKeyData) {
+			redacted.ClientKeyData = redactValue(redacted.ClientKeyData)
+		}
+		items[cert.Host] = &redacted
+	}
+	return items
+}
+
+func discoverySourcesByName(cfg *configtypes.ClientConfig) map[string]interface{} {
+	items := make(map[string]interface{})
+	if cfg.CoreCliOptions == nil {
+		return items
+	}
+	for _, source := range cfg.CoreCliOptions.DiscoverySources {
+		_, name, err := getDiscoverySourceTypeAndName(source)
+		if err != nil {
+			continue
+		}
+		items[name] = source
+	}
+	return items
+}
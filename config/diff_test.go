@@ -0,0 +1,216 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+func diffTestConfigs() (*configtypes.ClientConfig, *configtypes.ClientConfig) {
+	a := &configtypes.ClientConfig{
+		KnownContexts: []*configtypes.Context{
+			{
+				Name:        "ctx-a",
+				ContextType: configtypes.ContextTypeK8s,
+				ClusterOpts: &configtypes.ClusterServer{Endpoint: "old-endpoint", Path: "kubeconfig"},
+			},
+			{
+				Name:        "ctx-removed",
+				ContextType: configtypes.ContextTypeTMC,
+			},
+		},
+		CurrentContext: map[configtypes.ContextType]string{
+			configtypes.ContextTypeK8s: "ctx-a",
+			configtypes.ContextTypeTMC: "ctx-removed",
+		},
+		ClientOptions: &configtypes.ClientOptions{
+			Features: map[string]configtypes.FeatureMap{
+				"global": {"feature-a": "true", "feature-b": "false"},
+			},
+			Env: map[string]string{"ENV_A": "a"},
+		},
+		Certs: []*configtypes.Cert{
+			{Host: "example.com", SkipCertVerify: "false", ClientKeyData: "old-key"},
+		},
+		CoreCliOptions: &configtypes.CoreCliOptions{
+			DiscoverySources: []configtypes.PluginDiscovery{
+				{OCI: &configtypes.OCIDiscovery{Name: "default", Image: "example.com/default:v1"}},
+			},
+		},
+	}
+	b := &configtypes.ClientConfig{
+		KnownContexts: []*configtypes.Context{
+			{
+				Name:        "ctx-a",
+				ContextType: configtypes.ContextTypeK8s,
+				ClusterOpts: &configtypes.ClusterServer{Endpoint: "new-endpoint", Path: "kubeconfig"},
+			},
+			{
+				Name:        "ctx-added",
+				ContextType: configtypes.ContextTypeTanzu,
+			},
+		},
+		CurrentContext: map[configtypes.ContextType]string{
+			configtypes.ContextTypeK8s:   "ctx-a",
+			configtypes.ContextTypeTanzu: "ctx-added",
+		},
+		ClientOptions: &configtypes.ClientOptions{
+			Features: map[string]configtypes.FeatureMap{
+				"global": {"feature-a": "false"},
+			},
+			ContextTypeFeatures: map[configtypes.ContextType]map[string]configtypes.FeatureMap{
+				configtypes.ContextTypeTanzu: {"global": {"feature-c": "true"}},
+			},
+			Env: map[string]string{"ENV_A": "a", "ENV_B": "b"},
+		},
+		Certs: []*configtypes.Cert{
+			{Host: "example.com", SkipCertVerify: "true", ClientKeyData: "new-key"},
+		},
+		CoreCliOptions: &configtypes.CoreCliOptions{
+			DiscoverySources: []configtypes.PluginDiscovery{
+				{OCI: &configtypes.OCIDiscovery{Name: "default", Image: "example.com/default:v2"}, Priority: 1},
+			},
+		},
+	}
+	return a, b
+}
+
+func TestDiff(t *testing.T) {
+	a, b := diffTestConfigs()
+	d, err := Diff(a, b)
+	assert.NoError(t, err)
+
+	contexts := d.BySection(DiffSectionContexts)
+	assert.Len(t, contexts, 3)
+	assert.Equal(t, "ctx-a", contexts[0].Key)
+	assert.Equal(t, ChangeTypeModified, contexts[0].Type)
+	assert.Equal(t, []FieldChange{{Path: "clusterOpts.endpoint", Old: "old-endpoint", New: "new-endpoint"}}, contexts[0].Fields)
+	assert.Equal(t, "ctx-added", contexts[1].Key)
+	assert.Equal(t, ChangeTypeAdded, contexts[1].Type)
+	assert.Equal(t, "ctx-removed", contexts[2].Key)
+	assert.Equal(t, ChangeTypeRemoved, contexts[2].Type)
+
+	assert.Equal(t, []Change{
+		{Section: DiffSectionActiveContexts, Type: ChangeTypeRemoved, Key: "mission-control", Old: "ctx-removed"},
+		{Section: DiffSectionActiveContexts, Type: ChangeTypeAdded, Key: "tanzu", New: "ctx-added"},
+	}, d.BySection(DiffSectionActiveContexts))
+
+	assert.Equal(t, []Change{
+		{Section: DiffSectionFeatures, Type: ChangeTypeModified, Key: "global.feature-a", Old: "true", New: "false"},
+		{Section: DiffSectionFeatures, Type: ChangeTypeRemoved, Key: "global.feature-b", Old: "false"},
+		{Section: DiffSectionFeatures, Type: ChangeTypeAdded, Key: "tanzu:global.feature-c", New: "true"},
+	}, d.BySection(DiffSectionFeatures))
+
+	assert.Equal(t, []Change{
+		{Section: DiffSectionEnvs, Type: ChangeTypeAdded, Key: "ENV_B", New: redactValue("b")},
+	}, d.BySection(DiffSectionEnvs))
+
+	certs := d.BySection(DiffSectionCerts)
+	assert.Len(t, certs, 1)
+	assert.Len(t, certs[0].Fields, 2)
+	assert.Equal(t, "clientKeyData", certs[0].Fields[0].Path)
+	assert.NotContains(t, certs[0].Fields[0].Old, "old-key")
+	assert.Contains(t, certs[0].Fields[0].Old, redactedValue)
+	assert.NotEqual(t, certs[0].Fields[0].Old, certs[0].Fields[0].New)
+	assert.Equal(t, FieldChange{Path: "skipCertVerify", Old: "false", New: "true"}, certs[0].Fields[1])
+
+	sources := d.BySection(DiffSectionDiscoverySources)
+	assert.Len(t, sources, 1)
+	assert.Equal(t, []FieldChange{
+		{Path: "oci.image", Old: "example.com/default:v1", New: "example.com/default:v2"},
+		{Path: "priority", New: 1},
+	}, sources[0].Fields)
+
+	// same configs have no changes
+	d, err = Diff(a, a)
+	assert.NoError(t, err)
+	assert.True(t, d.IsEmpty())
+
+	// nil configs are treated as empty
+	d, err = Diff(nil, &configtypes.ClientConfig{ClientOptions: &configtypes.ClientOptions{Env: map[string]string{"A": "a"}}})
+	assert.NoError(t, err)
+	assert.Equal(t, []Change{{Section: DiffSectionEnvs, Type: ChangeTypeAdded, Key: "A", New: redactValue("a")}}, d.Changes)
+}
+
+func TestDiffRender(t *testing.T) {
+	a, b := diffTestConfigs()
+	d, err := Diff(a, b)
+	assert.NoError(t, err)
+
+	text := d.String()
+	assert.Contains(t, text, "contexts: modified \"ctx-a\"\n    clusterOpts.endpoint: \"old-endpoint\" -> \"new-endpoint\"\n")
+	assert.Contains(t, text, "contexts: added \"ctx-added\"\n")
+	assert.Contains(t, text, "activeContexts: removed \"mission-control\" (was \"ctx-removed\")\n")
+	assert.Contains(t, text, fmt.Sprintf("envs: added \"ENV_B\" = %q\n", redactValue("b")))
+	assert.Contains(t, text, "features: modified \"global.feature-a\": \"true\" -> \"false\"\n")
+	assert.NotContains(t, text, "new-key")
+
+	var buf bytes.Buffer
+	err = d.Render(&buf, DiffFormatJSON)
+	assert.NoError(t, err)
+	var decoded ConfigDiff
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Len(t, decoded.Changes, len(d.Changes))
+
+	buf.Reset()
+	err = d.Render(&buf, DiffFormatUnified)
+	assert.NoError(t, err)
+	unified := buf.String()
+	assert.True(t, strings.HasPrefix(unified, "--- a\n+++ b\n@@ contexts @@\n"))
+	assert.Contains(t, unified, "\n ctx-a:\n-  clusterOpts.endpoint: \"old-endpoint\"\n+  clusterOpts.endpoint: \"new-endpoint\"\n")
+	assert.Contains(t, unified, "\n+ctx-added:\n+  name: ctx-added\n")
+	assert.Contains(t, unified, fmt.Sprintf("\n@@ envs @@\n+ENV_B: %q\n", redactValue("b")))
+	assert.NotContains(t, unified, "\x1b[")
+
+	buf.Reset()
+	err = d.Render(&buf, DiffFormatUnified, WithDiffColor(true))
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), fmt.Sprintf("\x1b[32m+ENV_B: %q\x1b[0m", redactValue("b")))
+
+	err = d.Render(&buf, DiffFormat("xml"))
+	assert.EqualError(t, err, `unsupported diff format "xml"`)
+}
+
+func TestDiffRedactsContextCredentials(t *testing.T) {
+	cfg := func(token, env, password string) *configtypes.ClientConfig {
+		return &configtypes.ClientConfig{KnownContexts: []*configtypes.Context{{
+			Name:        "tanzu",
+			ContextType: configtypes.ContextTypeTanzu,
+			GlobalOpts:  &configtypes.GlobalServer{Endpoint: "api.example.com", Auth: configtypes.GlobalServerAuth{AccessToken: token}},
+			Env:         map[string]string{"API_TOKEN": env, "REF": "${secret:ref}"},
+			AdditionalMetadata: map[string]interface{}{
+				"password": password,
+				"orgName":  "org",
+			},
+		}}}
+	}
+	d, err := Diff(cfg("old-token", "old-env", "old-password"), cfg("new-token", "new-env", "new-password"))
+	assert.NoError(t, err)
+	contexts := d.BySection(DiffSectionContexts)
+	assert.Len(t, contexts, 1)
+	assert.Len(t, contexts[0].Fields, 3)
+	for _, field := range contexts[0].Fields {
+		assert.Contains(t, field.Old, redactedValue, field.Path)
+		assert.Contains(t, field.New, redactedValue, field.Path)
+		assert.NotEqual(t, field.Old, field.New, field.Path)
+	}
+
+	d, err = Diff(nil, cfg("my-token", "my-env", "my-password"))
+	assert.NoError(t, err)
+	var buf bytes.Buffer
+	assert.NoError(t, d.Render(&buf, DiffFormatJSON))
+	for _, secret := range []string{"my-token", "my-env", "my-password"} {
+		assert.NotContains(t, buf.String(), secret)
+	}
+	assert.Contains(t, buf.String(), "${secret:ref}")
+	assert.Contains(t, buf.String(), `"org"`)
+}
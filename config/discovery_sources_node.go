@@ -165,6 +165,23 @@ func getDiscoverySourceTypeAndName(discoverySource configtypes.PluginDiscovery)
 	return discoverySourceType, discoverySourceName, nil
 }
 
+// ValidateDiscoverySource validates the discovery source has a type and a name,
+// the image of an OCI discovery source is a valid OCI image reference and
+// the endpoint of a REST discovery source is a valid host or http(s) URL
+func ValidateDiscoverySource(discoverySource configtypes.PluginDiscovery) error {
+	_, name, err := getDiscoverySourceTypeAndName(discoverySource)
+	if err != nil {
+		return err
+	}
+	switch {
+	case discoverySource.OCI != nil:
+		return errors.Wrapf(validateOCIImageRef(discoverySource.OCI.Image), "invalid oci discovery source %q", name)
+	case discoverySource.REST != nil:
+		return errors.Wrapf(validateRESTEndpoint(discoverySource.REST.Endpoint), "invalid rest discovery source %q", name)
+	}
+	return nil
+}
+
 // Find the matching discovery source type and index from accepted discovery sources
 func findDiscoverySourceTypeAndIndexByWeakMatch(discoverySourceContentNodes []*yaml.Node) (string, int) {
 	acceptedDiscoverySources := []string{DiscoveryTypeOCI, DiscoveryTypeLocal, DiscoveryTypeGCP, DiscoveryTypeKubernetes, DiscoveryTypeREST}
@@ -0,0 +1,134 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"sort"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+// DiscoverySourceOrigin describes where an effective discovery source is configured
+type DiscoverySourceOrigin string
+
+const (
+	// DiscoverySourceOriginCLI indicates the discovery source is configured at the cli level
+	DiscoverySourceOriginCLI DiscoverySourceOrigin = "cli"
+	// DiscoverySourceOriginContext indicates the discovery source is configured on an active context
+	DiscoverySourceOriginContext DiscoverySourceOrigin = "context"
+)
+
+// EffectiveDiscoverySource is a discovery source that is effective for the active contexts
+type EffectiveDiscoverySource struct {
+	// Name of the discovery source
+	Name string `json:"name" yaml:"name"`
+	// Type of the discovery source i.e. oci, local, gcp, kubernetes or rest
+	Type string `json:"type" yaml:"type"`
+	// Origin describes where the discovery source is configured
+	Origin DiscoverySourceOrigin `json:"origin" yaml:"origin"`
+	// ContextName is the name of the context the discovery source is configured on, if the origin is context
+	ContextName string `json:"contextName,omitempty" yaml:"contextName,omitempty"`
+	// ContextType is the type of the context the discovery source is configured on, if the origin is context
+	ContextType configtypes.ContextType `json:"contextType,omitempty" yaml:"contextType,omitempty"`
+	// Source is the discovery source configuration
+	Source configtypes.PluginDiscovery `json:"source" yaml:"source"`
+	// ValidationError describes why the discovery source configuration is invalid, if it is
+	ValidationError string `json:"validationError,omitempty" yaml:"validationError,omitempty"`
+}
+
+// GetEffectiveDiscoverySources returns the discovery sources that are effective for the active contexts.
+// The cli discovery sources are merged with the discovery sources of every active context and
+// duplicates are removed by name; a discovery source of an active context takes precedence over a
+// cli discovery source of the same name, and the discovery source of a TMC context is overridden
+// by the discovery source of the other active context.
+// Disabled discovery sources are excluded, which allows an active context to disable a cli discovery source
+// by configuring a disabled discovery source of the same name.
+// The discovery sources of a context include those of the legacy server of the same name that the context
+// does not configure, as contexts written by older CLIs may only have them configured on the server.
+// The effective discovery sources are ordered by decreasing priority, and sources of equal priority
+// retain the order in which they are configured, with the cli discovery sources first.
+func GetEffectiveDiscoverySources() ([]EffectiveDiscoverySource, error) {
+	cfg, err := GetClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	contexts, err := getOrderedActiveContexts(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var effective []EffectiveDiscoverySource
+	indexByName := make(map[string]int)
+	add := func(source configtypes.PluginDiscovery, origin DiscoverySourceOrigin, ctx *configtypes.Context) {
+		sourceType, name, err := getDiscoverySourceTypeAndName(source)
+		if err != nil {
+			return
+		}
+		eds := EffectiveDiscoverySource{
+			Name:   name,
+			Type:   sourceType,
+			Origin: origin,
+			Source: source,
+		}
+		if ctx != nil {
+			eds.ContextName = ctx.Name
+			eds.ContextType = ctx.ContextType
+		}
+		if err := ValidateDiscoverySource(source); err != nil {
+			eds.ValidationError = err.Error()
+		}
+		if idx, exists := indexByName[name]; exists {
+			effective[idx] = eds
+			return
+		}
+		indexByName[name] = len(effective)
+		effective = append(effective, eds)
+	}
+
+	if cfg.CoreCliOptions != nil {
+		for _, source := range cfg.CoreCliOptions.DiscoverySources {
+			add(source, DiscoverySourceOriginCLI, nil)
+		}
+	}
+	for _, ctx := range contexts {
+		for _, source := range contextDiscoverySources(cfg, ctx) {
+			add(source, DiscoverySourceOriginContext, ctx)
+		}
+	}
+
+	result := make([]EffectiveDiscoverySource, 0, len(effective))
+	for _, eds := range effective {
+		if !eds.Source.Disabled {
+			result = append(result, eds)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Source.Priority > result[j].Source.Priority
+	})
+	return result, nil
+}
+
+// contextDiscoverySources returns the discovery sources of the context followed by the discovery sources of the
+// legacy server of the same name that are not configured on the context
+func contextDiscoverySources(cfg *configtypes.ClientConfig, ctx *configtypes.Context) []configtypes.PluginDiscovery {
+	sources := append([]configtypes.PluginDiscovery{}, ctx.DiscoverySources...)
+	names := make(map[string]bool)
+	for _, source := range ctx.DiscoverySources {
+		if _, name, err := getDiscoverySourceTypeAndName(source); err == nil {
+			names[name] = true
+		}
+	}
+	for _, s := range cfg.KnownServers { //nolint:staticcheck
+		if s == nil || s.Name != ctx.Name {
+			continue
+		}
+		for _, source := range s.DiscoverySources {
+			if _, name, err := getDiscoverySourceTypeAndName(source); err == nil && !names[name] {
+				names[name] = true
+				sources = append(sources, source)
+			}
+		}
+	}
+	return sources
+}
@@ -0,0 +1,230 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+func TestGetEffectiveDiscoverySources(t *testing.T) {
+	// Setup config test data
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+
+	defer func() {
+		cleanUp()
+	}()
+
+	cfg := &configtypes.ClientConfig{
+		KnownContexts: []*configtypes.Context{
+			{
+				Name:        "test-k8s",
+				ContextType: configtypes.ContextTypeK8s,
+				DiscoverySources: []configtypes.PluginDiscovery{
+					{Kubernetes: &configtypes.KubernetesDiscovery{Name: "k8s-source"}},
+					{OCI: &configtypes.OCIDiscovery{Name: "shared", Image: "example.com/k8s/plugins:latest"}},
+				},
+			},
+			{
+				Name:        "test-tmc",
+				ContextType: configtypes.ContextTypeTMC,
+				DiscoverySources: []configtypes.PluginDiscovery{
+					{REST: &configtypes.GenericRESTDiscovery{Name: "tmc-source", Endpoint: "api.example.com", BasePath: "v1/plugins"}, Priority: 10},
+					{OCI: &configtypes.OCIDiscovery{Name: "disabled-by-context"}, Disabled: true},
+				},
+			},
+			{
+				Name:        "test-inactive",
+				ContextType: configtypes.ContextTypeTanzu,
+				DiscoverySources: []configtypes.PluginDiscovery{
+					{OCI: &configtypes.OCIDiscovery{Name: "inactive-source", Image: "example.com/inactive:latest"}},
+				},
+			},
+		},
+		KnownServers: []*configtypes.Server{
+			{
+				Name: "test-k8s",
+				Type: configtypes.ManagementClusterServerType,
+				DiscoverySources: []configtypes.PluginDiscovery{
+					{OCI: &configtypes.OCIDiscovery{Name: "shared", Image: "example.com/server/plugins:latest"}},
+					{OCI: &configtypes.OCIDiscovery{Name: "server-source", Image: "example.com/server/plugins:latest"}},
+				},
+			},
+		},
+		CurrentContext: map[configtypes.ContextType]string{
+			configtypes.ContextTypeK8s: "test-k8s",
+			configtypes.ContextTypeTMC: "test-tmc",
+		},
+	}
+	err := StoreClientConfig(cfg)
+	assert.NoError(t, err)
+	err = SetCLIDiscoverySources([]configtypes.PluginDiscovery{
+		{OCI: &configtypes.OCIDiscovery{Name: "default", Image: "example.com/default/plugins:latest"}},
+		{OCI: &configtypes.OCIDiscovery{Name: "shared", Image: "example.com/cli/plugins:latest"}},
+		{OCI: &configtypes.OCIDiscovery{Name: "disabled-by-context", Image: "example.com/disabled:latest"}},
+		{OCI: &configtypes.OCIDiscovery{Name: "disabled", Image: "example.com/disabled:latest"}, Disabled: true},
+	})
+	assert.NoError(t, err)
+
+	// Invalid discovery sources are rejected on write, but may still be found in a hand edited config file
+	invalid := configtypes.PluginDiscovery{OCI: &configtypes.OCIDiscovery{Name: "invalid", Image: "Invalid/Image"}}
+	err = SetCLIDiscoverySource(invalid)
+	assert.ErrorContains(t, err, `invalid oci discovery source "invalid"`)
+	err = SetCLIDiscoverySources([]configtypes.PluginDiscovery{invalid})
+	assert.ErrorContains(t, err, `invalid oci discovery source "invalid"`)
+	func() {
+		AcquireTanzuConfigLock()
+		defer ReleaseTanzuConfigLock()
+		node, err := getClientConfigNodeNoLock()
+		assert.NoError(t, err)
+		_, err = setCLIDiscoverySource(node, invalid)
+		assert.NoError(t, err)
+		assert.NoError(t, persistConfig(node))
+	}()
+
+	// Only the added or changed discovery sources are validated, so the existing invalid source can be written back
+	existing, err := GetCLIDiscoverySources()
+	assert.NoError(t, err)
+	assert.NoError(t, SetCLIDiscoverySources(existing))
+	assert.NoError(t, SetCLIDiscoverySource(invalid))
+	changed := configtypes.PluginDiscovery{OCI: &configtypes.OCIDiscovery{Name: "invalid", Image: "Changed/Image"}}
+	err = SetCLIDiscoverySources(append(existing, changed))
+	assert.ErrorContains(t, err, `invalid oci discovery source "invalid"`)
+
+	sources, err := GetEffectiveDiscoverySources()
+	assert.NoError(t, err)
+
+	var names []string
+	for _, s := range sources {
+		names = append(names, s.Name)
+	}
+	assert.Equal(t, []string{"tmc-source", "default", "shared", "invalid", "k8s-source", "server-source"}, names)
+
+	assert.Equal(t, DiscoverySourceOriginContext, sources[0].Origin)
+	assert.Equal(t, "test-tmc", sources[0].ContextName)
+	assert.Equal(t, configtypes.ContextTypeTMC, sources[0].ContextType)
+	assert.Equal(t, DiscoveryTypeREST, sources[0].Type)
+
+	assert.Equal(t, DiscoverySourceOriginCLI, sources[1].Origin)
+	assert.Empty(t, sources[1].ContextName)
+	assert.Empty(t, sources[1].ValidationError)
+
+	// context source of the same name takes precedence over the cli source
+	assert.Equal(t, DiscoverySourceOriginContext, sources[2].Origin)
+	assert.Equal(t, "test-k8s", sources[2].ContextName)
+	assert.Equal(t, "example.com/k8s/plugins:latest", sources[2].Source.OCI.Image)
+
+	assert.Equal(t, DiscoverySourceOriginCLI, sources[3].Origin)
+	assert.Contains(t, sources[3].ValidationError, `invalid oci discovery source "invalid"`)
+
+	// the legacy server discovery sources not configured on the context are included with the context sources
+	assert.Equal(t, DiscoverySourceOriginContext, sources[5].Origin)
+	assert.Equal(t, "test-k8s", sources[5].ContextName)
+}
+
+func TestCLIDiscoverySourceEnableDisableAndPriority(t *testing.T) {
+	// Setup config test data
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+
+	defer func() {
+		cleanUp()
+	}()
+
+	err := SetCLIDiscoverySources([]configtypes.PluginDiscovery{
+		{OCI: &configtypes.OCIDiscovery{Name: "first", Image: "example.com/first:latest"}},
+		{OCI: &configtypes.OCIDiscovery{Name: "second", Image: "example.com/second:latest"}},
+	})
+	assert.NoError(t, err)
+
+	err = SetCLIDiscoverySourcePriority("second", 5)
+	assert.NoError(t, err)
+	err = DisableCLIDiscoverySource("first")
+	assert.NoError(t, err)
+
+	source, err := GetCLIDiscoverySource("second")
+	assert.NoError(t, err)
+	assert.Equal(t, 5, source.Priority)
+	source, err = GetCLIDiscoverySource("first")
+	assert.NoError(t, err)
+	assert.True(t, source.Disabled)
+
+	sources, err := GetEffectiveDiscoverySources()
+	assert.NoError(t, err)
+	assert.Len(t, sources, 1)
+	assert.Equal(t, "second", sources[0].Name)
+
+	err = EnableCLIDiscoverySource("first")
+	assert.NoError(t, err)
+	err = SetCLIDiscoverySourcePriority("second", 0)
+	assert.NoError(t, err)
+
+	source, err = GetCLIDiscoverySource("first")
+	assert.NoError(t, err)
+	assert.False(t, source.Disabled)
+	source, err = GetCLIDiscoverySource("second")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, source.Priority)
+
+	sources, err = GetEffectiveDiscoverySources()
+	assert.NoError(t, err)
+	assert.Len(t, sources, 2)
+	assert.Equal(t, "first", sources[0].Name)
+
+	err = DisableCLIDiscoverySource("not-exists")
+	assert.EqualError(t, err, "cli discovery source not found")
+}
+
+func TestValidateDiscoverySource(t *testing.T) {
+	tests := []struct {
+		name   string
+		source configtypes.PluginDiscovery
+		errStr string
+	}{
+		{
+			name:   "valid oci source",
+			source: configtypes.PluginDiscovery{OCI: &configtypes.OCIDiscovery{Name: "oci", Image: "projects.registry.vmware.com/tanzu_cli/plugins/plugin-inventory:latest"}},
+		},
+		{
+			name:   "invalid oci image",
+			source: configtypes.PluginDiscovery{OCI: &configtypes.OCIDiscovery{Name: "oci", Image: "/:"}},
+			errStr: `invalid oci discovery source "oci": invalid OCI image reference "/:"`,
+		},
+		{
+			name:   "valid rest endpoint host",
+			source: configtypes.PluginDiscovery{REST: &configtypes.GenericRESTDiscovery{Name: "rest", Endpoint: "api.example.com:8443"}},
+		},
+		{
+			name:   "valid rest endpoint url",
+			source: configtypes.PluginDiscovery{REST: &configtypes.GenericRESTDiscovery{Name: "rest", Endpoint: "https://api.example.com"}},
+		},
+		{
+			name:   "invalid rest endpoint url",
+			source: configtypes.PluginDiscovery{REST: &configtypes.GenericRESTDiscovery{Name: "rest", Endpoint: "ftp://api.example.com"}},
+			errStr: `invalid rest discovery source "rest": invalid url "ftp://api.example.com": must be an absolute http or https url`,
+		},
+		{
+			name:   "empty rest endpoint",
+			source: configtypes.PluginDiscovery{REST: &configtypes.GenericRESTDiscovery{Name: "rest"}},
+			errStr: `invalid rest discovery source "rest": endpoint cannot be empty`,
+		},
+		{
+			name:   "missing name",
+			source: configtypes.PluginDiscovery{Local: &configtypes.LocalDiscovery{Path: "/tmp"}},
+			errStr: "discovery source name cannot be empty",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateDiscoverySource(tc.source)
+			if tc.errStr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.errStr)
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+// Variables referencing the properties of the context for which the env entries are expanded
+const (
+	EnvVarContextName     = "context.name"
+	EnvVarContextType     = "context.type"
+	EnvVarContextEndpoint = "context.endpoint"
+)
+
+// envExpander expands the `${VAR}` references in the env entries.
+// A reference is resolved in the following order:
+//  1. context variables (`${context.name}`, `${context.type}` and `${context.endpoint}`)
+//  2. secret references (`${secret:<name>}`)
+//  3. other env entries
+//  4. process environment variables
+//
+// `$${` can be used to escape a literal `${`. The values of the literal entries are not expanded.
+type envExpander struct {
+	envs     map[string]string
+	literal  map[string]bool
+	ctx      *configtypes.Context
+	expanded map[string]string
+	visiting map[string]bool
+}
+
+func newEnvExpander(envs map[string]string, literal map[string]bool, ctx *configtypes.Context) *envExpander {
+	return &envExpander{
+		envs:     envs,
+		literal:  literal,
+		ctx:      ctx,
+		expanded: make(map[string]string),
+		visiting: make(map[string]bool),
+	}
+}
+
+// expandEnvs returns a copy of the env entries with all the variable references expanded
+func expandEnvs(envs map[string]string, ctx *configtypes.Context) (map[string]string, error) {
+	e := newEnvExpander(envs, nil, ctx)
+	for key := range envs {
+		if _, err := e.expandKey(key, nil); err != nil {
+			return nil, err
+		}
+	}
+	return e.expanded, nil
+}
+
+// resolveEnvs merges the env entries of the contexts over the global env entries as mergeEnvs does and expands
+// each entry against the context it belongs to, in the view of the env entries of that context. The global entries
+// are literal unless the expandGlobalEnvs setting is enabled, in which case they are expanded against the last
+// context. An entry that cannot be expanded is returned unexpanded, and the expansion errors are combined.
+func resolveEnvs(cfg *configtypes.ClientConfig, contexts ...*configtypes.Context) (map[string]string, error) {
+	expandGlobal, _ := IsConfigMetadataSettingsEnabled(SettingExpandGlobalEnvs)
+
+	// owners holds the context of the entry taking precedence for each key, nil for the global entries
+	owners := make(map[string]*configtypes.Context)
+	for key := range cfg.GetEnvConfigurations() {
+		owners[key] = nil
+	}
+	for _, ctx := range contexts {
+		for key := range contextEnvs(cfg, ctx) {
+			owners[key] = ctx
+		}
+	}
+	var lastCtx *configtypes.Context
+	if len(contexts) > 0 {
+		lastCtx = contexts[len(contexts)-1]
+	}
+
+	keys := make([]string, 0, len(owners))
+	for key := range owners {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	envs := make(map[string]string, len(keys))
+	expanders := make(map[*configtypes.Context]*envExpander)
+	var errs error
+	for _, key := range keys {
+		ctx := owners[key]
+		if ctx == nil {
+			ctx = lastCtx
+		}
+		e, ok := expanders[ctx]
+		if !ok {
+			e = newContextEnvExpander(cfg, ctx, expandGlobal)
+			expanders[ctx] = e
+		}
+		val, err := e.expandKey(key, nil)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			val = e.envs[key]
+		}
+		envs[key] = val
+	}
+	return envs, errs
+}
+
+// newContextEnvExpander returns the expander of the env entries in the view of the context
+func newContextEnvExpander(cfg *configtypes.ClientConfig, ctx *configtypes.Context, expandGlobal bool) *envExpander {
+	var envs map[string]string
+	var scoped map[string]string
+	if ctx == nil {
+		envs, scoped = mergeEnvs(cfg), map[string]string{}
+	} else {
+		envs, scoped = mergeEnvs(cfg, ctx), contextEnvs(cfg, ctx)
+	}
+	literal := make(map[string]bool)
+	if !expandGlobal {
+		for key := range envs {
+			if _, ok := scoped[key]; !ok {
+				literal[key] = true
+			}
+		}
+	}
+	return newEnvExpander(envs, literal, ctx)
+}
+
+func (e *envExpander) expandKey(key string, chain []string) (string, error) {
+	if val, ok := e.expanded[key]; ok {
+		return val, nil
+	}
+	if e.literal[key] {
+		return e.envs[key], nil
+	}
+	chain = append(chain, key)
+	if e.visiting[key] {
+		return "", errors.Errorf("cycle detected while expanding env %q: %s", chain[0], strings.Join(chain, " -> "))
+	}
+	e.visiting[key] = true
+	defer delete(e.visiting, key)
+
+	val, err := e.expandValue(e.envs[key], chain)
+	if err != nil {
+		return "", err
+	}
+	e.expanded[key] = val
+	return val, nil
+}
+
+func (e *envExpander) expandValue(value string, chain []string) (string, error) {
+	var sb strings.Builder
+	for {
+		start := strings.Index(value, "${")
+		if start == -1 {
+			sb.WriteString(value)
+			return sb.String(), nil
+		}
+		// `$${` escapes a literal `${`
+		if start > 0 && value[start-1] == '$' {
+			sb.WriteString(value[:start-1])
+			sb.WriteString("${")
+			value = value[start+2:]
+			continue
+		}
+		end := strings.Index(value[start:], "}")
+		if end == -1 {
+			return "", errors.Errorf("unterminated variable reference in env %q", chain[len(chain)-1])
+		}
+		sb.WriteString(value[:start])
+		resolved, err := e.resolve(value[start+2:start+end], chain)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(resolved)
+		value = value[start+end+1:]
+	}
+}
+
+func (e *envExpander) resolve(name string, chain []string) (string, error) {
+	switch {
+	case name == "":
+		return "", errors.Errorf("empty variable reference in env %q", chain[len(chain)-1])
+	case name == EnvVarContextName || name == EnvVarContextType || name == EnvVarContextEndpoint:
+		return e.resolveContextVar(name)
+	case strings.HasPrefix(name, SecretRefPrefix):
+		return GetSecret(strings.TrimPrefix(name, SecretRefPrefix))
+	}
+	if _, ok := e.envs[name]; ok {
+		return e.expandKey(name, chain)
+	}
+	if val, ok := os.LookupEnv(name); ok {
+		return val, nil
+	}
+	return "", errors.Errorf("undefined variable %q referenced in env %q", name, chain[len(chain)-1])
+}
+
+func (e *envExpander) resolveContextVar(name string) (string, error) {
+	if e.ctx == nil {
+		return "", errors.Errorf("variable %q cannot be resolved without an active context", name)
+	}
+	switch name {
+	case EnvVarContextName:
+		return e.ctx.Name, nil
+	case EnvVarContextType:
+		return string(e.ctx.ContextType), nil
+	default:
+		endpoint, err := EndpointFromContext(e.ctx)
+		if err != nil {
+			return "", errors.Wrapf(err, "unable to resolve variable %q", name)
+		}
+		return endpoint, nil
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+type fakeSecretStore map[string]string
+
+func (f fakeSecretStore) GetSecret(name string) (string, error) {
+	if val, ok := f[name]; ok {
+		return val, nil
+	}
+	return "", errors.Errorf("secret %q not found", name)
+}
+
+func TestExpandEnvs(t *testing.T) {
+	t.Setenv("TEST_EXPAND_OS_VAR", "from-os")
+	SetSecretStore(fakeSecretStore{"proxy-password": "s3cr3t"})
+	defer SetSecretStore(nil)
+
+	ctx := &configtypes.Context{
+		Name:        "test-ctx",
+		ContextType: configtypes.ContextTypeK8s,
+		ClusterOpts: &configtypes.ClusterServer{Endpoint: "https://test-endpoint"},
+	}
+
+	tests := []struct {
+		name     string
+		envs     map[string]string
+		ctx      *configtypes.Context
+		expected map[string]string
+		errStr   string
+	}{
+		{
+			name:     "no references",
+			envs:     map[string]string{"A": "a"},
+			expected: map[string]string{"A": "a"},
+		},
+		{
+			name:     "reference to other env entries",
+			envs:     map[string]string{"HOST": "proxy.local", "PORT": "3128", "PROXY": "http://${HOST}:${PORT}"},
+			expected: map[string]string{"HOST": "proxy.local", "PORT": "3128", "PROXY": "http://proxy.local:3128"},
+		},
+		{
+			name:     "nested references",
+			envs:     map[string]string{"A": "${B}/a", "B": "${C}/b", "C": "c"},
+			expected: map[string]string{"A": "c/b/a", "B": "c/b", "C": "c"},
+		},
+		{
+			name:     "reference to process environment",
+			envs:     map[string]string{"A": "${TEST_EXPAND_OS_VAR}"},
+			expected: map[string]string{"A": "from-os"},
+		},
+		{
+			name:     "context variables",
+			envs:     map[string]string{"A": "${context.name}/${context.type}/${context.endpoint}"},
+			ctx:      ctx,
+			expected: map[string]string{"A": "test-ctx/kubernetes/https://test-endpoint"},
+		},
+		{
+			name:     "secret reference",
+			envs:     map[string]string{"PASSWORD": "${secret:proxy-password}"},
+			expected: map[string]string{"PASSWORD": "s3cr3t"},
+		},
+		{
+			name:     "escaped reference",
+			envs:     map[string]string{"A": "$${B}", "B": "b"},
+			expected: map[string]string{"A": "${B}", "B": "b"},
+		},
+		{
+			name:   "cycle",
+			envs:   map[string]string{"A": "${B}", "B": "${A}"},
+			errStr: "cycle detected",
+		},
+		{
+			name:   "self reference",
+			envs:   map[string]string{"A": "x${A}"},
+			errStr: "cycle detected while expanding env \"A\": A -> A",
+		},
+		{
+			name:   "undefined variable",
+			envs:   map[string]string{"A": "${TEST_EXPAND_UNDEFINED}"},
+			errStr: "undefined variable \"TEST_EXPAND_UNDEFINED\" referenced in env \"A\"",
+		},
+		{
+			name:   "context variable without context",
+			envs:   map[string]string{"A": "${context.name}"},
+			errStr: "variable \"context.name\" cannot be resolved without an active context",
+		},
+		{
+			name:   "missing secret",
+			envs:   map[string]string{"A": "${secret:unknown}"},
+			errStr: "secret \"unknown\" not found",
+		},
+		{
+			name:   "unterminated reference",
+			envs:   map[string]string{"A": "${B"},
+			errStr: "unterminated variable reference in env \"A\"",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			expanded, err := expandEnvs(tc.envs, tc.ctx)
+			if tc.errStr != "" {
+				assert.ErrorContains(t, err, tc.errStr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, expanded)
+		})
+	}
+}
+
+func TestGetSecretWithoutStore(t *testing.T) {
+	SetSecretStore(nil)
+	_, err := GetSecret("test")
+	assert.EqualError(t, err, "unable to resolve secret \"test\": secret store is not configured")
+	assert.True(t, IsSecretRef("${secret:test}"))
+	assert.False(t, IsSecretRef("test"))
+}
@@ -8,6 +8,8 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/config/nodeutils"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
 )
 
 // GetAllEnvs retrieves all env values from config
@@ -33,24 +35,20 @@ func getAllEnvs(node *yaml.Node) (map[string]string, error) {
 
 // GetEnv retrieves env value by key
 func GetEnv(key string) (string, error) {
-	// Retrieve client config node
-	node, err := getClientConfigNode()
+	// Retrieve the client config, which is cached while the config files are unchanged
+	cfg, err := readClientConfig()
 	if err != nil {
 		return "", err
 	}
-	return getEnv(node, key)
+	return getEnv(cfg, key)
 }
 
-func getEnv(node *yaml.Node, key string) (string, error) {
+func getEnv(cfg *configtypes.ClientConfig, key string) (string, error) {
 	// check if key is empty
 	if key == "" {
 		return "", errors.New("key cannot be empty")
 	}
 
-	cfg, err := convertNodeToClientConfig(node)
-	if err != nil {
-		return "", err
-	}
 	if cfg.ClientOptions == nil || cfg.ClientOptions.Env == nil {
 		return "", errors.New("not found")
 	}
@@ -62,6 +60,9 @@ func getEnv(node *yaml.Node, key string) (string, error) {
 
 // DeleteEnv delete the env entry of specified key
 func DeleteEnv(key string) error {
+	if err := checkSystemConfigLock(DiffSectionEnvs, key); err != nil {
+		return err
+	}
 	// Retrieve client config node
 	AcquireTanzuConfigLock()
 	defer ReleaseTanzuConfigLock()
@@ -87,7 +88,12 @@ func deleteEnv(node *yaml.Node, key string) (err error) {
 		{Name: KeyClientOptions},
 		{Name: KeyEnv},
 	}
-	envsNode := nodeutils.FindNode(node.Content[0], nodeutils.WithKeys(keys))
+	return deleteEnvInNode(node.Content[0], keys, key)
+}
+
+// deleteEnvInNode deletes the env entry of specified key in the env node found under parent node by keys
+func deleteEnvInNode(parent *yaml.Node, keys []nodeutils.Key, key string) (err error) {
+	envsNode := nodeutils.FindNode(parent, nodeutils.WithKeys(keys))
 	if envsNode == nil {
 		return err
 	}
@@ -112,6 +118,9 @@ func deleteEnv(node *yaml.Node, key string) (err error) {
 
 // SetEnv add or update a env key and value
 func SetEnv(key, value string) (err error) {
+	if err := checkSystemConfigLock(DiffSectionEnvs, key); err != nil {
+		return err
+	}
 	// Retrieve client config node
 	AcquireTanzuConfigLock()
 	defer ReleaseTanzuConfigLock()
@@ -142,7 +151,12 @@ func setEnv(node *yaml.Node, key, value string) (persist bool, err error) {
 		{Name: KeyClientOptions, Type: yaml.MappingNode},
 		{Name: KeyEnv, Type: yaml.MappingNode},
 	}
-	envsNode := nodeutils.FindNode(node.Content[0], nodeutils.WithForceCreate(), nodeutils.WithKeys(keys))
+	return setEnvInNode(node.Content[0], keys, key, value)
+}
+
+// setEnvInNode add or update the env key and value in the env node found under parent node by keys
+func setEnvInNode(parent *yaml.Node, keys []nodeutils.Key, key, value string) (persist bool, err error) {
+	envsNode := nodeutils.FindNode(parent, nodeutils.WithForceCreate(), nodeutils.WithKeys(keys))
 	if envsNode == nil {
 		return persist, err
 	}
@@ -172,11 +186,216 @@ func setEnv(node *yaml.Node, key, value string) (persist bool, err error) {
 
 // GetEnvConfigurations returns a map of configured environment variables
 // to values as part of tanzu configuration file
-// it returns nil if configuration is not yet defined
+// The env entries of the active contexts and their context types are merged over the global env entries.
+// The variable references in the entries of a context and its context type are expanded against that context,
+// the references in the global entries only if the expandGlobalEnvs config metadata setting is enabled.
+// An entry that cannot be expanded is returned unexpanded and a warning is logged.
+// it returns empty map if configuration is not yet defined
 func GetEnvConfigurations() map[string]string {
-	envs, err := GetAllEnvs()
+	cfg, err := GetClientConfig()
 	if err != nil {
 		return make(map[string]string)
 	}
+	contexts, err := getOrderedActiveContexts(cfg)
+	if err != nil {
+		return make(map[string]string)
+	}
+	envs, err := resolveEnvs(cfg, contexts...)
+	if err != nil {
+		log.Warningf("unable to expand the env configurations: %v", err)
+	}
+	return envs
+}
+
+// getOrderedActiveContexts returns the active contexts in increasing order of precedence.
+// TMC context can be active along with one of the other context types,
+// so it is ordered first to let the other active context take precedence.
+func getOrderedActiveContexts(cfg *configtypes.ClientConfig) ([]*configtypes.Context, error) {
+	activeContexts, err := cfg.GetAllActiveContextsMap()
+	if err != nil {
+		return nil, err
+	}
+	var contexts []*configtypes.Context
+	if ctx, ok := activeContexts[configtypes.ContextTypeTMC]; ok {
+		contexts = append(contexts, ctx)
+	}
+	for _, contextType := range configtypes.SupportedContextTypes {
+		if ctx, ok := activeContexts[contextType]; ok && contextType != configtypes.ContextTypeTMC {
+			contexts = append(contexts, ctx)
+		}
+	}
+	return contexts, nil
+}
+
+// GetEnvConfigurationsForContext returns a map of configured environment variables to values
+// that are applicable when the specified context is active.
+// The env entries of the context take precedence over the env entries of its context type,
+// which in turn take precedence over the global env entries.
+// The variable references in the values are expanded as in GetEnvConfigurations,
+// and an error is returned if an entry cannot be expanded.
+func GetEnvConfigurationsForContext(contextName string) (map[string]string, error) {
+	cfg, err := GetClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	ctx, err := findContext(cfg, contextName)
+	if err != nil {
+		return nil, err
+	}
+	envs, err := resolveEnvs(cfg, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return envs, nil
+}
+
+// mergeEnvs merges the env entries of the specified contexts and their context types
+// over the global env entries in the order the contexts are specified
+func mergeEnvs(cfg *configtypes.ClientConfig, contexts ...*configtypes.Context) map[string]string {
+	envs := make(map[string]string)
+	for key, value := range cfg.GetEnvConfigurations() {
+		envs[key] = value
+	}
+	for _, ctx := range contexts {
+		for key, value := range contextEnvs(cfg, ctx) {
+			envs[key] = value
+		}
+	}
 	return envs
 }
+
+// contextEnvs returns the env entries of the context merged over the env entries of its context type
+func contextEnvs(cfg *configtypes.ClientConfig, ctx *configtypes.Context) map[string]string {
+	envs := make(map[string]string)
+	if cfg.ClientOptions != nil {
+		for key, value := range cfg.ClientOptions.ContextTypeEnv[ctx.ContextType] {
+			envs[key] = value
+		}
+	}
+	for key, value := range ctx.Env {
+		envs[key] = value
+	}
+	return envs
+}
+
+// SetContextEnv add or update a env key and value scoped to the specified context
+func SetContextEnv(contextName, key, value string) error {
+	// The context env overrides the global env, so it cannot be set if the global env is locked
+	if err := checkSystemConfigLock(DiffSectionEnvs, key); err != nil {
+		return err
+	}
+	// Retrieve client config node
+	AcquireTanzuConfigLock()
+	defer ReleaseTanzuConfigLock()
+	node, err := getClientConfigNodeNoLock()
+	if err != nil {
+		return err
+	}
+	// check if key is empty
+	if key == "" {
+		return errors.New("key cannot be empty")
+	}
+	contextNode, err := getContextNode(node, contextName)
+	if err != nil {
+		return err
+	}
+	keys := []nodeutils.Key{
+		{Name: KeyEnv, Type: yaml.MappingNode},
+	}
+	persist, err := setEnvInNode(contextNode, keys, key, value)
+	if err != nil {
+		return err
+	}
+	if persist {
+		return persistConfig(node)
+	}
+	return nil
+}
+
+// DeleteContextEnv delete the env entry of specified key scoped to the specified context
+func DeleteContextEnv(contextName, key string) error {
+	// Retrieve client config node
+	AcquireTanzuConfigLock()
+	defer ReleaseTanzuConfigLock()
+	node, err := getClientConfigNodeNoLock()
+	if err != nil {
+		return err
+	}
+	// check if key is empty
+	if key == "" {
+		return errors.New("key cannot be empty")
+	}
+	contextNode, err := getContextNode(node, contextName)
+	if err != nil {
+		return err
+	}
+	keys := []nodeutils.Key{
+		{Name: KeyEnv},
+	}
+	if err := deleteEnvInNode(contextNode, keys, key); err != nil {
+		return err
+	}
+	return persistConfig(node)
+}
+
+// SetContextTypeEnv add or update a env key and value scoped to the specified context type
+func SetContextTypeEnv(contextType configtypes.ContextType, key, value string) error {
+	// The context type env overrides the global env, so it cannot be set if the global env is locked
+	if err := checkSystemConfigLock(DiffSectionEnvs, key); err != nil {
+		return err
+	}
+	// Retrieve client config node
+	AcquireTanzuConfigLock()
+	defer ReleaseTanzuConfigLock()
+	node, err := getClientConfigNodeNoLock()
+	if err != nil {
+		return err
+	}
+	if err := validateContextType(contextType); err != nil {
+		return err
+	}
+	// check if key is empty
+	if key == "" {
+		return errors.New("key cannot be empty")
+	}
+	keys := []nodeutils.Key{
+		{Name: KeyClientOptions, Type: yaml.MappingNode},
+		{Name: KeyContextTypeEnv, Type: yaml.MappingNode},
+		{Name: string(contextType), Type: yaml.MappingNode},
+	}
+	persist, err := setEnvInNode(node.Content[0], keys, key, value)
+	if err != nil {
+		return err
+	}
+	if persist {
+		return persistConfig(node)
+	}
+	return nil
+}
+
+// DeleteContextTypeEnv delete the env entry of specified key scoped to the specified context type
+func DeleteContextTypeEnv(contextType configtypes.ContextType, key string) error {
+	// Retrieve client config node
+	AcquireTanzuConfigLock()
+	defer ReleaseTanzuConfigLock()
+	node, err := getClientConfigNodeNoLock()
+	if err != nil {
+		return err
+	}
+	if err := validateContextType(contextType); err != nil {
+		return err
+	}
+	// check if key is empty
+	if key == "" {
+		return errors.New("key cannot be empty")
+	}
+	keys := []nodeutils.Key{
+		{Name: KeyClientOptions},
+		{Name: KeyContextTypeEnv},
+		{Name: string(contextType)},
+	}
+	if err := deleteEnvInNode(node.Content[0], keys, key); err != nil {
+		return err
+	}
+	return persistConfig(node)
+}
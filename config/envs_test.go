@@ -238,3 +238,94 @@ func TestDeleteEnv(t *testing.T) {
 		})
 	}
 }
+
+func TestContextEnvs(t *testing.T) {
+	// Setup config data
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+
+	defer func() {
+		cleanUp()
+	}()
+
+	err := SetContext(&configtypes.Context{
+		Name:        "test-k8s",
+		ContextType: configtypes.ContextTypeK8s,
+		ClusterOpts: &configtypes.ClusterServer{Endpoint: "test-k8s-endpoint"},
+	}, false)
+	assert.NoError(t, err)
+	err = SetContext(&configtypes.Context{
+		Name:        "test-tmc",
+		ContextType: configtypes.ContextTypeTMC,
+		GlobalOpts:  &configtypes.GlobalServer{Endpoint: "test-tmc-endpoint"},
+	}, false)
+	assert.NoError(t, err)
+
+	assert.NoError(t, SetEnv("REGISTRY", "global.registry"))
+	assert.NoError(t, SetEnv("PROXY", "global.proxy"))
+	assert.NoError(t, SetEnv("ENDPOINT", "${context.endpoint}/api"))
+	assert.NoError(t, SetContextTypeEnv(configtypes.ContextTypeK8s, "PROXY", "k8s.proxy"))
+	assert.NoError(t, SetContextEnv("test-k8s", "REGISTRY", "${PROXY}/registry"))
+	assert.NoError(t, SetContextEnv("test-tmc", "TMC_ONLY", "${context.name}"))
+
+	// Global envs are literal by default
+	envs, err := GetEnvConfigurationsForContext("test-k8s")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"REGISTRY": "k8s.proxy/registry",
+		"PROXY":    "k8s.proxy",
+		"ENDPOINT": "${context.endpoint}/api",
+	}, envs)
+
+	assert.NoError(t, SetConfigMetadataSetting(SettingExpandGlobalEnvs, "true"))
+	envs, err = GetEnvConfigurationsForContext("test-k8s")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"REGISTRY": "k8s.proxy/registry",
+		"PROXY":    "k8s.proxy",
+		"ENDPOINT": "test-k8s-endpoint/api",
+	}, envs)
+
+	// Global envs are not modified
+	val, err := GetEnv("REGISTRY")
+	assert.NoError(t, err)
+	assert.Equal(t, "global.registry", val)
+
+	// Without an active context the context variables cannot be expanded
+	envs = GetEnvConfigurations()
+	assert.Equal(t, "${context.endpoint}/api", envs["ENDPOINT"])
+	assert.Equal(t, "global.registry", envs["REGISTRY"])
+
+	// Active contexts envs are merged over the global envs, and expanded against their own context
+	assert.NoError(t, SetActiveContext("test-tmc"))
+	assert.NoError(t, SetActiveContext("test-k8s"))
+	envs = GetEnvConfigurations()
+	assert.Equal(t, map[string]string{
+		"REGISTRY": "k8s.proxy/registry",
+		"PROXY":    "k8s.proxy",
+		"ENDPOINT": "test-k8s-endpoint/api",
+		"TMC_ONLY": "test-tmc",
+	}, envs)
+
+	// An entry that cannot be expanded is left unexpanded without affecting the other entries
+	assert.NoError(t, SetContextEnv("test-k8s", "BROKEN", "${TEST_CONTEXT_ENVS_UNDEFINED}"))
+	envs = GetEnvConfigurations()
+	assert.Equal(t, "${TEST_CONTEXT_ENVS_UNDEFINED}", envs["BROKEN"])
+	assert.Equal(t, "k8s.proxy/registry", envs["REGISTRY"])
+	assert.Equal(t, "test-tmc", envs["TMC_ONLY"])
+	_, err = GetEnvConfigurationsForContext("test-k8s")
+	assert.ErrorContains(t, err, `undefined variable "TEST_CONTEXT_ENVS_UNDEFINED" referenced in env "BROKEN"`)
+	assert.NoError(t, DeleteContextEnv("test-k8s", "BROKEN"))
+
+	assert.NoError(t, DeleteContextEnv("test-k8s", "REGISTRY"))
+	assert.NoError(t, DeleteContextTypeEnv(configtypes.ContextTypeK8s, "PROXY"))
+	envs, err = GetEnvConfigurationsForContext("test-k8s")
+	assert.NoError(t, err)
+	assert.Equal(t, "global.registry", envs["REGISTRY"])
+	assert.Equal(t, "global.proxy", envs["PROXY"])
+
+	_, err = GetEnvConfigurationsForContext("unknown")
+	assert.Error(t, err)
+	assert.EqualError(t, SetContextEnv("unknown", "A", "a"), "context unknown not found")
+	assert.EqualError(t, SetContextTypeEnv("unknown", "A", "a"), `unsupported context type "unknown"`)
+	assert.EqualError(t, SetContextEnv("test-k8s", "", "a"), "key cannot be empty")
+}
@@ -0,0 +1,222 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"encoding/base64"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// EnvConfigInlineKey is the environment variable that holds a complete config as base64 encoded YAML or JSON.
+	// The config is held in memory for the lifetime of the process instead of CFG and CFG_NG.
+	EnvConfigInlineKey = "TANZU_CONFIG_INLINE"
+
+	// EnvConfigFDKey is the environment variable that holds the number of an open file descriptor
+	// to read a complete config in YAML or JSON from e.g. "3" with "3< config.yaml".
+	// The config is held in memory for the lifetime of the process instead of CFG and CFG_NG.
+	EnvConfigFDKey = "TANZU_CONFIG_FD"
+
+	// EnvConfigInlineWritesKey is the environment variable that configures the handling of the writes
+	// of an ephemeral config, either EphemeralWritesReject (the default) or EphemeralWritesInMemory.
+	EnvConfigInlineWritesKey = "TANZU_CONFIG_INLINE_WRITES"
+)
+
+// EphemeralWriteMode is the handling of the writes of an ephemeral config
+type EphemeralWriteMode string
+
+const (
+	// EphemeralWritesReject rejects the writes with ErrEphemeralConfigReadOnly
+	EphemeralWritesReject EphemeralWriteMode = "reject"
+	// EphemeralWritesInMemory keeps the writes in memory for the lifetime of the process
+	EphemeralWritesInMemory EphemeralWriteMode = "memory"
+)
+
+// ErrEphemeralConfigReadOnly is returned when writing an ephemeral config that rejects the writes
+var ErrEphemeralConfigReadOnly = errors.New("ephemeral config is read-only")
+
+// ephemeralConfig is the config held in memory for the lifetime of the process
+var ephemeralConfig = struct {
+	sync.Mutex
+	once     sync.Once
+	node     *yaml.Node
+	metadata *yaml.Node
+	mode     EphemeralWriteMode
+	revision int64
+	err      error
+}{}
+
+// IsEphemeralConfig checks whether the config is an ephemeral config provided with
+// TANZU_CONFIG_INLINE or TANZU_CONFIG_FD rather than CFG and CFG_NG
+func IsEphemeralConfig() bool {
+	loadEphemeralConfig()
+	ephemeralConfig.Lock()
+	defer ephemeralConfig.Unlock()
+	return ephemeralConfig.node != nil || ephemeralConfig.err != nil
+}
+
+// GetEphemeralWriteMode returns the handling of the writes of the ephemeral config
+func GetEphemeralWriteMode() EphemeralWriteMode {
+	loadEphemeralConfig()
+	ephemeralConfig.Lock()
+	defer ephemeralConfig.Unlock()
+	return ephemeralConfig.mode
+}
+
+// loadEphemeralConfig loads the ephemeral config from the environment once per process
+func loadEphemeralConfig() {
+	ephemeralConfig.once.Do(func() {
+		node, mode, err := readEphemeralConfig()
+		ephemeralConfig.Lock()
+		defer ephemeralConfig.Unlock()
+		ephemeralConfig.node, ephemeralConfig.mode, ephemeralConfig.err = node, mode, err
+	})
+}
+
+// readEphemeralConfig reads the ephemeral config from the environment.
+// Returns nil node if no ephemeral config is provided.
+func readEphemeralConfig() (*yaml.Node, EphemeralWriteMode, error) {
+	mode := EphemeralWriteMode(strings.ToLower(os.Getenv(EnvConfigInlineWritesKey)))
+	switch mode {
+	case "":
+		mode = EphemeralWritesReject
+	case EphemeralWritesReject, EphemeralWritesInMemory:
+	default:
+		return nil, mode, errors.Errorf("invalid %s %q, expected %q or %q", EnvConfigInlineWritesKey, mode, EphemeralWritesReject, EphemeralWritesInMemory)
+	}
+
+	inline, fd := os.Getenv(EnvConfigInlineKey), os.Getenv(EnvConfigFDKey)
+	var data []byte
+	switch {
+	case inline != "" && fd != "":
+		return nil, mode, errors.Errorf("only one of %s and %s can be set", EnvConfigInlineKey, EnvConfigFDKey)
+	case inline != "":
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(inline))
+		if err != nil {
+			return nil, mode, errors.Wrapf(err, "failed to decode %s", EnvConfigInlineKey)
+		}
+		data = decoded
+	case fd != "":
+		n, err := strconv.ParseUint(fd, 10, 32)
+		if err != nil {
+			return nil, mode, errors.Wrapf(err, "invalid %s %q", EnvConfigFDKey, fd)
+		}
+		f := os.NewFile(uintptr(n), "tanzu-config")
+		if f == nil {
+			return nil, mode, errors.Errorf("invalid %s %q", EnvConfigFDKey, fd)
+		}
+		defer f.Close()
+		data, err = io.ReadAll(f)
+		if err != nil {
+			return nil, mode, errors.Wrapf(err, "failed to read the config from file descriptor %d", n)
+		}
+	default:
+		return nil, mode, nil
+	}
+
+	node, err := parseEphemeralConfig(data)
+	return node, mode, err
+}
+
+// parseEphemeralConfig parses the YAML or JSON config into a client config node
+func parseEphemeralConfig(data []byte) (*yaml.Node, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, errors.Wrap(err, "failed to parse the ephemeral config")
+	}
+	if len(node.Content) == 0 {
+		return newClientConfigNode()
+	}
+	if node.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.New("failed to parse the ephemeral config: expected a mapping")
+	}
+	node.Content[0].Style = 0
+	if _, err := convertNodeToClientConfig(&node); err != nil {
+		return nil, errors.Wrap(err, "failed to parse the ephemeral config")
+	}
+	return &node, nil
+}
+
+// getEphemeralConfigNode returns a copy of the ephemeral config node, or nil if there is no ephemeral config
+func getEphemeralConfigNode() (*yaml.Node, error) {
+	loadEphemeralConfig()
+	ephemeralConfig.Lock()
+	defer ephemeralConfig.Unlock()
+	if ephemeralConfig.err != nil {
+		return nil, ephemeralConfig.err
+	}
+	if ephemeralConfig.node == nil {
+		return nil, nil
+	}
+	return cloneNode(ephemeralConfig.node), nil
+}
+
+// persistEphemeralConfig keeps the node in memory or rejects the write according to the write mode
+func persistEphemeralConfig(node *yaml.Node) error {
+	if err := checkReadOnly("the ephemeral config"); err != nil {
+		return err
+	}
+	ephemeralConfig.Lock()
+	defer ephemeralConfig.Unlock()
+	if ephemeralConfig.err != nil {
+		return ephemeralConfig.err
+	}
+	if ephemeralConfig.mode != EphemeralWritesInMemory {
+		return errors.Wrapf(ErrEphemeralConfigReadOnly, "cannot write the config provided by %s or %s", EnvConfigInlineKey, EnvConfigFDKey)
+	}
+	ephemeralConfig.node = cloneNode(node)
+	ephemeralConfig.revision++
+	return nil
+}
+
+// getEphemeralMetadataNode returns a copy of the config metadata written in memory along with the ephemeral config,
+// or nil if it was not written
+func getEphemeralMetadataNode() *yaml.Node {
+	ephemeralConfig.Lock()
+	defer ephemeralConfig.Unlock()
+	if ephemeralConfig.metadata == nil {
+		return nil
+	}
+	return cloneNode(ephemeralConfig.metadata)
+}
+
+// persistEphemeralMetadata keeps the config metadata node in memory or rejects the write according to
+// the write mode of the ephemeral config, so that the config metadata file is not written either
+func persistEphemeralMetadata(node *yaml.Node) error {
+	if err := checkReadOnly("the ephemeral config metadata"); err != nil {
+		return err
+	}
+	ephemeralConfig.Lock()
+	defer ephemeralConfig.Unlock()
+	if ephemeralConfig.err != nil {
+		return ephemeralConfig.err
+	}
+	if ephemeralConfig.mode != EphemeralWritesInMemory {
+		return errors.Wrapf(ErrEphemeralConfigReadOnly, "cannot write the config metadata along with the config provided by %s or %s", EnvConfigInlineKey, EnvConfigFDKey)
+	}
+	ephemeralConfig.metadata = cloneNode(node)
+	return nil
+}
+
+// getEphemeralConfigRevision returns the revision of the ephemeral config, incremented on every write in memory
+func getEphemeralConfigRevision() int64 {
+	ephemeralConfig.Lock()
+	defer ephemeralConfig.Unlock()
+	return ephemeralConfig.revision
+}
+
+// resetEphemeralConfig discards the ephemeral config so that it is loaded from the environment again
+func resetEphemeralConfig() {
+	ephemeralConfig.Lock()
+	defer ephemeralConfig.Unlock()
+	ephemeralConfig.once = sync.Once{}
+	ephemeralConfig.node, ephemeralConfig.metadata, ephemeralConfig.mode, ephemeralConfig.revision, ephemeralConfig.err = nil, nil, "", 0, nil
+}
@@ -0,0 +1,246 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"encoding/base64"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+const testEphemeralConfig = `clientOptions:
+  env:
+    FOO: bar
+contexts:
+  - name: ci
+    target: kubernetes
+    clusterOpts:
+      endpoint: https://ci
+      path: kubeconfig
+      context: ci
+currentContext:
+  kubernetes: ci
+`
+
+const testEphemeralConfigJSON = `{"clientOptions": {"env": {"FOO": "bar"}}, "contexts": [{"name": "ci", "target": "kubernetes", "clusterOpts": {"endpoint": "https://ci"}}]}`
+
+func setupTestEphemeralConfig(t *testing.T, content string, mode EphemeralWriteMode) {
+	t.Setenv(EnvConfigInlineKey, base64.StdEncoding.EncodeToString([]byte(content)))
+	t.Setenv(EnvConfigInlineWritesKey, string(mode))
+	resetEphemeralConfig()
+	t.Cleanup(resetEphemeralConfig)
+}
+
+func readTestConfigFiles(t *testing.T) string {
+	var content string
+	for _, key := range []string{EnvConfigKey, EnvConfigNextGenKey, EnvConfigMetadataKey} {
+		b, err := os.ReadFile(os.Getenv(key))
+		require.NoError(t, err)
+		content += string(b)
+	}
+	return content
+}
+
+func TestEphemeralConfigInline(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "yaml", content: testEphemeralConfig},
+		{name: "json", content: testEphemeralConfigJSON},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, cleanUp := setupTestConfig(t, &CfgTestData{})
+			defer cleanUp()
+			require.NoError(t, SetEnv("FOO", "on-disk"))
+			setupTestEphemeralConfig(t, tc.content, "")
+
+			assert.True(t, IsEphemeralConfig())
+			assert.Equal(t, EphemeralWritesReject, GetEphemeralWriteMode())
+			env, err := GetEnv("FOO")
+			require.NoError(t, err)
+			assert.Equal(t, "bar", env)
+			ctx, err := GetContext("ci")
+			require.NoError(t, err)
+			assert.Equal(t, "https://ci", ctx.ClusterOpts.Endpoint)
+		})
+	}
+}
+
+func TestEphemeralConfigWritesRejected(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+	setupTestEphemeralConfig(t, testEphemeralConfig, EphemeralWritesReject)
+	before := readTestConfigFiles(t)
+
+	err := SetEnv("FOO", "updated")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrEphemeralConfigReadOnly))
+	assert.ErrorIs(t, DeleteContext("ci"), ErrEphemeralConfigReadOnly)
+
+	env, err := GetEnv("FOO")
+	require.NoError(t, err)
+	assert.Equal(t, "bar", env)
+	assert.Equal(t, before, readTestConfigFiles(t))
+}
+
+func TestEphemeralConfigWritesInMemory(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+	setupTestEphemeralConfig(t, testEphemeralConfig, EphemeralWritesInMemory)
+	before := readTestConfigFiles(t)
+
+	require.NoError(t, SetEnv("FOO", "updated"))
+	require.NoError(t, SetContext(&configtypes.Context{Name: "other", ContextType: configtypes.ContextTypeK8s, ClusterOpts: &configtypes.ClusterServer{Endpoint: "https://other"}}, true))
+	require.NoError(t, UpdateContext("ci", func(c *configtypes.Context) error {
+		c.ClusterOpts.Endpoint = "https://ci-updated"
+		return nil
+	}))
+
+	env, err := GetEnv("FOO")
+	require.NoError(t, err)
+	assert.Equal(t, "updated", env)
+	ctx, err := GetActiveContext(configtypes.ContextTypeK8s)
+	require.NoError(t, err)
+	assert.Equal(t, "other", ctx.Name)
+	ctx, err = GetContext("ci")
+	require.NoError(t, err)
+	assert.Equal(t, "https://ci-updated", ctx.ClusterOpts.Endpoint)
+	revision, err := GetConfigRevision()
+	require.NoError(t, err)
+	assert.Greater(t, revision, int64(0))
+
+	// Nothing is written to disk
+	assert.Equal(t, before, readTestConfigFiles(t))
+
+	// The writes are discarded with the process
+	resetEphemeralConfig()
+	env, err = GetEnv("FOO")
+	require.NoError(t, err)
+	assert.Equal(t, "bar", env)
+}
+
+func TestEphemeralConfigMetadataWrites(t *testing.T) {
+	for _, mode := range []EphemeralWriteMode{EphemeralWritesReject, EphemeralWritesInMemory} {
+		t.Run(string(mode), func(t *testing.T) {
+			_, cleanUp := setupTestConfig(t, &CfgTestData{cfgMetadata: "configMetadata:\n  settings:\n    foo: on-disk\n"})
+			defer cleanUp()
+			setupTestEphemeralConfig(t, testEphemeralConfig, mode)
+			before := readTestConfigFiles(t)
+			auditPath, err := AuditFilePath()
+			require.NoError(t, err)
+
+			err = SetConfigMetadataSetting("foo", "updated")
+			setting, getErr := GetConfigMetadataSetting("foo")
+			require.NoError(t, getErr)
+			if mode == EphemeralWritesReject {
+				assert.ErrorIs(t, err, ErrEphemeralConfigReadOnly)
+				assert.ErrorIs(t, DeleteConfigMetadataSetting("foo"), ErrEphemeralConfigReadOnly)
+				assert.ErrorIs(t, SetConfigMetadataPatchStrategy("contexts.foo", "replace"), ErrEphemeralConfigReadOnly)
+				assert.Equal(t, "on-disk", setting)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "updated", setting)
+				require.NoError(t, SetConfigMetadataPatchStrategy("contexts.foo", "replace"))
+				patchStrategies, err := GetConfigMetadataPatchStrategy()
+				require.NoError(t, err)
+				assert.Equal(t, map[string]string{"contexts.foo": "replace"}, patchStrategies)
+			}
+
+			// Neither the config metadata file nor the audit trail are written
+			assert.Equal(t, before, readTestConfigFiles(t))
+			assert.NoFileExists(t, auditPath)
+		})
+	}
+}
+
+func TestEphemeralConfigFromFileDescriptor(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	_, err = w.WriteString(testEphemeralConfig)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	t.Setenv(EnvConfigFDKey, strconv.Itoa(int(r.Fd())))
+	resetEphemeralConfig()
+	t.Cleanup(resetEphemeralConfig)
+
+	env, err := GetEnv("FOO")
+	require.NoError(t, err)
+	assert.Equal(t, "bar", env)
+	// The file descriptor is closed once read, release the pipe without waiting for its finalizer
+	_ = r.Close()
+
+	// The config is read once per process
+	env, err = GetEnv("FOO")
+	require.NoError(t, err)
+	assert.Equal(t, "bar", env)
+}
+
+func TestInvalidEphemeralConfig(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+
+	tests := []struct {
+		name   string
+		env    map[string]string
+		errStr string
+	}{
+		{
+			name:   "invalid base64",
+			env:    map[string]string{EnvConfigInlineKey: "not base64!"},
+			errStr: "failed to decode TANZU_CONFIG_INLINE",
+		},
+		{
+			name:   "invalid yaml",
+			env:    map[string]string{EnvConfigInlineKey: base64.StdEncoding.EncodeToString([]byte("contexts: [\n"))},
+			errStr: "failed to parse the ephemeral config",
+		},
+		{
+			name:   "not a mapping",
+			env:    map[string]string{EnvConfigInlineKey: base64.StdEncoding.EncodeToString([]byte("- a\n"))},
+			errStr: "failed to parse the ephemeral config: expected a mapping",
+		},
+		{
+			name:   "invalid file descriptor",
+			env:    map[string]string{EnvConfigFDKey: "fd"},
+			errStr: `invalid TANZU_CONFIG_FD "fd"`,
+		},
+		{
+			name:   "inline and file descriptor",
+			env:    map[string]string{EnvConfigInlineKey: "e30=", EnvConfigFDKey: "3"},
+			errStr: "only one of TANZU_CONFIG_INLINE and TANZU_CONFIG_FD can be set",
+		},
+		{
+			name:   "invalid write mode",
+			env:    map[string]string{EnvConfigInlineKey: "e30=", EnvConfigInlineWritesKey: "disk"},
+			errStr: `invalid TANZU_CONFIG_INLINE_WRITES "disk"`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+			resetEphemeralConfig()
+			t.Cleanup(resetEphemeralConfig)
+
+			assert.True(t, IsEphemeralConfig())
+			_, err := GetClientConfig()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.errStr)
+			assert.Error(t, SetEnv("FOO", "bar"))
+		})
+	}
+}
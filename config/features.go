@@ -28,12 +28,12 @@ func GetAllFeatureFlags() (map[string]types.FeatureMap, error) {
 
 // IsFeatureEnabled checks and returns whether specific plugin and key is true
 func IsFeatureEnabled(plugin, key string) (bool, error) {
-	// Retrieve client config node
-	node, err := getClientConfigNode()
+	// Retrieve the client config, which is cached while the config files are unchanged
+	cfg, err := readClientConfig()
 	if err != nil {
 		return false, err
 	}
-	val, err := getFeature(node, plugin, key)
+	val, err := getFeature(cfg, plugin, key)
 	if err != nil {
 		return false, err
 	}
@@ -43,7 +43,7 @@ func IsFeatureEnabled(plugin, key string) (bool, error) {
 	return false, nil
 }
 
-func getFeature(node *yaml.Node, plugin, key string) (string, error) {
+func getFeature(cfg *types.ClientConfig, plugin, key string) (string, error) {
 	// check if plugin is empty
 	if plugin == "" {
 		return "", errors.New("plugin cannot be empty")
@@ -54,10 +54,6 @@ func getFeature(node *yaml.Node, plugin, key string) (string, error) {
 		return "", errors.New("key cannot be empty")
 	}
 
-	cfg, err := convertNodeToClientConfig(node)
-	if err != nil {
-		return "", err
-	}
 	if cfg.ClientOptions == nil || cfg.ClientOptions.Features == nil || cfg.ClientOptions.Features[plugin] == nil {
 		return "", errors.New("not found")
 	}
@@ -69,6 +65,9 @@ func getFeature(node *yaml.Node, plugin, key string) (string, error) {
 
 // DeleteFeature deletes the specified plugin key
 func DeleteFeature(plugin, key string) error {
+	if err := checkSystemConfigLock(DiffSectionFeatures, plugin+"."+key); err != nil {
+		return err
+	}
 	// Retrieve client config node
 	AcquireTanzuConfigLock()
 	defer ReleaseTanzuConfigLock()
@@ -99,7 +98,12 @@ func deleteFeature(node *yaml.Node, plugin, key string) error {
 		{Name: KeyFeatures},
 		{Name: plugin},
 	}
-	pluginNode := nodeutils.FindNode(node.Content[0], nodeutils.WithKeys(keys))
+	return deletePluginFeature(node.Content[0], keys, key)
+}
+
+// deletePluginFeature deletes the feature key in the plugin node found under parent node by keys
+func deletePluginFeature(parent *yaml.Node, keys []nodeutils.Key, key string) error {
+	pluginNode := nodeutils.FindNode(parent, nodeutils.WithKeys(keys))
 	if pluginNode == nil {
 		return nil
 	}
@@ -118,6 +122,9 @@ func deleteFeature(node *yaml.Node, plugin, key string) error {
 
 // SetFeature add or update plugin key value
 func SetFeature(plugin, key, value string) (err error) {
+	if err := checkSystemConfigLock(DiffSectionFeatures, plugin+"."+key); err != nil {
+		return err
+	}
 	// Retrieve client config node
 	AcquireTanzuConfigLock()
 	defer ReleaseTanzuConfigLock()
@@ -158,7 +165,12 @@ func setFeature(node *yaml.Node, plugin, key, value string) (persist bool, err e
 		{Name: KeyFeatures, Type: yaml.MappingNode},
 		{Name: plugin, Type: yaml.MappingNode},
 	}
-	pluginNode := nodeutils.FindNode(node.Content[0], nodeutils.WithForceCreate(), nodeutils.WithKeys(keys))
+	return setPluginFeature(node.Content[0], keys, key, value)
+}
+
+// setPluginFeature add or update the feature key value in the plugin node found under parent node by keys
+func setPluginFeature(parent *yaml.Node, keys []nodeutils.Key, key, value string) (persist bool, err error) {
+	pluginNode := nodeutils.FindNode(parent, nodeutils.WithForceCreate(), nodeutils.WithKeys(keys))
 	if pluginNode == nil {
 		return persist, nodeutils.ErrNodeNotFound
 	}
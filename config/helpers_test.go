@@ -5,6 +5,7 @@ package config
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -42,6 +43,23 @@ func setupTestConfig(t *testing.T, data *CfgTestData) (files []*os.File, cleanup
 	err = os.Setenv(EnvConfigMetadataKey, cfgMetadataFile.Name())
 	assert.NoError(t, err)
 
+	// Isolate the tests from the system config, config policy and ephemeral config of the host
+	err = os.Setenv(EnvSystemConfigKey, "")
+	assert.NoError(t, err)
+	err = os.Setenv(EnvConfigPolicyKey, "")
+	assert.NoError(t, err)
+	err = os.Setenv(EnvConfigInlineKey, "")
+	assert.NoError(t, err)
+	err = os.Setenv(EnvConfigFDKey, "")
+	assert.NoError(t, err)
+	resetEphemeralConfig()
+
+	auditDir, err := os.MkdirTemp("", "tanzu_config_audit")
+	assert.Nil(t, err)
+
+	err = os.Setenv(EnvConfigAuditFileKey, filepath.Join(auditDir, ConfigAuditName))
+	assert.NoError(t, err)
+
 	cleanup = func() {
 		err = os.Remove(cfgFile.Name())
 		assert.NoError(t, err)
@@ -51,6 +69,9 @@ func setupTestConfig(t *testing.T, data *CfgTestData) (files []*os.File, cleanup
 
 		err = os.Remove(cfgMetadataFile.Name())
 		assert.NoError(t, err)
+
+		err = os.RemoveAll(auditDir)
+		assert.NoError(t, err)
 	}
 
 	return []*os.File{cfgFile, cfgNextGenFile, cfgMetadataFile}, cleanup
@@ -0,0 +1,216 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+// The interfaces below expose the context, feature, env and cert APIs, which are the parts of the config API
+// used by most plugins, so that plugins can depend on them and substitute the fakes in fakes/config in tests.
+// DefaultAPI implements all of them by delegating to the package functions.
+// The other APIs, e.g. discovery sources, repositories, CLI options, metadata settings, policy, audit,
+// context bundles, kubeconfig reconciliation, XDG directories and plugin caches, are not covered and are
+// called directly through the package functions.
+//
+// The fakes are generated with the counterfeiter version pinned in hack/tools/Makefile (make generate).
+
+//go:generate ../hack/tools/bin/counterfeiter -generate
+
+//counterfeiter:generate -o ../fakes/config . ContextReader
+//counterfeiter:generate -o ../fakes/config . ContextWriter
+//counterfeiter:generate -o ../fakes/config . FeatureReader
+//counterfeiter:generate -o ../fakes/config . FeatureWriter
+//counterfeiter:generate -o ../fakes/config . EnvReader
+//counterfeiter:generate -o ../fakes/config . EnvWriter
+//counterfeiter:generate -o ../fakes/config . CertStore
+//counterfeiter:generate -o ../fakes/config . API
+
+// ContextReader reads the contexts
+type ContextReader interface {
+	GetContext(name string) (*configtypes.Context, error)
+	ContextExists(name string) (bool, error)
+	GetActiveContext(contextType configtypes.ContextType) (*configtypes.Context, error)
+	GetContextsByType(contextType configtypes.ContextType) ([]*configtypes.Context, error)
+	GetAllActiveContextsMap() (map[configtypes.ContextType]*configtypes.Context, error)
+}
+
+// ContextWriter adds, updates, deletes and activates the contexts
+type ContextWriter interface {
+	SetContext(c *configtypes.Context, setCurrent bool) error
+	DeleteContext(name string) error
+	SetActiveContext(name string) error
+	RemoveActiveContext(contextType configtypes.ContextType) error
+}
+
+// FeatureReader reads the feature flags of the plugins
+type FeatureReader interface {
+	IsFeatureEnabled(plugin, key string) (bool, error)
+	IsFeatureEnabledForContext(plugin, key, contextName string) (bool, error)
+	GetAllFeatureFlags() (map[string]configtypes.FeatureMap, error)
+}
+
+// FeatureWriter sets and deletes the feature flags of the plugins
+type FeatureWriter interface {
+	SetFeature(plugin, key, value string) error
+	DeleteFeature(plugin, key string) error
+}
+
+// EnvReader reads the env variables
+type EnvReader interface {
+	GetEnv(key string) (string, error)
+	GetAllEnvs() (map[string]string, error)
+	GetEnvConfigurationsForContext(contextName string) (map[string]string, error)
+}
+
+// EnvWriter sets and deletes the env variables
+type EnvWriter interface {
+	SetEnv(key, value string) error
+	DeleteEnv(key string) error
+}
+
+// CertStore reads and writes the certs
+type CertStore interface {
+	GetCerts() ([]*configtypes.Cert, error)
+	GetCert(host string) (*configtypes.Cert, error)
+	CertExists(host string) (bool, error)
+	SetCert(c *configtypes.Cert) error
+	DeleteCert(host string) error
+}
+
+// API is the config API exposed through the interfaces above
+type API interface {
+	ContextReader
+	ContextWriter
+	FeatureReader
+	FeatureWriter
+	EnvReader
+	EnvWriter
+	CertStore
+}
+
+// DefaultAPI implements API by delegating to the package functions
+type DefaultAPI struct{}
+
+var _ API = DefaultAPI{}
+
+// GetContext delegates to GetContext
+func (DefaultAPI) GetContext(name string) (*configtypes.Context, error) {
+	return GetContext(name)
+}
+
+// ContextExists delegates to ContextExists
+func (DefaultAPI) ContextExists(name string) (bool, error) {
+	return ContextExists(name)
+}
+
+// GetActiveContext delegates to GetActiveContext
+func (DefaultAPI) GetActiveContext(contextType configtypes.ContextType) (*configtypes.Context, error) {
+	return GetActiveContext(contextType)
+}
+
+// GetContextsByType delegates to GetContextsByType
+func (DefaultAPI) GetContextsByType(contextType configtypes.ContextType) ([]*configtypes.Context, error) {
+	return GetContextsByType(contextType)
+}
+
+// GetAllActiveContextsMap delegates to GetAllActiveContextsMap
+func (DefaultAPI) GetAllActiveContextsMap() (map[configtypes.ContextType]*configtypes.Context, error) {
+	return GetAllActiveContextsMap()
+}
+
+// SetContext delegates to SetContext
+func (DefaultAPI) SetContext(c *configtypes.Context, setCurrent bool) error {
+	return SetContext(c, setCurrent)
+}
+
+// DeleteContext delegates to DeleteContext
+func (DefaultAPI) DeleteContext(name string) error {
+	return DeleteContext(name)
+}
+
+// SetActiveContext delegates to SetActiveContext
+func (DefaultAPI) SetActiveContext(name string) error {
+	return SetActiveContext(name)
+}
+
+// RemoveActiveContext delegates to RemoveActiveContext
+func (DefaultAPI) RemoveActiveContext(contextType configtypes.ContextType) error {
+	return RemoveActiveContext(contextType)
+}
+
+// IsFeatureEnabled delegates to IsFeatureEnabled
+func (DefaultAPI) IsFeatureEnabled(plugin, key string) (bool, error) {
+	return IsFeatureEnabled(plugin, key)
+}
+
+// IsFeatureEnabledForContext delegates to IsFeatureEnabledForContext
+func (DefaultAPI) IsFeatureEnabledForContext(plugin, key, contextName string) (bool, error) {
+	return IsFeatureEnabledForContext(plugin, key, contextName)
+}
+
+// GetAllFeatureFlags delegates to GetAllFeatureFlags
+func (DefaultAPI) GetAllFeatureFlags() (map[string]configtypes.FeatureMap, error) {
+	return GetAllFeatureFlags()
+}
+
+// SetFeature delegates to SetFeature
+func (DefaultAPI) SetFeature(plugin, key, value string) error {
+	return SetFeature(plugin, key, value)
+}
+
+// DeleteFeature delegates to DeleteFeature
+func (DefaultAPI) DeleteFeature(plugin, key string) error {
+	return DeleteFeature(plugin, key)
+}
+
+// GetEnv delegates to GetEnv
+func (DefaultAPI) GetEnv(key string) (string, error) {
+	return GetEnv(key)
+}
+
+// GetAllEnvs delegates to GetAllEnvs
+func (DefaultAPI) GetAllEnvs() (map[string]string, error) {
+	return GetAllEnvs()
+}
+
+// GetEnvConfigurationsForContext delegates to GetEnvConfigurationsForContext
+func (DefaultAPI) GetEnvConfigurationsForContext(contextName string) (map[string]string, error) {
+	return GetEnvConfigurationsForContext(contextName)
+}
+
+// SetEnv delegates to SetEnv
+func (DefaultAPI) SetEnv(key, value string) error {
+	return SetEnv(key, value)
+}
+
+// DeleteEnv delegates to DeleteEnv
+func (DefaultAPI) DeleteEnv(key string) error {
+	return DeleteEnv(key)
+}
+
+// GetCerts delegates to GetCerts
+func (DefaultAPI) GetCerts() ([]*configtypes.Cert, error) {
+	return GetCerts()
+}
+
+// GetCert delegates to GetCert
+func (DefaultAPI) GetCert(host string) (*configtypes.Cert, error) {
+	return GetCert(host)
+}
+
+// CertExists delegates to CertExists
+func (DefaultAPI) CertExists(host string) (bool, error) {
+	return CertExists(host)
+}
+
+// SetCert delegates to SetCert
+func (DefaultAPI) SetCert(c *configtypes.Cert) error {
+	return SetCert(c)
+}
+
+// DeleteCert delegates to DeleteCert
+func (DefaultAPI) DeleteCert(host string) error {
+	return DeleteCert(host)
+}
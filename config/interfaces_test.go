@@ -0,0 +1,80 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+func TestDefaultAPI(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+	var api API = DefaultAPI{}
+
+	require.NoError(t, api.SetContext(&configtypes.Context{
+		Name:        "test-k8s",
+		ContextType: configtypes.ContextTypeK8s,
+		ClusterOpts: &configtypes.ClusterServer{Endpoint: "test-endpoint", Path: "test-path", Context: "test-context"},
+	}, true))
+	ctx, err := api.GetContext("test-k8s")
+	require.NoError(t, err)
+	assert.Equal(t, "test-endpoint", ctx.ClusterOpts.Endpoint)
+	exists, err := api.ContextExists("test-k8s")
+	require.NoError(t, err)
+	assert.True(t, exists)
+	ctx, err = api.GetActiveContext(configtypes.ContextTypeK8s)
+	require.NoError(t, err)
+	assert.Equal(t, "test-k8s", ctx.Name)
+	require.NoError(t, api.RemoveActiveContext(configtypes.ContextTypeK8s))
+	active, err := api.GetAllActiveContextsMap()
+	require.NoError(t, err)
+	assert.Empty(t, active)
+	require.NoError(t, api.SetActiveContext("test-k8s"))
+	contexts, err := api.GetContextsByType(configtypes.ContextTypeK8s)
+	require.NoError(t, err)
+	assert.Len(t, contexts, 1)
+	require.NoError(t, api.DeleteContext("test-k8s"))
+	exists, err = api.ContextExists("test-k8s")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	require.NoError(t, api.SetFeature("test-plugin", "test-feature", "true"))
+	enabled, err := api.IsFeatureEnabled("test-plugin", "test-feature")
+	require.NoError(t, err)
+	assert.True(t, enabled)
+	flags, err := api.GetAllFeatureFlags()
+	require.NoError(t, err)
+	assert.Equal(t, "true", flags["test-plugin"]["test-feature"])
+	require.NoError(t, api.DeleteFeature("test-plugin", "test-feature"))
+	_, err = api.IsFeatureEnabled("test-plugin", "test-feature")
+	assert.Error(t, err)
+
+	require.NoError(t, api.SetEnv("test-env", "value"))
+	value, err := api.GetEnv("test-env")
+	require.NoError(t, err)
+	assert.Equal(t, "value", value)
+	envs, err := api.GetAllEnvs()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"test-env": "value"}, envs)
+	require.NoError(t, api.DeleteEnv("test-env"))
+	_, err = api.GetEnv("test-env")
+	assert.Error(t, err)
+
+	require.NoError(t, api.SetCert(&configtypes.Cert{Host: "test-host", SkipCertVerify: "true"}))
+	cert, err := api.GetCert("test-host")
+	require.NoError(t, err)
+	assert.Equal(t, "true", cert.SkipCertVerify)
+	certs, err := api.GetCerts()
+	require.NoError(t, err)
+	assert.Len(t, certs, 1)
+	require.NoError(t, api.DeleteCert("test-host"))
+	exists, err = api.CertExists("test-host")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
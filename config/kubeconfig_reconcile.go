@@ -0,0 +1,380 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/config/internal/kubeconfig"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/config/nodeutils"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+// KubeconfigStaleKey is the AdditionalMetadata key of a context marked stale by ReconcileKubeconfigContext.
+// Its value is the KubeconfigDriftKind found when the context was marked.
+const KubeconfigStaleKey = "tanzuKubeconfigStale"
+
+// KubeconfigDriftKind is the kind of difference between a context and the kubeconfig it references
+type KubeconfigDriftKind string
+
+const (
+	// KubeconfigMissing indicates the kubeconfig file of the context does not exist
+	KubeconfigMissing KubeconfigDriftKind = "kubeconfig-missing"
+	// KubeContextMissing indicates the kube context of the context is not in the kubeconfig
+	KubeContextMissing KubeconfigDriftKind = "kube-context-missing"
+	// KubeContextRenamed indicates the kube context of the context is not in the kubeconfig but a
+	// single kube context, not referenced by any other context, points to the endpoint of the context
+	KubeContextRenamed KubeconfigDriftKind = "kube-context-renamed"
+	// KubeconfigServerChanged indicates the server of the kube context differs from the endpoint of the context
+	KubeconfigServerChanged KubeconfigDriftKind = "server-changed"
+)
+
+// KubeconfigReconcileAction is the action taken by ReconcileKubeconfigContext
+type KubeconfigReconcileAction string
+
+const (
+	// KubeconfigReconcileUpdate updates the endpoint and, for a renamed kube context, the kube context of the context.
+	// It only applies to KubeContextRenamed and KubeconfigServerChanged.
+	KubeconfigReconcileUpdate KubeconfigReconcileAction = "update"
+	// KubeconfigReconcileMarkStale sets KubeconfigStaleKey in the AdditionalMetadata of the context
+	KubeconfigReconcileMarkStale KubeconfigReconcileAction = "mark-stale"
+	// KubeconfigReconcileRemove deletes the context
+	KubeconfigReconcileRemove KubeconfigReconcileAction = "remove"
+)
+
+// KubeconfigDrift is a difference between a context and the kubeconfig it references
+type KubeconfigDrift struct {
+	// Context is the name of the context
+	Context string `json:"context" yaml:"context"`
+	// Kind of the difference
+	Kind KubeconfigDriftKind `json:"kind" yaml:"kind"`
+	// Path of the kubeconfig referenced by the context
+	Path string `json:"path" yaml:"path"`
+	// KubeContext referenced by the context
+	KubeContext string `json:"kubeContext" yaml:"kubeContext"`
+	// Endpoint of the context
+	Endpoint string `json:"endpoint,omitempty" yaml:"endpoint,omitempty"`
+	// Server of the kube context in the kubeconfig, set for KubeContextRenamed and KubeconfigServerChanged
+	Server string `json:"server,omitempty" yaml:"server,omitempty"`
+	// RenamedTo is the kube context the context most likely refers to now, set for KubeContextRenamed
+	RenamedTo string `json:"renamedTo,omitempty" yaml:"renamedTo,omitempty"`
+}
+
+// KubeconfigContextsOptions are the options of AddContextsFromKubeconfig
+type KubeconfigContextsOptions struct {
+	// KubeContexts to add contexts for, all the kube contexts of the kubeconfig if empty
+	KubeContexts []string
+	// OnCollision is the handling of an existing context with the same name, defaults to ImportCollisionFail
+	OnCollision ImportCollisionPolicy
+}
+
+type KubeconfigContextsOpts func(o *KubeconfigContextsOptions)
+
+// WithKubeContexts adds contexts only for the specified kube contexts
+func WithKubeContexts(kubeContexts ...string) KubeconfigContextsOpts {
+	return func(o *KubeconfigContextsOptions) {
+		o.KubeContexts = kubeContexts
+	}
+}
+
+// WithKubeconfigCollisionPolicy sets the handling of an existing context with the same name as a kube context
+func WithKubeconfigCollisionPolicy(policy ImportCollisionPolicy) KubeconfigContextsOpts {
+	return func(o *KubeconfigContextsOptions) {
+		o.OnCollision = policy
+	}
+}
+
+// ScanKubeconfigContexts compares every context that references a kubeconfig with that kubeconfig and
+// returns the contexts whose kubeconfig or kube context is missing, whose kube context was renamed, or
+// whose endpoint differs from the server of the kube context.
+func ScanKubeconfigContexts() ([]KubeconfigDrift, error) {
+	cfg, err := GetClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	return scanKubeconfigContexts(cfg.KnownContexts)
+}
+
+// scanKubeconfigContexts compares every context that references a kubeconfig with that kubeconfig
+func scanKubeconfigContexts(contexts []*configtypes.Context) ([]KubeconfigDrift, error) {
+	var err error
+	// kube contexts referenced by a context, keyed on the kubeconfig path
+	referenced := make(map[string]map[string]bool)
+	for _, ctx := range contexts {
+		if ctx.ClusterOpts == nil || ctx.ClusterOpts.Path == "" {
+			continue
+		}
+		path := filepath.Clean(ctx.ClusterOpts.Path)
+		if referenced[path] == nil {
+			referenced[path] = make(map[string]bool)
+		}
+		referenced[path][ctx.ClusterOpts.Context] = true
+	}
+
+	drifts := make([]KubeconfigDrift, 0)
+	kubeconfigs := make(map[string]*kubeconfig.Config)
+	for _, ctx := range contexts {
+		if ctx.ClusterOpts == nil || ctx.ClusterOpts.Path == "" {
+			continue
+		}
+		drift := KubeconfigDrift{
+			Context:     ctx.Name,
+			Path:        ctx.ClusterOpts.Path,
+			KubeContext: ctx.ClusterOpts.Context,
+			Endpoint:    ctx.ClusterOpts.Endpoint,
+		}
+		path := filepath.Clean(ctx.ClusterOpts.Path)
+		kc, ok := kubeconfigs[path]
+		if !ok {
+			kc, err = kubeconfig.ReadKubeConfig(path)
+			if err != nil {
+				if !os.IsNotExist(err) {
+					return nil, errors.Wrapf(err, "failed to read the kubeconfig of context %q", ctx.Name)
+				}
+				kc = nil
+			}
+			kubeconfigs[path] = kc
+		}
+		if kc == nil {
+			drift.Kind = KubeconfigMissing
+			drifts = append(drifts, drift)
+			continue
+		}
+
+		if kubeContext := kubeconfig.GetContext(kc, ctx.ClusterOpts.Context); kubeContext != nil {
+			// The server of Tanzu contexts is derived from the active resource, only kubernetes contexts are compared
+			server := kubeContextServer(kc, kubeContext)
+			if ctx.ContextType == configtypes.ContextTypeK8s && ctx.ClusterOpts.Endpoint != "" && server != "" && server != ctx.ClusterOpts.Endpoint {
+				drift.Kind = KubeconfigServerChanged
+				drift.Server = server
+				drifts = append(drifts, drift)
+			}
+			continue
+		}
+
+		drift.Kind = KubeContextMissing
+		var candidates []*kubeconfig.Context
+		if ctx.ClusterOpts.Endpoint != "" {
+			for _, kubeContext := range kc.Contexts {
+				if !referenced[path][kubeContext.Name] && kubeContextServer(kc, kubeContext) == ctx.ClusterOpts.Endpoint {
+					candidates = append(candidates, kubeContext)
+				}
+			}
+		}
+		if len(candidates) == 1 {
+			drift.Kind = KubeContextRenamed
+			drift.RenamedTo = candidates[0].Name
+			drift.Server = ctx.ClusterOpts.Endpoint
+		}
+		drifts = append(drifts, drift)
+	}
+	return drifts, nil
+}
+
+// ReconcileKubeconfigContext applies the action to the context of the drift returned by ScanKubeconfigContexts.
+// The context is scanned again along with the update of the config, which fails if the drift has changed since.
+func ReconcileKubeconfigContext(drift KubeconfigDrift, action KubeconfigReconcileAction) error {
+	switch action {
+	case KubeconfigReconcileUpdate:
+		if drift.Kind != KubeContextRenamed && drift.Kind != KubeconfigServerChanged {
+			return errors.Errorf("cannot update context %q: the %s drift has no replacement kube context or server", drift.Context, drift.Kind)
+		}
+	case KubeconfigReconcileMarkStale, KubeconfigReconcileRemove:
+	default:
+		return errors.Errorf("unsupported kubeconfig reconcile action %q", action)
+	}
+
+	AcquireTanzuConfigLock()
+	defer ReleaseTanzuConfigLock()
+	node, err := getClientConfigNodeNoLock()
+	if err != nil {
+		return err
+	}
+	ctx, err := getContext(node, drift.Context)
+	if err != nil {
+		return err
+	}
+	if err := checkKubeconfigDrift(node, drift); err != nil {
+		return err
+	}
+
+	persist := true
+	switch action {
+	case KubeconfigReconcileUpdate:
+		if ctx.ClusterOpts == nil {
+			return errors.Errorf("context %q does not have cluster options", drift.Context)
+		}
+		ctx.ClusterOpts.Endpoint = drift.Server
+		if drift.Kind == KubeContextRenamed {
+			ctx.ClusterOpts.Context = drift.RenamedTo
+		}
+		if _, err := setContextAndServer(node, ctx, false); err != nil {
+			return err
+		}
+		if _, err := setKubeconfigStaleMarker(node, drift.Context, ""); err != nil {
+			return err
+		}
+	case KubeconfigReconcileMarkStale:
+		persist, err = setKubeconfigStaleMarker(node, drift.Context, string(drift.Kind))
+		if err != nil {
+			return err
+		}
+	case KubeconfigReconcileRemove:
+		if err := removeContextAndServer(node, drift.Context); err != nil {
+			return err
+		}
+	}
+	if persist {
+		return persistConfig(node)
+	}
+	return nil
+}
+
+// checkKubeconfigDrift returns an error if the context of the node no longer has the drift
+func checkKubeconfigDrift(node *yaml.Node, drift KubeconfigDrift) error {
+	cfg, err := convertNodeToClientConfig(node)
+	if err != nil {
+		return err
+	}
+	drifts, err := scanKubeconfigContexts(cfg.KnownContexts)
+	if err != nil {
+		return err
+	}
+	for i := range drifts {
+		if drifts[i] == drift {
+			return nil
+		}
+	}
+	return errors.Errorf("the %s drift of context %q has changed since the scan", drift.Kind, drift.Context)
+}
+
+// AddContextsFromKubeconfig adds a kubernetes context for each kube context of the kubeconfig, named after
+// the kube context. With ImportCollisionFail no context is added if any of the names is already used.
+// The contexts are added in a single update of the config, so either all or none are added.
+// Returns the added contexts.
+func AddContextsFromKubeconfig(path string, opts ...KubeconfigContextsOpts) ([]*configtypes.Context, error) {
+	options := &KubeconfigContextsOptions{OnCollision: ImportCollisionFail}
+	for _, opt := range opts {
+		opt(options)
+	}
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid kubeconfig path %q", path)
+	}
+	kc, err := kubeconfig.ReadKubeConfig(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read the kubeconfig %q", path)
+	}
+
+	var kubeContexts []*kubeconfig.Context
+	if len(options.KubeContexts) == 0 {
+		kubeContexts = kc.Contexts
+	}
+	for _, name := range options.KubeContexts {
+		kubeContext := kubeconfig.GetContext(kc, name)
+		if kubeContext == nil {
+			return nil, errors.Errorf("kubeconfig %q does not contain the kube context %q", path, name)
+		}
+		kubeContexts = append(kubeContexts, kubeContext)
+	}
+	// The contexts are added in a single update of the config, so that a failure leaves the config untouched
+	AcquireTanzuConfigLock()
+	defer ReleaseTanzuConfigLock()
+	node, err := getClientConfigNodeNoLock()
+	if err != nil {
+		return nil, err
+	}
+	if options.OnCollision == ImportCollisionFail || options.OnCollision == "" {
+		for _, kubeContext := range kubeContexts {
+			if _, err := resolveContextNameCollision(node, kubeContext.Name, ImportCollisionFail); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	contexts := make([]*configtypes.Context, 0, len(kubeContexts))
+	for _, kubeContext := range kubeContexts {
+		name, err := resolveContextNameCollision(node, kubeContext.Name, options.OnCollision)
+		if err != nil {
+			return nil, err
+		}
+		ctx := &configtypes.Context{
+			Name:        name,
+			ContextType: configtypes.ContextTypeK8s,
+			ClusterOpts: &configtypes.ClusterServer{
+				Endpoint: kubeContextServer(kc, kubeContext),
+				Path:     path,
+				Context:  kubeContext.Name,
+			},
+		}
+		if options.OnCollision == ImportCollisionOverwrite {
+			if _, err := getContext(node, name); err == nil {
+				if err := removeContextAndServer(node, name); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if _, err := setContextAndServer(node, ctx, false); err != nil {
+			return nil, err
+		}
+		contexts = append(contexts, ctx)
+	}
+	if len(contexts) == 0 {
+		return contexts, nil
+	}
+	if err := persistConfig(node); err != nil {
+		return nil, err
+	}
+	return contexts, nil
+}
+
+// kubeContextServer returns the server of the cluster of the kube context
+func kubeContextServer(kc *kubeconfig.Config, kubeContext *kubeconfig.Context) string {
+	if cluster := kubeconfig.GetCluster(kc, kubeContext.Context.Cluster); cluster != nil {
+		return cluster.Cluster.Server
+	}
+	return ""
+}
+
+// setKubeconfigStaleMarker sets KubeconfigStaleKey in the AdditionalMetadata of the context of the node to the reason,
+// or removes it if the reason is empty. The node is edited directly as setContext merges AdditionalMetadata.
+// Returns true if the node is modified.
+func setKubeconfigStaleMarker(node *yaml.Node, name, reason string) (bool, error) {
+	contextNode, err := getContextNode(node, name)
+	if err != nil {
+		return false, err
+	}
+
+	keys := []nodeutils.Key{{Name: KeyAdditionalMetadata, Type: yaml.MappingNode}}
+	if reason == "" {
+		metadataNode := nodeutils.FindNode(contextNode, nodeutils.WithKeys(keys))
+		if metadataNode == nil {
+			return false, nil
+		}
+		index := nodeutils.GetNodeIndex(metadataNode.Content, KubeconfigStaleKey)
+		if index == -1 {
+			return false, nil
+		}
+		// GetNodeIndex returns the index of the value, the key precedes it
+		metadataNode.Content = append(metadataNode.Content[:index-1], metadataNode.Content[index+1:]...)
+		return true, nil
+	}
+	metadataNode := nodeutils.FindNode(contextNode, nodeutils.WithForceCreate(), nodeutils.WithKeys(keys))
+	if metadataNode == nil {
+		return false, errors.Errorf("failed to mark context %q stale", name)
+	}
+	if index := nodeutils.GetNodeIndex(metadataNode.Content, KubeconfigStaleKey); index != -1 {
+		if metadataNode.Content[index].Value == reason {
+			return false, nil
+		}
+		metadataNode.Content[index].Value = reason
+	} else {
+		metadataNode.Content = append(metadataNode.Content, nodeutils.CreateScalarNode(KubeconfigStaleKey, reason)...)
+	}
+	return true, nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+// setupTestReconcileContexts adds contexts for the kube contexts of testBundleKubeconfig and then edits
+// the kubeconfig the way kubectl would: dev is renamed, other points to a new server and a context is added
+// for a deleted kube context and for a deleted kubeconfig
+func setupTestReconcileContexts(t *testing.T) string {
+	kubeconfigPath := filepath.Join(t.TempDir(), "kubeconfig")
+	require.NoError(t, os.WriteFile(kubeconfigPath, []byte(testBundleKubeconfig), 0600))
+	for _, ctx := range []*configtypes.Context{
+		{Name: "dev", ClusterOpts: &configtypes.ClusterServer{Endpoint: "https://dev.example.com:6443", Path: kubeconfigPath, Context: "dev"}},
+		{Name: "other", ClusterOpts: &configtypes.ClusterServer{Endpoint: "https://other.example.com:6443", Path: kubeconfigPath, Context: "other"}},
+		{Name: "deleted", ClusterOpts: &configtypes.ClusterServer{Endpoint: "https://deleted.example.com:6443", Path: kubeconfigPath, Context: "deleted"}},
+		{Name: "gone", ClusterOpts: &configtypes.ClusterServer{Path: filepath.Join(t.TempDir(), "missing"), Context: "gone"}},
+		{Name: "tmc", ContextType: configtypes.ContextTypeTMC, GlobalOpts: &configtypes.GlobalServer{Endpoint: "tmc.example.com"}},
+	} {
+		if ctx.ContextType == "" {
+			ctx.ContextType = configtypes.ContextTypeK8s
+		}
+		require.NoError(t, SetContext(ctx, false))
+	}
+	edited := strings.NewReplacer(
+		"name: dev\n", "name: dev-renamed\n",
+		"https://other.example.com:6443", "https://other.example.com:7443",
+	).Replace(testBundleKubeconfig)
+	require.NoError(t, os.WriteFile(kubeconfigPath, []byte(edited), 0600))
+	return kubeconfigPath
+}
+
+func TestScanKubeconfigContexts(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+	kubeconfigPath := setupTestReconcileContexts(t)
+
+	drifts, err := ScanKubeconfigContexts()
+	require.NoError(t, err)
+	byContext := make(map[string]KubeconfigDrift)
+	for _, drift := range drifts {
+		byContext[drift.Context] = drift
+	}
+	require.Len(t, byContext, 4)
+	assert.Equal(t, KubeconfigDrift{
+		Context:     "dev",
+		Kind:        KubeContextRenamed,
+		Path:        kubeconfigPath,
+		KubeContext: "dev",
+		Endpoint:    "https://dev.example.com:6443",
+		Server:      "https://dev.example.com:6443",
+		RenamedTo:   "dev-renamed",
+	}, byContext["dev"])
+	assert.Equal(t, KubeconfigServerChanged, byContext["other"].Kind)
+	assert.Equal(t, "https://other.example.com:7443", byContext["other"].Server)
+	assert.Equal(t, KubeContextMissing, byContext["deleted"].Kind)
+	assert.Empty(t, byContext["deleted"].RenamedTo)
+	assert.Equal(t, KubeconfigMissing, byContext["gone"].Kind)
+}
+
+func TestReconcileKubeconfigContext(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+	setupTestReconcileContexts(t)
+	drifts, err := ScanKubeconfigContexts()
+	require.NoError(t, err)
+	byContext := make(map[string]KubeconfigDrift)
+	for _, drift := range drifts {
+		byContext[drift.Context] = drift
+	}
+
+	// Mark stale, then update the renamed context which clears the marker
+	require.NoError(t, ReconcileKubeconfigContext(byContext["dev"], KubeconfigReconcileMarkStale))
+	ctx, err := GetContext("dev")
+	require.NoError(t, err)
+	assert.Equal(t, string(KubeContextRenamed), ctx.AdditionalMetadata[KubeconfigStaleKey])
+	require.NoError(t, ReconcileKubeconfigContext(byContext["dev"], KubeconfigReconcileUpdate))
+	ctx, err = GetContext("dev")
+	require.NoError(t, err)
+	assert.Equal(t, "dev-renamed", ctx.ClusterOpts.Context)
+	assert.NotContains(t, ctx.AdditionalMetadata, KubeconfigStaleKey)
+
+	require.NoError(t, ReconcileKubeconfigContext(byContext["other"], KubeconfigReconcileUpdate))
+	ctx, err = GetContext("other")
+	require.NoError(t, err)
+	assert.Equal(t, "https://other.example.com:7443", ctx.ClusterOpts.Endpoint)
+	assert.Equal(t, "other", ctx.ClusterOpts.Context)
+
+	// A drift that has changed since the scan is not acted on
+	assert.EqualError(t, ReconcileKubeconfigContext(byContext["other"], KubeconfigReconcileRemove),
+		`the server-changed drift of context "other" has changed since the scan`)
+	_, err = GetContext("other")
+	require.NoError(t, err)
+
+	assert.EqualError(t, ReconcileKubeconfigContext(byContext["deleted"], KubeconfigReconcileUpdate),
+		`cannot update context "deleted": the kube-context-missing drift has no replacement kube context or server`)
+	require.NoError(t, ReconcileKubeconfigContext(byContext["deleted"], KubeconfigReconcileMarkStale))
+	ctx, err = GetContext("deleted")
+	require.NoError(t, err)
+	assert.Equal(t, string(KubeContextMissing), ctx.AdditionalMetadata[KubeconfigStaleKey])
+
+	require.NoError(t, ReconcileKubeconfigContext(byContext["gone"], KubeconfigReconcileRemove))
+	_, err = GetContext("gone")
+	assert.Error(t, err)
+
+	drifts, err = ScanKubeconfigContexts()
+	require.NoError(t, err)
+	require.Len(t, drifts, 1)
+	assert.Equal(t, "deleted", drifts[0].Context)
+}
+
+func TestAddContextsFromKubeconfig(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+	kubeconfigPath := filepath.Join(t.TempDir(), "kubeconfig")
+	require.NoError(t, os.WriteFile(kubeconfigPath, []byte(testBundleKubeconfig), 0600))
+
+	contexts, err := AddContextsFromKubeconfig(kubeconfigPath, WithKubeContexts("dev"))
+	require.NoError(t, err)
+	require.Len(t, contexts, 1)
+	ctx, err := GetContext("dev")
+	require.NoError(t, err)
+	assert.Equal(t, configtypes.ContextTypeK8s, ctx.ContextType)
+	assert.Equal(t, &configtypes.ClusterServer{Endpoint: "https://dev.example.com:6443", Path: kubeconfigPath, Context: "dev"}, ctx.ClusterOpts)
+
+	// No context is added if any name collides
+	_, err = AddContextsFromKubeconfig(kubeconfigPath)
+	assert.EqualError(t, err, `context "dev" already exists`)
+	_, err = GetContext("other")
+	assert.Error(t, err)
+
+	contexts, err = AddContextsFromKubeconfig(kubeconfigPath, WithKubeconfigCollisionPolicy(ImportCollisionRename))
+	require.NoError(t, err)
+	require.Len(t, contexts, 2)
+	assert.Equal(t, "dev-2", contexts[0].Name)
+	assert.Equal(t, "dev", contexts[0].ClusterOpts.Context)
+	assert.Equal(t, "other", contexts[1].Name)
+
+	_, err = AddContextsFromKubeconfig(kubeconfigPath, WithKubeContexts("missing"))
+	assert.EqualError(t, err, `kubeconfig "`+kubeconfigPath+`" does not contain the kube context "missing"`)
+	_, err = AddContextsFromKubeconfig(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+
+	// No context is added or replaced if any is rejected
+	insecurePath := filepath.Join(t.TempDir(), "kubeconfig")
+	insecure := strings.Replace(testBundleKubeconfig, "https://other.example.com:6443", "http://other.example.com:6443", 1)
+	require.NoError(t, os.WriteFile(insecurePath, []byte(insecure), 0600))
+	setupTestPolicy(t, testPolicy)
+	_, err = AddContextsFromKubeconfig(insecurePath, WithKubeconfigCollisionPolicy(ImportCollisionOverwrite))
+	var violation *PolicyViolation
+	require.ErrorAs(t, err, &violation)
+	ctx, err = GetContext("dev")
+	require.NoError(t, err)
+	assert.Equal(t, kubeconfigPath, ctx.ClusterOpts.Path)
+	ctx, err = GetContext("other")
+	require.NoError(t, err)
+	assert.Equal(t, kubeconfigPath, ctx.ClusterOpts.Path)
+}
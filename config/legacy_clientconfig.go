@@ -33,6 +33,10 @@ func CopyLegacyConfigDir() error {
 		return err
 	}
 	if legacyPathExists && !newPathExists {
+		// The config is read from the legacy dir in memory only, see readLegacyClientConfigNode
+		if IsReadOnly() {
+			return nil
+		}
 		if err := copyDir(legacyPath, newPath); err != nil {
 			return nil
 		}
@@ -42,6 +46,21 @@ func CopyLegacyConfigDir() error {
 	return nil
 }
 
+// readLegacyClientConfigNode reads the config from the legacy dir, as CopyLegacyConfigDir does not copy it
+// to the new location in read-only mode. Returns nil if the config path is overridden or there is no legacy config.
+//
+// Deprecated: This method is deprecated
+func readLegacyClientConfigNode() (*yaml.Node, error) {
+	if _, ok := os.LookupEnv(EnvConfigKey); ok {
+		return nil, nil
+	}
+	path, err := legacyConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	return readConfigFileNode(path)
+}
+
 // storeConfigToLegacyDir stores configuration to legacy dir and logs warning in case of errors.
 //
 // Deprecated: This method is deprecated
@@ -73,13 +92,17 @@ func storeConfigToLegacyDir(data []byte) {
 	if err != nil {
 		return
 	}
-	err = os.WriteFile(legacyCfgPath, data, 0644)
+	tightenFilePermissions(legacyCfgPath)
+	err = os.WriteFile(legacyCfgPath, data, configFileMode)
 }
 
 // persistLegacyClientConfig write to config.yaml
 //
 // Deprecated: This method is deprecated
 func persistLegacyClientConfig(node *yaml.Node) error {
+	if err := checkReadOnly("the legacy config"); err != nil {
+		return err
+	}
 	data, err := yaml.Marshal(node)
 	if err != nil {
 		return errors.Wrap(err, "failed to marshal nodeutils")
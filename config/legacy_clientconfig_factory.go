@@ -15,10 +15,14 @@ import (
 // StoreClientConfig stores the config in the local directory.
 // Make sure to Acquire and Release tanzu lock when reading/writing to the
 // tanzu client configuration
+// The feature flags and env variables merged from the system config are not stored in the user config,
+// and modifications of the values locked by the system config are refused.
 // Deprecated: StoreClientConfig is deprecated. Avoid using this method for Delete operations. Use New Config API methods.
 func StoreClientConfig(cfg *configtypes.ClientConfig) error {
 	// new plugins would be setting only contexts, so populate servers for backwards compatibility
-	populateServers(cfg)
+	if !legacyServersMigrated() {
+		populateServers(cfg)
+	}
 	// old plugins would be setting only servers, so populate contexts for forwards compatibility
 	PopulateContexts(cfg)
 
@@ -26,6 +30,15 @@ func StoreClientConfig(cfg *configtypes.ClientConfig) error {
 	if err != nil {
 		return err
 	}
+	// The config may have been read with GetClientConfig, which merges the system config beneath the user config
+	userCfg, err := convertNodeToClientConfig(node)
+	if err != nil {
+		return err
+	}
+	cfg, err = stripSystemConfigValues(cfg, userCfg)
+	if err != nil {
+		return err
+	}
 
 	err = setServers(node, cfg.KnownServers)
 	if err != nil {
@@ -152,6 +165,9 @@ func DeleteClientConfig() error {
 	if err != nil {
 		return err
 	}
+	if err := checkReadOnly(cfgPath); err != nil {
+		return err
+	}
 	err = os.Remove(cfgPath)
 	if err != nil {
 		return errors.Wrap(err, "could not remove config")
@@ -165,6 +181,9 @@ func DeleteClientConfigNextGen() error {
 	if err != nil {
 		return err
 	}
+	if err := checkReadOnly(cfgPath); err != nil {
+		return err
+	}
 	err = os.Remove(cfgPath)
 	if err != nil {
 		return errors.Wrap(err, "could not remove config-ng")
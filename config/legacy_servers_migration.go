@@ -0,0 +1,263 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+// LegacyServersMigrationReport is the outcome of MigrateLegacyServers
+type LegacyServersMigrationReport struct {
+	// AlreadyMigrated is true if the migration had been completed before
+	AlreadyMigrated bool `json:"alreadyMigrated" yaml:"alreadyMigrated"`
+	// ContextsCreated are the contexts created for the servers without a context
+	ContextsCreated []string `json:"contextsCreated,omitempty" yaml:"contextsCreated,omitempty"`
+	// DiscoverySourcesMoved are the discovery sources only present in a server added to its context, keyed on the context
+	DiscoverySourcesMoved map[string][]string `json:"discoverySourcesMoved,omitempty" yaml:"discoverySourcesMoved,omitempty"`
+	// Mismatches describe the servers that differ from their context. The migration is not completed if there is any.
+	Mismatches []string `json:"mismatches,omitempty" yaml:"mismatches,omitempty"`
+}
+
+// LegacyServerCompatibilityIssue is a difference between the contexts and the legacy servers that plugins
+// built with an older runtime, which read servers, would observe
+type LegacyServerCompatibilityIssue struct {
+	// Name of the context or server
+	Name string `json:"name" yaml:"name"`
+	// Problem describes the difference
+	Problem string `json:"problem" yaml:"problem"`
+}
+
+// MigrateLegacyServersOptions are the options of MigrateLegacyServers
+type MigrateLegacyServersOptions struct {
+	// DryRun reports the migration without writing the config
+	DryRun bool
+}
+
+type MigrateLegacyServersOpts func(o *MigrateLegacyServersOptions)
+
+// WithMigrationDryRun reports the migration without writing the config
+func WithMigrationDryRun() MigrateLegacyServersOpts {
+	return func(o *MigrateLegacyServersOptions) {
+		o.DryRun = true
+	}
+}
+
+// MigrateLegacyServers completes the migration off the deprecated legacy servers. It creates a context for
+// every server without one, adds the discovery sources only present in a server to its context and sets
+// SettingLegacyServersMigrated in the same locked update, after which contexts are no longer mirrored as
+// servers nor is the config copied to the legacy config dir. The existing servers are kept for plugins that still read them.
+// The migration fails without any change if a server differs from its context. It is idempotent.
+func MigrateLegacyServers(opts ...MigrateLegacyServersOpts) (*LegacyServersMigrationReport, error) {
+	options := &MigrateLegacyServersOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	AcquireTanzuConfigLock()
+	defer ReleaseTanzuConfigLock()
+	node, err := getClientConfigNodeNoLock()
+	if err != nil {
+		return nil, err
+	}
+	report := &LegacyServersMigrationReport{AlreadyMigrated: legacyServersMigrated()}
+	cfg, err := convertNodeToClientConfig(node)
+	if err != nil {
+		return nil, err
+	}
+
+	var persist bool
+	for _, s := range cfg.KnownServers {
+		if s.Type == configtypes.ServerType(configtypes.ContextTypeTanzu) {
+			continue
+		}
+		ctx, err := cfg.GetContext(s.Name)
+		if err != nil {
+			ctx = convertServerToContext(s)
+			if _, err := setContext(node, ctx); err != nil {
+				return nil, errors.Wrapf(err, "failed to create a context for server %q", s.Name)
+			}
+			if s.Name == cfg.CurrentServer {
+				if _, err := setCurrentContext(node, ctx.Name, ctx.ContextType); err != nil {
+					return nil, err
+				}
+			}
+			report.ContextsCreated = append(report.ContextsCreated, s.Name)
+			persist = true
+			continue
+		}
+		if mismatch := legacyServerMismatch(s, ctx); mismatch != "" {
+			report.Mismatches = append(report.Mismatches, fmt.Sprintf("server %q %s", s.Name, mismatch))
+			continue
+		}
+		moved := serverOnlyDiscoverySources(s, ctx)
+		if len(moved) == 0 {
+			continue
+		}
+		if report.DiscoverySourcesMoved == nil {
+			report.DiscoverySourcesMoved = make(map[string][]string)
+		}
+		for _, ds := range moved {
+			_, name, _ := getDiscoverySourceTypeAndName(ds)
+			report.DiscoverySourcesMoved[ctx.Name] = append(report.DiscoverySourcesMoved[ctx.Name], name)
+		}
+		ctx.DiscoverySources = append(ctx.DiscoverySources, moved...)
+		if _, err := setContext(node, ctx); err != nil {
+			return nil, errors.Wrapf(err, "failed to move the discovery sources of server %q", s.Name)
+		}
+		persist = true
+	}
+
+	if len(report.Mismatches) != 0 {
+		return report, errors.Errorf("cannot migrate legacy servers that differ from their context: %s", strings.Join(report.Mismatches, "; "))
+	}
+	if options.DryRun {
+		return report, nil
+	}
+	if !persist && report.AlreadyMigrated {
+		return report, nil
+	}
+	// The setting is written along with the contexts, so that a failure leaves both the config and the
+	// config metadata untouched
+	err = persistConfigAndMetadata(node, func(metadataNode *yaml.Node) error {
+		_, err := setSetting(metadataNode, SettingLegacyServersMigrated, "true")
+		return err
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to complete the legacy servers migration")
+	}
+	return report, nil
+}
+
+// RollbackLegacyServersMigration resumes mirroring contexts as servers and copying the config to the legacy
+// config dir. The contexts added since the migration are mirrored as servers right away.
+func RollbackLegacyServersMigration() error {
+	AcquireTanzuConfigLock()
+	defer ReleaseTanzuConfigLock()
+	node, err := getClientConfigNodeNoLock()
+	if err != nil {
+		return err
+	}
+	cfg, err := convertNodeToClientConfig(node)
+	if err != nil {
+		return err
+	}
+	populateServers(cfg)
+	if err := setServers(node, cfg.KnownServers); err != nil {
+		return err
+	}
+	if cfg.CurrentServer != "" {
+		if _, err := setCurrentServer(node, cfg.CurrentServer); err != nil {
+			return err
+		}
+	}
+	// The setting is deleted along with the servers mirrored from the contexts
+	err = persistConfigAndMetadata(node, func(metadataNode *yaml.Node) error {
+		return deleteSetting(metadataNode, SettingLegacyServersMigrated)
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to roll back the legacy servers migration")
+	}
+	return nil
+}
+
+// CheckLegacyServersCompatibility reports the contexts that plugins reading the legacy servers would not see
+// or would see out of date, as contexts are no longer mirrored as servers after MigrateLegacyServers
+func CheckLegacyServersCompatibility() ([]LegacyServerCompatibilityIssue, error) {
+	cfg, err := GetClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	servers := make(map[string]*configtypes.Server, len(cfg.KnownServers))
+	for _, s := range cfg.KnownServers {
+		servers[s.Name] = s
+	}
+	issues := make([]LegacyServerCompatibilityIssue, 0)
+	for _, ctx := range cfg.KnownContexts {
+		if ctx.ContextType == configtypes.ContextTypeTanzu {
+			continue
+		}
+		s, ok := servers[ctx.Name]
+		if !ok {
+			issues = append(issues, LegacyServerCompatibilityIssue{Name: ctx.Name, Problem: "context has no server"})
+			continue
+		}
+		if mismatch := legacyServerMismatch(s, ctx); mismatch != "" {
+			issues = append(issues, LegacyServerCompatibilityIssue{Name: ctx.Name, Problem: "server " + mismatch})
+		}
+	}
+	for _, s := range cfg.KnownServers {
+		if s.Type != configtypes.ServerType(configtypes.ContextTypeTanzu) && !cfg.HasContext(s.Name) {
+			issues = append(issues, LegacyServerCompatibilityIssue{Name: s.Name, Problem: "server has no context"})
+		}
+	}
+	if cfg.CurrentServer != "" && cfg.CurrentContext[configtypes.ContextTypeK8s] != "" && cfg.CurrentServer != cfg.CurrentContext[configtypes.ContextTypeK8s] {
+		issues = append(issues, LegacyServerCompatibilityIssue{
+			Name:    cfg.CurrentServer,
+			Problem: fmt.Sprintf("current server differs from the current kubernetes context %q", cfg.CurrentContext[configtypes.ContextTypeK8s]),
+		})
+	}
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Name < issues[j].Name })
+	return issues, nil
+}
+
+// legacyServersMigrated returns true once MigrateLegacyServers has completed and until it is rolled back
+func legacyServersMigrated() bool {
+	migrated, err := IsConfigMetadataSettingsEnabled(SettingLegacyServersMigrated)
+	return err == nil && migrated
+}
+
+// legacyServerMismatch describes how the server differs from the server the context would be mirrored as,
+// or returns an empty string if they are equivalent
+func legacyServerMismatch(s *configtypes.Server, ctx *configtypes.Context) string {
+	expected := convertContextToServer(ctx)
+	if s.Type != "" && expected.Type != "" && s.Type != expected.Type {
+		return fmt.Sprintf("has type %q instead of %q", s.Type, expected.Type)
+	}
+	var serverEndpoint, contextEndpoint string
+	if s.GlobalOpts != nil {
+		serverEndpoint = s.GlobalOpts.Endpoint
+	}
+	if expected.GlobalOpts != nil {
+		contextEndpoint = expected.GlobalOpts.Endpoint
+	}
+	if serverEndpoint != contextEndpoint {
+		return fmt.Sprintf("has global endpoint %q instead of %q", serverEndpoint, contextEndpoint)
+	}
+	serverOpts, contextOpts := configtypes.ManagementClusterServer{}, configtypes.ManagementClusterServer{}
+	if s.ManagementClusterOpts != nil {
+		serverOpts = *s.ManagementClusterOpts
+	}
+	if expected.ManagementClusterOpts != nil {
+		contextOpts = *expected.ManagementClusterOpts
+	}
+	if serverOpts != contextOpts {
+		return fmt.Sprintf("has management cluster %s/%s at %q instead of %s/%s at %q",
+			serverOpts.Path, serverOpts.Context, serverOpts.Endpoint, contextOpts.Path, contextOpts.Context, contextOpts.Endpoint)
+	}
+	return ""
+}
+
+// serverOnlyDiscoverySources returns the discovery sources of the server that are not in its context
+func serverOnlyDiscoverySources(s *configtypes.Server, ctx *configtypes.Context) []configtypes.PluginDiscovery {
+	known := make(map[string]bool)
+	for _, ds := range ctx.DiscoverySources {
+		if dsType, name, err := getDiscoverySourceTypeAndName(ds); err == nil {
+			known[dsType+"/"+name] = true
+		}
+	}
+	var missing []configtypes.PluginDiscovery
+	for _, ds := range s.DiscoverySources {
+		if dsType, name, err := getDiscoverySourceTypeAndName(ds); err == nil && !known[dsType+"/"+name] {
+			missing = append(missing, ds)
+		}
+	}
+	return missing
+}
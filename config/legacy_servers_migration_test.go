@@ -0,0 +1,189 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+func TestMigrateLegacyServers(t *testing.T) {
+	cfg := &CfgTestData{
+		cfg: `clientOptions:
+  cli:
+    discoverySources:
+      - oci:
+          name: default
+          image: "/:"
+servers:
+  - name: legacy-mgmt
+    type: managementcluster
+    managementClusterOpts:
+      endpoint: https://legacy:6443
+      path: legacy-path
+      context: legacy-context
+  - name: mirrored
+    type: managementcluster
+    managementClusterOpts:
+      endpoint: https://mirrored:6443
+      path: mirrored-path
+      context: mirrored-context
+    discoverySources:
+      - oci:
+          name: server-only
+          image: example.com/server-only:latest
+current: legacy-mgmt
+`,
+		cfgNextGen: `contexts:
+  - name: mirrored
+    target: kubernetes
+    clusterOpts:
+      endpoint: https://mirrored:6443
+      path: mirrored-path
+      context: mirrored-context
+      isManagementCluster: true
+`,
+	}
+	_, cleanUp := setupTestConfig(t, cfg)
+	defer cleanUp()
+
+	// A dry run reports the migration without completing it
+	report, err := MigrateLegacyServers(WithMigrationDryRun())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"legacy-mgmt"}, report.ContextsCreated)
+	assert.Equal(t, map[string][]string{"mirrored": {"server-only"}}, report.DiscoverySourcesMoved)
+	assert.False(t, legacyServersMigrated())
+	_, err = GetContext("legacy-mgmt")
+	assert.Error(t, err)
+
+	report, err = MigrateLegacyServers()
+	require.NoError(t, err)
+	assert.False(t, report.AlreadyMigrated)
+	assert.Equal(t, []string{"legacy-mgmt"}, report.ContextsCreated)
+	assert.True(t, legacyServersMigrated())
+
+	ctx, err := GetContext("legacy-mgmt")
+	require.NoError(t, err)
+	assert.Equal(t, &configtypes.ClusterServer{Endpoint: "https://legacy:6443", Path: "legacy-path", Context: "legacy-context", IsManagementCluster: true}, ctx.ClusterOpts)
+	current, err := GetActiveContext(configtypes.ContextTypeK8s)
+	require.NoError(t, err)
+	assert.Equal(t, "legacy-mgmt", current.Name)
+	ctx, err = GetContext("mirrored")
+	require.NoError(t, err)
+	require.Len(t, ctx.DiscoverySources, 1)
+	assert.Equal(t, "server-only", ctx.DiscoverySources[0].OCI.Name)
+
+	// The migration is idempotent
+	report, err = MigrateLegacyServers()
+	require.NoError(t, err)
+	assert.True(t, report.AlreadyMigrated)
+	assert.Empty(t, report.ContextsCreated)
+	assert.Empty(t, report.DiscoverySourcesMoved)
+
+	// Contexts are no longer mirrored as servers, which the compatibility report shows
+	require.NoError(t, SetContext(&configtypes.Context{
+		Name:        "new-mgmt",
+		ContextType: configtypes.ContextTypeK8s,
+		ClusterOpts: &configtypes.ClusterServer{Endpoint: "https://new:6443", Path: "new-path", Context: "new-context", IsManagementCluster: true},
+	}, false))
+	_, err = GetServer("new-mgmt") //nolint:staticcheck
+	assert.Error(t, err)
+	issues, err := CheckLegacyServersCompatibility()
+	require.NoError(t, err)
+	assert.Equal(t, []LegacyServerCompatibilityIssue{{Name: "new-mgmt", Problem: "context has no server"}}, issues)
+
+	// The rollback mirrors the contexts added since the migration
+	require.NoError(t, RollbackLegacyServersMigration())
+	assert.False(t, legacyServersMigrated())
+	server, err := GetServer("new-mgmt") //nolint:staticcheck
+	require.NoError(t, err)
+	assert.Equal(t, "new-path", server.ManagementClusterOpts.Path)
+	issues, err = CheckLegacyServersCompatibility()
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+}
+
+func TestMigrateLegacyServersMismatch(t *testing.T) {
+	cfg := &CfgTestData{
+		cfg: `servers:
+  - name: mgmt
+    type: managementcluster
+    managementClusterOpts:
+      endpoint: https://old:6443
+      path: path
+      context: context
+`,
+		cfgNextGen: `contexts:
+  - name: mgmt
+    target: kubernetes
+    clusterOpts:
+      endpoint: https://new:6443
+      path: path
+      context: context
+      isManagementCluster: true
+`,
+	}
+	_, cleanUp := setupTestConfig(t, cfg)
+	defer cleanUp()
+
+	report, err := MigrateLegacyServers()
+	require.Error(t, err)
+	assert.Equal(t, []string{`server "mgmt" has management cluster path/context at "https://old:6443" instead of path/context at "https://new:6443"`}, report.Mismatches)
+	assert.False(t, legacyServersMigrated())
+
+	issues, err := CheckLegacyServersCompatibility()
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, "mgmt", issues[0].Name)
+}
+
+func TestMigrateLegacyServersRejectedLeavesConfigUntouched(t *testing.T) {
+	cfg := &CfgTestData{
+		cfg: `servers:
+  - name: insecure-mgmt
+    type: managementcluster
+    managementClusterOpts:
+      endpoint: http://insecure:6443
+      path: path
+      context: context
+`,
+	}
+	_, cleanUp := setupTestConfig(t, cfg)
+	defer cleanUp()
+	setupTestPolicy(t, testPolicy)
+
+	// The setting is written in the same update as the contexts, so neither is written if the update is rejected
+	_, err := MigrateLegacyServers()
+	var violation *PolicyViolation
+	require.ErrorAs(t, err, &violation)
+	assert.False(t, legacyServersMigrated())
+	_, err = GetContext("insecure-mgmt")
+	assert.Error(t, err)
+
+	setupTestPolicy(t, "rules: []\n")
+	revision, err := GetConfigRevision()
+	require.NoError(t, err)
+	_, err = MigrateLegacyServers()
+	require.NoError(t, err)
+	value, err := GetConfigMetadataSetting(SettingLegacyServersMigrated)
+	require.NoError(t, err)
+	assert.Equal(t, "true", value)
+	_, err = GetContext("insecure-mgmt")
+	require.NoError(t, err)
+	migratedRevision, err := GetConfigRevision()
+	require.NoError(t, err)
+	assert.Equal(t, revision+1, migratedRevision)
+
+	// The rollback deletes the setting in the same update as the servers
+	require.NoError(t, RollbackLegacyServersMigration())
+	_, err = GetConfigMetadataSetting(SettingLegacyServersMigrated)
+	assert.Error(t, err)
+	revision, err = GetConfigRevision()
+	require.NoError(t, err)
+	assert.Equal(t, migratedRevision+1, revision)
+}
@@ -7,8 +7,6 @@
 package config
 
 import (
-	"os"
-
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v3"
 
@@ -23,30 +21,30 @@ func getMetadataNode() (*yaml.Node, error) {
 	return getMetadataNodeNoLock()
 }
 
-// getMetadataNodeNoLock retrieves the config from the local directory without acquiring the lock
+// getMetadataNodeNoLock retrieves the config from the local directory without acquiring the lock.
+// With an ephemeral config, the config metadata written in memory takes precedence.
 func getMetadataNodeNoLock() (*yaml.Node, error) {
+	if IsEphemeralConfig() {
+		if node := getEphemeralMetadataNode(); node != nil {
+			return node, nil
+		}
+	}
 	cfgPath, err := CfgMetadataFilePath()
 	if err != nil {
 		return nil, errors.Wrap(err, "failed getting config metadata path")
 	}
 
-	bytes, err := os.ReadFile(cfgPath)
-	if err != nil || len(bytes) == 0 {
-		node, err := newMetadataNode()
+	node, err := readConfigFileNode(cfgPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to construct struct from config metadata data")
+	}
+	if node == nil {
+		node, err = newMetadataNode()
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to create new config metadata")
 		}
-		return node, nil
 	}
-	var node yaml.Node
-
-	err = yaml.Unmarshal(bytes, &node)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to construct struct from config metadata data")
-	}
-	node.Content[0].Style = 0
-
-	return &node, nil
+	return node, nil
 }
 
 func newMetadataNode() (*yaml.Node, error) {
@@ -59,10 +57,37 @@ func newMetadataNode() (*yaml.Node, error) {
 	return node, nil
 }
 
+// updateConfigMetadata applies the update to the config metadata and writes it under the metadata lock
+func updateConfigMetadata(update func(node *yaml.Node) error) error {
+	AcquireTanzuMetadataLock()
+	defer ReleaseTanzuMetadataLock()
+	node, err := getMetadataNodeNoLock()
+	if err != nil {
+		return err
+	}
+	if err := update(node); err != nil {
+		return err
+	}
+	return persistConfigMetadata(node)
+}
+
 func persistConfigMetadata(node *yaml.Node) error {
+	// The config metadata is not written to disk along with an ephemeral config
+	if IsEphemeralConfig() {
+		return persistEphemeralMetadata(node)
+	}
 	path, err := CfgMetadataFilePath()
 	if err != nil {
 		return errors.Wrap(err, "could not find config metadata path")
 	}
-	return persistNode(node, WithCfgPath(path))
+	// Capture the current config metadata to record the mutation in the audit trail
+	oldNode, err := getMetadataNodeNoLock()
+	if err != nil {
+		oldNode = nil
+	}
+	if err := persistNode(node, WithCfgPath(path)); err != nil {
+		return err
+	}
+	auditMetadata(oldNode, node)
+	return nil
 }
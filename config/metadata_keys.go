@@ -8,4 +8,5 @@ const (
 	KeyConfigMetadata = "configMetadata"
 	KeyPatchStrategy  = "patchStrategy"
 	KeySettings       = "settings"
+	KeyRevision       = "revision"
 )
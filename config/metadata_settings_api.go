@@ -14,6 +14,10 @@ import (
 
 const (
 	SettingUseUnifiedConfig = "useUnifiedConfig"
+	// SettingLegacyServersMigrated stops mirroring contexts as legacy servers once set by MigrateLegacyServers
+	SettingLegacyServersMigrated = "legacyServersMigrated"
+	// SettingExpandGlobalEnvs expands the variable references in the global env entries, which are literal otherwise
+	SettingExpandGlobalEnvs = "expandGlobalEnvs"
 )
 
 // GetConfigMetadataSettings retrieves feature flags
@@ -0,0 +1,113 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+)
+
+const (
+	// configFileMode is the mode of the config files, which may hold access and refresh tokens
+	configFileMode os.FileMode = 0600
+	// configDirMode is the mode of the directories holding the config files
+	configDirMode os.FileMode = 0700
+)
+
+// PermissionProblem is an ownership or mode problem of a file or directory under LocalDir
+type PermissionProblem struct {
+	// Path of the file or directory
+	Path string `json:"path" yaml:"path"`
+	// Mode of the file or directory
+	Mode os.FileMode `json:"mode" yaml:"mode"`
+	// Problem describes the ownership or mode problem
+	Problem string `json:"problem" yaml:"problem"`
+}
+
+// CheckPermissions reports the ownership and mode problems of every file and directory under LocalDir.
+// Files must not be accessible by the group or others i.e. 0600, directories 0700, and all must be owned
+// by the current user. Modes are not checked on Windows.
+func CheckPermissions() ([]PermissionProblem, error) {
+	problems := make([]PermissionProblem, 0)
+	if runtime.GOOS == "windows" {
+		return problems, nil
+	}
+	localDir, err := LocalDir()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not find local tanzu dir for OS")
+	}
+	err = filepath.WalkDir(localDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == localDir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		// Symlinks are not followed
+		if d.Type()&os.ModeSymlink != 0 {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		expected := configFileMode
+		if info.IsDir() {
+			expected = configDirMode
+		}
+		if info.Mode().Perm()&^expected != 0 {
+			problems = append(problems, PermissionProblem{
+				Path:    path,
+				Mode:    info.Mode().Perm(),
+				Problem: fmt.Sprintf("mode %#o is more permissive than %#o", info.Mode().Perm(), expected),
+			})
+		}
+		if uid, ok := fileOwnerUID(info); ok && uid != os.Getuid() {
+			problems = append(problems, PermissionProblem{
+				Path:    path,
+				Mode:    info.Mode().Perm(),
+				Problem: fmt.Sprintf("owned by uid %d instead of the current user uid %d", uid, os.Getuid()),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to check the permissions of %s", localDir)
+	}
+	return problems, nil
+}
+
+// tightenFilePermissions restricts the mode of an existing config file to 0600 if it is more permissive,
+// logging a warning. Modes are not tightened on Windows.
+func tightenFilePermissions(path string) {
+	tightenPermissions(path, configFileMode)
+}
+
+// tightenDirPermissions restricts the mode of an existing config directory to 0700 if it is more permissive,
+// logging a warning. Modes are not tightened on Windows.
+func tightenDirPermissions(path string) {
+	tightenPermissions(path, configDirMode)
+}
+
+func tightenPermissions(path string, mode os.FileMode) {
+	if runtime.GOOS == "windows" {
+		return
+	}
+	info, err := os.Stat(path)
+	if err != nil || info.Mode().Perm()&^mode == 0 {
+		return
+	}
+	if err := os.Chmod(path, mode); err != nil {
+		log.Warningf("unable to restrict the permissions of %s from %#o to %#o: %v", path, info.Mode().Perm(), mode, err)
+		return
+	}
+	log.Warningf("restricted the permissions of %s from %#o to %#o", path, info.Mode().Perm(), mode)
+}
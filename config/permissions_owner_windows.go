@@ -0,0 +1,13 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"os"
+)
+
+// fileOwnerUID returns the uid of the owner of the file, not available on Windows
+func fileOwnerUID(_ os.FileInfo) (int, bool) {
+	return 0, false
+}
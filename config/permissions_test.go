@@ -0,0 +1,105 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupTestLocalDir points LocalDir to a temporary home directory and the config files within it
+func setupTestLocalDir(t *testing.T) string {
+	if runtime.GOOS == "windows" {
+		t.Skip("file modes are not supported on Windows")
+	}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	localDir, err := LocalDir()
+	require.NoError(t, err)
+	t.Setenv(EnvConfigKey, filepath.Join(localDir, ConfigName))
+	t.Setenv(EnvConfigNextGenKey, filepath.Join(localDir, CfgNextGenName))
+	t.Setenv(EnvConfigMetadataKey, filepath.Join(localDir, CfgMetadataName))
+	return localDir
+}
+
+func TestConfigFilesCreatedPrivate(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+	localDir := setupTestLocalDir(t)
+
+	require.NoError(t, SetEnv("FOO", "bar"))
+	info, err := os.Stat(localDir)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+	for _, name := range []string{ConfigName, CfgNextGenName, CfgMetadataName} {
+		info, err := os.Stat(filepath.Join(localDir, name))
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm(), name)
+	}
+
+	problems, err := CheckPermissions()
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+}
+
+func TestConfigFilesTightenedOnWrite(t *testing.T) {
+	files, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+	if runtime.GOOS == "windows" {
+		t.Skip("file modes are not supported on Windows")
+	}
+	for _, f := range files {
+		require.NoError(t, os.Chmod(f.Name(), 0644))
+	}
+
+	require.NoError(t, SetEnv("FOO", "bar"))
+	for _, key := range []string{EnvConfigKey, EnvConfigNextGenKey} {
+		info, err := os.Stat(os.Getenv(key))
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm(), key)
+	}
+}
+
+func TestConfigDirTightenedOnWrite(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+	localDir := setupTestLocalDir(t)
+	require.NoError(t, os.MkdirAll(localDir, 0755))
+	require.NoError(t, os.Chmod(localDir, 0755))
+
+	require.NoError(t, SetEnv("FOO", "bar"))
+	info, err := os.Stat(localDir)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+}
+
+func TestCheckPermissions(t *testing.T) {
+	localDir := setupTestLocalDir(t)
+
+	problems, err := CheckPermissions()
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	pluginDir := filepath.Join(localDir, PluginsBaseDir, "my-plugin")
+	require.NoError(t, os.MkdirAll(pluginDir, 0700))
+	require.NoError(t, os.Chmod(localDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(localDir, ConfigName), []byte("{}"), 0600))
+	require.NoError(t, os.Chmod(filepath.Join(localDir, ConfigName), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(pluginDir, "settings.yaml"), []byte("{}"), 0600))
+	require.NoError(t, os.Chmod(filepath.Join(pluginDir, "settings.yaml"), 0640))
+	require.NoError(t, os.Symlink(filepath.Join(localDir, ConfigName), filepath.Join(localDir, "link.yaml")))
+
+	problems, err = CheckPermissions()
+	require.NoError(t, err)
+	assert.Equal(t, []PermissionProblem{
+		{Path: localDir, Mode: 0755, Problem: "mode 0755 is more permissive than 0700"},
+		{Path: filepath.Join(localDir, ConfigName), Mode: 0644, Problem: "mode 0644 is more permissive than 0600"},
+		{Path: filepath.Join(pluginDir, "settings.yaml"), Mode: 0640, Problem: "mode 0640 is more permissive than 0600"},
+	}, problems)
+}
@@ -26,7 +26,7 @@ func GetTanzuPluginConfigDir() (string, error) {
 
 	// Create plugins directory in tanzu config
 	pluginsBaseDir := filepath.Join(tanzuDir, PluginsBaseDir)
-	if err := os.MkdirAll(pluginsBaseDir, 0755); err != nil {
+	if err := os.MkdirAll(pluginsBaseDir, configDirMode); err != nil {
 		return "", errors.Wrap(err, "could not make local tanzu plugins directory")
 	}
 
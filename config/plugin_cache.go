@@ -0,0 +1,348 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+const (
+	// PluginCacheFileName is the name of the file holding the entries of a plugin cache within its CacheDir
+	PluginCacheFileName = "plugin-cache.json"
+	// LocalPluginCacheFileLock is the name of the lock file of a plugin cache within its CacheDir
+	LocalPluginCacheFileLock = ".plugin-cache.lock"
+	// DefaultPluginCacheMaxEntries is the default maximum number of entries of a plugin cache
+	DefaultPluginCacheMaxEntries = 1000
+	// DefaultPluginCacheMaxBytes is the default maximum total size of the values of a plugin cache
+	DefaultPluginCacheMaxBytes = 10 * 1024 * 1024
+	// DefaultPluginCacheLockTimeout is the default time waiting on the lock of a plugin cache
+	DefaultPluginCacheLockTimeout = 30 * time.Second
+
+	// pluginCacheAccessResolution is the resolution of the last access time of the entries, a hit only writes
+	// the cache file if the last access of the entry is older, so that reads do not rewrite the file every time
+	pluginCacheAccessResolution = time.Minute
+)
+
+// PluginCacheOptions are the options of PluginCache
+type PluginCacheOptions struct {
+	// Context is the name of the context the entries are scoped to, the active contexts if empty
+	Context string
+	// MaxEntries is the maximum number of entries across all scopes
+	MaxEntries int
+	// MaxBytes is the maximum total size of the values across all scopes
+	MaxBytes int
+}
+
+type PluginCacheOpts func(o *PluginCacheOptions)
+
+// WithPluginCacheContext scopes the entries to the context instead of the active contexts
+func WithPluginCacheContext(name string) PluginCacheOpts {
+	return func(o *PluginCacheOptions) {
+		o.Context = name
+	}
+}
+
+// WithPluginCacheMaxEntries sets the maximum number of entries, the least recently used entries are evicted beyond it
+func WithPluginCacheMaxEntries(n int) PluginCacheOpts {
+	return func(o *PluginCacheOptions) {
+		o.MaxEntries = n
+	}
+}
+
+// WithPluginCacheMaxBytes sets the maximum total size of the values, the least recently used entries are evicted beyond it
+func WithPluginCacheMaxBytes(n int) PluginCacheOpts {
+	return func(o *PluginCacheOptions) {
+		o.MaxBytes = n
+	}
+}
+
+// PluginCacheStore is a TTL cache of a plugin stored in its CacheDir. Entries are scoped to the active contexts,
+// or the context specified with WithPluginCacheContext, at the time of each operation, so that data cached for a
+// context, and for a Tanzu context its organization, is not returned for another. Every operation holds a file
+// lock, so the store can be shared by concurrent processes.
+type PluginCacheStore struct {
+	plugin  string
+	dir     string
+	options PluginCacheOptions
+	now     func() time.Time
+}
+
+// pluginCacheEntry is an entry of the plugin cache file
+type pluginCacheEntry struct {
+	Scope      string    `json:"scope"`
+	Key        string    `json:"key"`
+	Value      []byte    `json:"value"`
+	Expiration time.Time `json:"expiration"`
+	LastAccess time.Time `json:"lastAccess"`
+}
+
+// pluginCacheFile is the content of the plugin cache file
+type pluginCacheFile struct {
+	Entries []*pluginCacheEntry `json:"entries"`
+}
+
+// PluginCache returns the cache of the plugin scoped to the active contexts, or the context specified
+// with WithPluginCacheContext. The cache is stored in CacheDir(plugin).
+// The least recently used entries are evicted first, with the last access of the entries tracked to the minute.
+func PluginCache(plugin string, opts ...PluginCacheOpts) (*PluginCacheStore, error) {
+	options := PluginCacheOptions{MaxEntries: DefaultPluginCacheMaxEntries, MaxBytes: DefaultPluginCacheMaxBytes}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.MaxEntries <= 0 || options.MaxBytes <= 0 {
+		return nil, errors.New("the maximum entries and bytes of the plugin cache must be positive")
+	}
+	dir, err := CacheDir(plugin)
+	if err != nil {
+		return nil, err
+	}
+	if options.Context != "" {
+		if _, err := GetContext(options.Context); err != nil {
+			return nil, err
+		}
+	}
+	return &PluginCacheStore{plugin: plugin, dir: dir, options: options, now: time.Now}, nil
+}
+
+// Get returns the value of the key and true, or false if the key is not cached or has expired
+func (c *PluginCacheStore) Get(key string) ([]byte, bool, error) {
+	var value []byte
+	var found bool
+	err := c.update(func(f *pluginCacheFile, scope string, now time.Time) bool {
+		entry := f.find(scope, key)
+		if entry == nil || !now.Before(entry.Expiration) {
+			return false
+		}
+		value, found = entry.Value, true
+		if now.Sub(entry.LastAccess) < pluginCacheAccessResolution {
+			return false
+		}
+		entry.LastAccess = now
+		return true
+	})
+	return value, found, err
+}
+
+// Set caches the value of the key for the ttl, evicting the least recently used entries if the limits are exceeded
+func (c *PluginCacheStore) Set(key string, value []byte, ttl time.Duration) error {
+	if key == "" {
+		return errors.New("the key of a plugin cache entry cannot be empty")
+	}
+	if ttl <= 0 {
+		return errors.Errorf("the ttl of plugin cache entry %q must be positive", key)
+	}
+	if len(value) > c.options.MaxBytes {
+		return errors.Errorf("the value of plugin cache entry %q exceeds the maximum size of %d bytes", key, c.options.MaxBytes)
+	}
+	return c.update(func(f *pluginCacheFile, scope string, now time.Time) bool {
+		entry := f.find(scope, key)
+		if entry == nil {
+			entry = &pluginCacheEntry{Scope: scope, Key: key}
+			f.Entries = append(f.Entries, entry)
+		}
+		entry.Value = value
+		entry.Expiration = now.Add(ttl)
+		entry.LastAccess = now
+		f.evict(now, c.options.MaxEntries, c.options.MaxBytes)
+		return true
+	})
+}
+
+// Invalidate removes the key from the cache
+func (c *PluginCacheStore) Invalidate(key string) error {
+	return c.update(func(f *pluginCacheFile, scope string, now time.Time) bool {
+		for i, entry := range f.Entries {
+			if entry.Scope == scope && entry.Key == key {
+				f.Entries = append(f.Entries[:i], f.Entries[i+1:]...)
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// PurgePluginCaches removes the caches of the plugins, or of all plugins if none is specified.
+// Only the cache files are removed, other files of the plugins in their CacheDir are kept.
+func PurgePluginCaches(plugins ...string) error {
+	if len(plugins) == 0 {
+		base, err := cacheBaseDir()
+		if err != nil {
+			return err
+		}
+		entries, err := os.ReadDir(base)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return errors.Wrap(err, "failed to read the cache dir")
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				plugins = append(plugins, entry.Name())
+			}
+		}
+	}
+	for _, plugin := range plugins {
+		dir, err := CacheDir(plugin)
+		if err != nil {
+			return err
+		}
+		if err := withPluginCacheLock(dir, func() error {
+			if err := os.Remove(filepath.Join(dir, PluginCacheFileName)); err != nil && !os.IsNotExist(err) {
+				return errors.Wrapf(err, "failed to purge the cache of plugin %q", plugin)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// update reads the cache file under the lock, applies the mutation to the entries of the current scope and
+// writes the file if it returns true. Expired entries are dropped whenever the file is written.
+func (c *PluginCacheStore) update(mutate func(f *pluginCacheFile, scope string, now time.Time) bool) error {
+	scope, err := pluginCacheScope(c.options.Context)
+	if err != nil {
+		return err
+	}
+	return withPluginCacheLock(c.dir, func() error {
+		path := filepath.Join(c.dir, PluginCacheFileName)
+		f := readPluginCacheFile(path)
+		now := c.now()
+		if !mutate(f, scope, now) {
+			return nil
+		}
+		f.removeExpired(now)
+		data, err := json.Marshal(f)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal the cache of plugin %q", c.plugin)
+		}
+		if err := writePluginCacheFile(path, data); err != nil {
+			return errors.Wrapf(err, "failed to write the cache of plugin %q", c.plugin)
+		}
+		return nil
+	})
+}
+
+// withPluginCacheLock runs the function while holding the lock of the plugin cache in the directory
+func withPluginCacheLock(dir string, f func() error) error {
+	lock, err := getFileLockWithTimeOut(filepath.Join(dir, LocalPluginCacheFileLock), DefaultPluginCacheLockTimeout)
+	if lock != nil {
+		defer func() { _ = lock.Close() }()
+	}
+	if err != nil {
+		return errors.Wrap(err, "cannot acquire lock for the plugin cache")
+	}
+	return f()
+}
+
+// writePluginCacheFile writes the cache file to a temporary file renamed over it, so that the cache file
+// is never left partially written
+func writePluginCacheFile(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := f.Name()
+	err = f.Chmod(configFileMode)
+	if err == nil {
+		_, err = f.Write(data)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, path)
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+	}
+	return err
+}
+
+// readPluginCacheFile reads the cache file. As it only holds cached data, a missing or corrupted file is an empty cache.
+func readPluginCacheFile(path string) *pluginCacheFile {
+	f := &pluginCacheFile{}
+	data, err := os.ReadFile(path)
+	if err != nil || json.Unmarshal(data, f) != nil {
+		return &pluginCacheFile{}
+	}
+	return f
+}
+
+func (f *pluginCacheFile) find(scope, key string) *pluginCacheEntry {
+	for _, entry := range f.Entries {
+		if entry.Scope == scope && entry.Key == key {
+			return entry
+		}
+	}
+	return nil
+}
+
+func (f *pluginCacheFile) removeExpired(now time.Time) {
+	entries := f.Entries[:0]
+	for _, entry := range f.Entries {
+		if now.Before(entry.Expiration) {
+			entries = append(entries, entry)
+		}
+	}
+	f.Entries = entries
+}
+
+// evict removes the expired entries, then the least recently used entries until the limits are met
+func (f *pluginCacheFile) evict(now time.Time, maxEntries, maxBytes int) {
+	f.removeExpired(now)
+	sort.SliceStable(f.Entries, func(i, j int) bool {
+		return f.Entries[i].LastAccess.After(f.Entries[j].LastAccess)
+	})
+	size := 0
+	for i, entry := range f.Entries {
+		size += len(entry.Value)
+		if i >= maxEntries || size > maxBytes {
+			f.Entries = f.Entries[:i]
+			return
+		}
+	}
+}
+
+// pluginCacheScope returns the scope of the cache entries: the context, or the active contexts if empty.
+// The organization of Tanzu contexts is part of the scope, as a context can be logged in to another organization.
+func pluginCacheScope(contextName string) (string, error) {
+	var contexts []*configtypes.Context
+	if contextName != "" {
+		ctx, err := GetContext(contextName)
+		if err != nil {
+			return "", err
+		}
+		contexts = append(contexts, ctx)
+	} else {
+		active, err := GetAllActiveContextsMap()
+		if err != nil {
+			return "", err
+		}
+		for _, ctx := range active {
+			contexts = append(contexts, ctx)
+		}
+	}
+	scopes := make([]string, 0, len(contexts))
+	for _, ctx := range contexts {
+		scope := string(ctx.ContextType) + ":" + ctx.Name
+		if orgID := stringValue(ctx.AdditionalMetadata[OrgIDKey]); orgID != "" {
+			scope += ":" + orgID
+		}
+		scopes = append(scopes, scope)
+	}
+	sort.Strings(scopes)
+	return strings.Join(scopes, ","), nil
+}
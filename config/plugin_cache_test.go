@@ -0,0 +1,238 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+// setupTestPluginCache sets up the test config and stores the plugin caches in a temporary XDG cache dir
+func setupTestPluginCache(t *testing.T) func() {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	t.Setenv(EnvXDGLayoutKey, "true")
+	t.Setenv(EnvXDGCacheHomeKey, t.TempDir())
+	return cleanUp
+}
+
+func TestPluginCache(t *testing.T) {
+	defer setupTestPluginCache(t)()
+
+	cache, err := PluginCache("my-plugin")
+	require.NoError(t, err)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	_, found, err := cache.Get("key")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	require.NoError(t, cache.Set("key", []byte("value"), time.Minute))
+	value, found, err := cache.Get("key")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []byte("value"), value)
+
+	// A hit only writes the last access of the entry once it is older than the resolution
+	require.NoError(t, cache.Set("key", []byte("value"), time.Hour))
+	dir, err := CacheDir("my-plugin")
+	require.NoError(t, err)
+	content, err := os.ReadFile(filepath.Join(dir, PluginCacheFileName))
+	require.NoError(t, err)
+	now = now.Add(pluginCacheAccessResolution - time.Second)
+	_, found, err = cache.Get("key")
+	require.NoError(t, err)
+	assert.True(t, found)
+	unchanged, err := os.ReadFile(filepath.Join(dir, PluginCacheFileName))
+	require.NoError(t, err)
+	assert.Equal(t, content, unchanged)
+	now = now.Add(time.Second)
+	_, found, err = cache.Get("key")
+	require.NoError(t, err)
+	assert.True(t, found)
+	changed, err := os.ReadFile(filepath.Join(dir, PluginCacheFileName))
+	require.NoError(t, err)
+	assert.NotEqual(t, content, changed)
+	require.NoError(t, cache.Set("key", []byte("value"), time.Minute))
+
+	// Another store of the plugin sees the entry
+	other, err := PluginCache("my-plugin")
+	require.NoError(t, err)
+	other.now = cache.now
+	value, found, err = other.Get("key")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []byte("value"), value)
+
+	now = now.Add(time.Minute)
+	_, found, err = cache.Get("key")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	require.NoError(t, cache.Set("key", []byte("value"), time.Minute))
+	require.NoError(t, cache.Invalidate("key"))
+	_, found, err = cache.Get("key")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	assert.EqualError(t, cache.Set("key", []byte("value"), 0), `the ttl of plugin cache entry "key" must be positive`)
+	assert.EqualError(t, cache.Set("", []byte("value"), time.Minute), "the key of a plugin cache entry cannot be empty")
+	_, err = PluginCache("my-plugin", WithPluginCacheMaxEntries(0))
+	assert.Error(t, err)
+	_, err = PluginCache("../my-plugin")
+	assert.Error(t, err)
+}
+
+func TestPluginCacheScope(t *testing.T) {
+	defer setupTestPluginCache(t)()
+	require.NoError(t, SetContext(&configtypes.Context{
+		Name:               "tanzu",
+		ContextType:        configtypes.ContextTypeTanzu,
+		GlobalOpts:         &configtypes.GlobalServer{Endpoint: "https://api.tanzu.example.com"},
+		AdditionalMetadata: map[string]interface{}{OrgIDKey: "org-1"},
+	}, true))
+
+	org1, err := PluginCache("my-plugin")
+	require.NoError(t, err)
+	require.NoError(t, org1.Set("key", []byte("org-1"), time.Minute))
+
+	// The same context logged in to another organization does not see the entry, even through the same store
+	setOrgID := func(orgID string) {
+		require.NoError(t, UpdateContext("tanzu", func(c *configtypes.Context) error {
+			c.AdditionalMetadata[OrgIDKey] = orgID
+			return nil
+		}))
+	}
+	setOrgID("org-2")
+	org2, err := PluginCache("my-plugin")
+	require.NoError(t, err)
+	for _, cache := range []*PluginCacheStore{org1, org2} {
+		_, found, err := cache.Get("key")
+		require.NoError(t, err)
+		assert.False(t, found)
+	}
+
+	// Nor does a cache without active context
+	require.NoError(t, RemoveActiveContext(configtypes.ContextTypeTanzu))
+	_, found, err := org1.Get("key")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	// A cache scoped to the context explicitly uses its current organization, whether it is active or not
+	scoped, err := PluginCache("my-plugin", WithPluginCacheContext("tanzu"))
+	require.NoError(t, err)
+	_, found, err = scoped.Get("key")
+	require.NoError(t, err)
+	assert.False(t, found)
+	setOrgID("org-1")
+	value, found, err := scoped.Get("key")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []byte("org-1"), value)
+
+	require.NoError(t, SetActiveContext("tanzu"))
+	value, found, err = org2.Get("key")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, []byte("org-1"), value)
+
+	_, err = PluginCache("my-plugin", WithPluginCacheContext("missing"))
+	assert.Error(t, err)
+}
+
+func TestPluginCacheEviction(t *testing.T) {
+	defer setupTestPluginCache(t)()
+
+	cache, err := PluginCache("my-plugin", WithPluginCacheMaxEntries(2), WithPluginCacheMaxBytes(10))
+	require.NoError(t, err)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time {
+		now = now.Add(pluginCacheAccessResolution)
+		return now
+	}
+
+	require.NoError(t, cache.Set("a", []byte("a"), time.Hour))
+	require.NoError(t, cache.Set("b", []byte("b"), time.Hour))
+	_, found, err := cache.Get("a")
+	require.NoError(t, err)
+	assert.True(t, found)
+	// b is the least recently used entry
+	require.NoError(t, cache.Set("c", []byte("c"), time.Hour))
+	_, found, err = cache.Get("b")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	// a and c are evicted to fit the size limit
+	require.NoError(t, cache.Set("d", []byte("1234567890"), time.Hour))
+	for key, expected := range map[string]bool{"a": false, "c": false, "d": true} {
+		_, found, err = cache.Get(key)
+		require.NoError(t, err)
+		assert.Equal(t, expected, found, key)
+	}
+
+	assert.EqualError(t, cache.Set("e", []byte("12345678901"), time.Hour),
+		`the value of plugin cache entry "e" exceeds the maximum size of 10 bytes`)
+}
+
+func TestPluginCacheConcurrentWrites(t *testing.T) {
+	defer setupTestPluginCache(t)()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			cache, err := PluginCache("my-plugin")
+			assert.NoError(t, err)
+			assert.NoError(t, cache.Set(fmt.Sprintf("key-%d", i), []byte("value"), time.Hour))
+		}(i)
+	}
+	wg.Wait()
+
+	cache, err := PluginCache("my-plugin")
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		_, found, err := cache.Get(fmt.Sprintf("key-%d", i))
+		require.NoError(t, err)
+		assert.True(t, found)
+	}
+}
+
+func TestPurgePluginCaches(t *testing.T) {
+	defer setupTestPluginCache(t)()
+
+	for _, plugin := range []string{"plugin-1", "plugin-2", "plugin-3"} {
+		cache, err := PluginCache(plugin)
+		require.NoError(t, err)
+		require.NoError(t, cache.Set("key", []byte("value"), time.Hour))
+	}
+	dir, err := CacheDir("plugin-1")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "other"), []byte("other"), 0600))
+
+	require.NoError(t, PurgePluginCaches("plugin-1"))
+	assert.NoFileExists(t, filepath.Join(dir, PluginCacheFileName))
+	assert.FileExists(t, filepath.Join(dir, "other"))
+	dir, err = CacheDir("plugin-2")
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(dir, PluginCacheFileName))
+
+	require.NoError(t, PurgePluginCaches())
+	for _, plugin := range []string{"plugin-2", "plugin-3"} {
+		cache, err := PluginCache(plugin)
+		require.NoError(t, err)
+		_, found, err := cache.Get("key")
+		require.NoError(t, err)
+		assert.False(t, found)
+	}
+}
@@ -0,0 +1,439 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+	"gopkg.in/yaml.v3"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+const (
+	// EnvConfigPolicyKey is the environment variable that points to the config policy.
+	// Setting it to an empty value disables the policy enforcement.
+	EnvConfigPolicyKey = "TANZU_CONFIG_POLICY"
+
+	// ConfigPolicyName is the name of the config policy
+	ConfigPolicyName = "policy.yaml"
+)
+
+// Policy is the organisation policy enforced on the config writes
+type Policy struct {
+	Rules []PolicyRule `json:"rules" yaml:"rules"`
+
+	// validated is set once the rules are validated and their patterns compiled
+	validated bool
+}
+
+// loadedPolicy is the config policy loaded from the file in the path, along with the file state it was loaded from
+var loadedPolicy = struct {
+	sync.Mutex
+	path     string
+	size     int64
+	modTime  time.Time
+	cachedAt time.Time
+	policy   *Policy
+}{}
+
+// PolicyRule constrains the values of the config found at the path.
+//
+// The path is a dotted path within the config e.g. "certs[*].skipCertVerify". Map keys are matched
+// as glob patterns e.g. "clientOptions.features.*.unstable-*", and sequence items are selected with
+// "[<pattern>]", matched as a glob pattern against the name or host of the item, or its index.
+type PolicyRule struct {
+	// Name of the rule
+	Name string `json:"name" yaml:"name"`
+	// Path of the values the constraint applies to
+	Path string `json:"path" yaml:"path"`
+	// Constraint on the values found at the path
+	Constraint PolicyConstraint `json:"constraint" yaml:"constraint"`
+	// Message describes the violation of the rule, defaults to the description of the constraint
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+
+	// pattern and notPattern are the compiled patterns of the constraint, set by Validate
+	pattern, notPattern *regexp.Regexp
+}
+
+// PolicyConstraint is the constraint on the values of a rule. All the specified conditions must hold.
+// The values are compared with their string representation.
+type PolicyConstraint struct {
+	// Forbidden forbids any value at the path
+	Forbidden bool `json:"forbidden,omitempty" yaml:"forbidden,omitempty"`
+	// Equals requires the value to be equal to the string
+	Equals *string `json:"equals,omitempty" yaml:"equals,omitempty"`
+	// NotEquals requires the value not to be equal to the string
+	NotEquals *string `json:"notEquals,omitempty" yaml:"notEquals,omitempty"`
+	// OneOf requires the value to be one of the strings
+	OneOf []string `json:"oneOf,omitempty" yaml:"oneOf,omitempty"`
+	// NoneOf requires the value not to be any of the strings
+	NoneOf []string `json:"noneOf,omitempty" yaml:"noneOf,omitempty"`
+	// Pattern requires the value to match the regular expression
+	Pattern string `json:"pattern,omitempty" yaml:"pattern,omitempty"`
+	// NotPattern requires the value not to match the regular expression
+	NotPattern string `json:"notPattern,omitempty" yaml:"notPattern,omitempty"`
+}
+
+// PolicyViolation is a value of the config that violates a rule of the policy
+type PolicyViolation struct {
+	// Rule is the name of the violated rule
+	Rule string `json:"rule" yaml:"rule"`
+	// Path is the path of the violating value within the config
+	Path string `json:"path" yaml:"path"`
+	// Value is the violating value
+	Value interface{} `json:"value,omitempty" yaml:"value,omitempty"`
+	// Message describes the violation
+	Message string `json:"message" yaml:"message"`
+}
+
+// Error returns the description of the policy violation
+func (v *PolicyViolation) Error() string {
+	return fmt.Sprintf("policy rule %q violated by %s: %s", v.Rule, v.Path, v.Message)
+}
+
+// ConfigPolicyPath returns the path of the config policy, checking for environment overrides.
+// Returns an empty path if the policy enforcement is disabled.
+func ConfigPolicyPath() string {
+	if p, ok := os.LookupEnv(EnvConfigPolicyKey); ok {
+		return p
+	}
+	return filepath.Join(systemConfigDir(), ConfigPolicyName)
+}
+
+// GetPolicy retrieves the config policy. Returns nil if there is no config policy.
+// The policy is validated and its patterns compiled once, and reused while the policy file is unchanged.
+// Returns an error naming the policy path if the policy file exists but is unreadable, malformed or invalid.
+func GetPolicy() (*Policy, error) {
+	policyPath := ConfigPolicyPath()
+	if policyPath == "" {
+		return nil, nil
+	}
+	info, err := os.Stat(policyPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to read config policy %q", policyPath)
+	}
+	loadedPolicy.Lock()
+	defer loadedPolicy.Unlock()
+	if loadedPolicy.policy != nil && loadedPolicy.path == policyPath && loadedPolicy.size == info.Size() &&
+		loadedPolicy.modTime.Equal(info.ModTime()) && loadedPolicy.cachedAt.Sub(info.ModTime()) >= configCacheRacyWindow {
+		return loadedPolicy.policy, nil
+	}
+	policy, err := loadPolicy(policyPath)
+	if err != nil || policy == nil {
+		return policy, err
+	}
+	loadedPolicy.path, loadedPolicy.size, loadedPolicy.modTime = policyPath, info.Size(), info.ModTime()
+	loadedPolicy.cachedAt, loadedPolicy.policy = time.Now(), policy
+	return policy, nil
+}
+
+func loadPolicy(policyPath string) (*Policy, error) {
+	// The policy file is read directly rather than through the config file cache, which ignores read errors
+	data, err := os.ReadFile(policyPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read config policy %q", policyPath)
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var policy Policy
+	if err := yaml.Unmarshal(data, &policy); err != nil {
+		return nil, errors.Wrapf(err, "malformed config policy %q", policyPath)
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, errors.Wrapf(err, "invalid config policy %q", policyPath)
+	}
+	return &policy, nil
+}
+
+// Validate checks the rules of the policy are well-formed and compiles their patterns
+func (p *Policy) Validate() error {
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if rule.Name == "" {
+			return errors.Errorf("rule %d: name cannot be empty", i)
+		}
+		if _, err := parsePolicyPath(rule.Path); err != nil {
+			return errors.Wrapf(err, "rule %q", rule.Name)
+		}
+		var err error
+		if rule.pattern, err = compilePolicyPattern(rule.Name, rule.Constraint.Pattern); err != nil {
+			return err
+		}
+		if rule.notPattern, err = compilePolicyPattern(rule.Name, rule.Constraint.NotPattern); err != nil {
+			return err
+		}
+	}
+	p.validated = true
+	return nil
+}
+
+// compilePolicyPattern compiles the pattern of the constraint of the rule. Returns nil if the pattern is empty.
+func compilePolicyPattern(rule, pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, errors.Wrapf(err, "rule %q: invalid pattern %q", rule, pattern)
+	}
+	return re, nil
+}
+
+// Evaluate returns the violations of the policy by the config
+func (p *Policy) Evaluate(cfg *configtypes.ClientConfig) ([]PolicyViolation, error) {
+	if !p.validated {
+		if err := p.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	generic, err := toGeneric(cfg)
+	if err != nil {
+		return nil, err
+	}
+	violations := make([]PolicyViolation, 0)
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		segments, err := parsePolicyPath(rule.Path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "rule %q", rule.Name)
+		}
+		for _, match := range matchPolicyPath(generic, "", segments) {
+			if message, ok := rule.check(match.value); !ok {
+				violations = append(violations, PolicyViolation{Rule: rule.Name, Path: match.path, Value: match.value, Message: message})
+			}
+		}
+	}
+	return violations, nil
+}
+
+// AuditPolicy returns the violations of the config policy by the existing config
+func AuditPolicy() ([]PolicyViolation, error) {
+	policy, err := GetPolicy()
+	if err != nil {
+		return nil, err
+	}
+	if policy == nil {
+		return make([]PolicyViolation, 0), nil
+	}
+	cfg, err := GetClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	return policy.Evaluate(cfg)
+}
+
+// checkPolicy returns the violations of the config policy introduced by the write of the new config node over the
+// old config node, combined in a single error. The violations already present in the old config do not prevent
+// the write, so that a write is only refused for the values it modifies.
+func checkPolicy(oldNode, newNode *yaml.Node) error {
+	// A policy that cannot be loaded cannot be enforced, so the write is refused until the policy is fixed
+	policy, err := GetPolicy()
+	if err != nil {
+		return errors.Wrapf(err, "config writes are refused until the config policy is fixed or removed by an administrator, or %s points to another policy", EnvConfigPolicyKey)
+	}
+	if policy == nil {
+		return nil
+	}
+	newCfg, err := convertNodeToClientConfig(newNode)
+	if err != nil {
+		return err
+	}
+	violations, err := policy.Evaluate(newCfg)
+	if err != nil || len(violations) == 0 {
+		return err
+	}
+	existing := make(map[string]bool)
+	if oldNode != nil {
+		oldCfg, err := convertNodeToClientConfig(oldNode)
+		if err != nil {
+			return err
+		}
+		oldViolations, err := policy.Evaluate(oldCfg)
+		if err != nil {
+			return err
+		}
+		for i := range oldViolations {
+			existing[oldViolations[i].key()] = true
+		}
+	}
+	var errs error
+	for i := range violations {
+		if !existing[violations[i].key()] {
+			errs = multierr.Append(errs, &violations[i])
+		}
+	}
+	return errs
+}
+
+// key identifies the violation by the rule, path and value
+func (v *PolicyViolation) key() string {
+	return fmt.Sprintf("%s\x00%s\x00%v", v.Rule, v.Path, v.Value)
+}
+
+// check returns the description of the violation if the value violates the constraint of the rule
+func (r *PolicyRule) check(value interface{}) (string, bool) {
+	c := &r.Constraint
+	s := fmt.Sprint(value)
+	var message string
+	switch {
+	case c.Forbidden:
+		message = "must not be set"
+	case c.Equals != nil && s != *c.Equals:
+		message = fmt.Sprintf("must be %q", *c.Equals)
+	case c.NotEquals != nil && s == *c.NotEquals:
+		message = fmt.Sprintf("must not be %q", *c.NotEquals)
+	case len(c.OneOf) > 0 && !containsString(c.OneOf, s):
+		message = fmt.Sprintf("must be one of %q", c.OneOf)
+	case len(c.NoneOf) > 0 && containsString(c.NoneOf, s):
+		message = fmt.Sprintf("must not be one of %q", c.NoneOf)
+	case r.pattern != nil && !r.pattern.MatchString(s):
+		message = fmt.Sprintf("must match %q", c.Pattern)
+	case r.notPattern != nil && r.notPattern.MatchString(s):
+		message = fmt.Sprintf("must not match %q", c.NotPattern)
+	default:
+		return "", true
+	}
+	if r.Message != "" {
+		message = r.Message
+	}
+	return message, false
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// policyPathSegment is a map key pattern or a sequence item pattern of a policy path
+type policyPathSegment struct {
+	pattern  string
+	sequence bool
+}
+
+// parsePolicyPath parses the dotted path with sequence item selectors e.g. "certs[*].host"
+func parsePolicyPath(p string) ([]policyPathSegment, error) {
+	if p == "" {
+		return nil, errors.New("path cannot be empty")
+	}
+	var segments []policyPathSegment
+	for _, part := range strings.Split(p, ".") {
+		key := part
+		var items []string
+		if i := strings.Index(part, "["); i != -1 {
+			key = part[:i]
+			rest := part[i:]
+			for rest != "" {
+				end := strings.Index(rest, "]")
+				if !strings.HasPrefix(rest, "[") || end == -1 {
+					return nil, errors.Errorf("invalid path %q", p)
+				}
+				items = append(items, rest[1:end])
+				rest = rest[end+1:]
+			}
+		}
+		if key == "" && len(segments) == 0 {
+			return nil, errors.Errorf("invalid path %q", p)
+		}
+		if key != "" {
+			segments = append(segments, policyPathSegment{pattern: key})
+		}
+		for _, item := range items {
+			segments = append(segments, policyPathSegment{pattern: item, sequence: true})
+		}
+	}
+	for _, segment := range segments {
+		if _, err := path.Match(segment.pattern, ""); err != nil {
+			return nil, errors.Errorf("invalid path %q", p)
+		}
+	}
+	return segments, nil
+}
+
+// policyPathMatch is a value found at a policy path
+type policyPathMatch struct {
+	path  string
+	value interface{}
+}
+
+// matchPolicyPath returns the values of the generic yaml representation found at the path segments
+func matchPolicyPath(value interface{}, current string, segments []policyPathSegment) []policyPathMatch {
+	if len(segments) == 0 {
+		return []policyPathMatch{{path: current, value: value}}
+	}
+	segment := segments[0]
+	var matches []policyPathMatch
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if segment.sequence {
+			return nil
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if ok, _ := path.Match(segment.pattern, key); ok {
+				matches = append(matches, matchPolicyPath(v[key], joinDiffPath(current, key), segments[1:])...)
+			}
+		}
+	case []interface{}:
+		if !segment.sequence {
+			return nil
+		}
+		for i, item := range v {
+			id := sequenceItemID(item)
+			if id == "" {
+				id = strconv.Itoa(i)
+			}
+			if ok, _ := path.Match(segment.pattern, id); ok {
+				matches = append(matches, matchPolicyPath(item, fmt.Sprintf("%s[%s]", current, id), segments[1:])...)
+			}
+		}
+	}
+	return matches
+}
+
+// sequenceItemID returns the name or host identifying the sequence item i.e. a context, cert or discovery source.
+// Returns empty string if the item has no identity.
+func sequenceItemID(item interface{}) string {
+	m, ok := item.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	for _, key := range []string{"name", "host"} {
+		if id, ok := m[key].(string); ok && id != "" {
+			return id
+		}
+	}
+	// Discovery sources are keyed by their type e.g. oci: {name: default}
+	for _, v := range m {
+		if nested, ok := v.(map[string]interface{}); ok {
+			if id, ok := nested["name"].(string); ok && id != "" {
+				return id
+			}
+		}
+	}
+	return ""
+}
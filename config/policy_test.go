@@ -0,0 +1,303 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+const testPolicy = `rules:
+  - name: no-skip-cert-verify
+    path: certs[*].skipCertVerify
+    constraint:
+      notEquals: "true"
+  - name: no-insecure-hosts
+    path: certs[*].insecure
+    constraint:
+      notEquals: "true"
+    message: insecure connections are not allowed
+  - name: approved-discovery-sources
+    path: cli.discoverySources[*].oci.image
+    constraint:
+      pattern: ^registry\.example\.com/
+  - name: no-unstable-features
+    path: clientOptions.features.*.unstable-*
+    constraint:
+      notEquals: "true"
+  - name: no-proxy-override
+    path: clientOptions.env.HTTPS_PROXY
+    constraint:
+      forbidden: true
+  - name: https-endpoints
+    path: contexts[*].clusterOpts.endpoint
+    constraint:
+      pattern: ^https://
+`
+
+func setupTestPolicy(t *testing.T, content string) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	t.Setenv(EnvConfigPolicyKey, path)
+}
+
+func TestPolicyEnforcedOnWrites(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+	setupTestPolicy(t, testPolicy)
+
+	tests := []struct {
+		name      string
+		write     func() error
+		violation *PolicyViolation
+	}{
+		{
+			name:      "cert skipping verification",
+			write:     func() error { return SetCert(&configtypes.Cert{Host: "example.com", SkipCertVerify: "true"}) },
+			violation: &PolicyViolation{Rule: "no-skip-cert-verify", Path: "certs[example.com].skipCertVerify", Value: "true", Message: `must not be "true"`},
+		},
+		{
+			name:      "insecure cert",
+			write:     func() error { return SetCert(&configtypes.Cert{Host: "example.com", Insecure: "true"}) },
+			violation: &PolicyViolation{Rule: "no-insecure-hosts", Path: "certs[example.com].insecure", Value: "true", Message: "insecure connections are not allowed"},
+		},
+		{
+			name: "unapproved discovery source",
+			write: func() error {
+				return SetCLIDiscoverySource(configtypes.PluginDiscovery{OCI: &configtypes.OCIDiscovery{Name: "other", Image: "other.example.com/plugins:latest"}})
+			},
+			violation: &PolicyViolation{Rule: "approved-discovery-sources", Path: "cli.discoverySources[other].oci.image", Value: "other.example.com/plugins:latest", Message: `must match "^registry\\.example\\.com/"`},
+		},
+		{
+			name:      "unstable feature",
+			write:     func() error { return SetFeature("global", "unstable-feature", "true") },
+			violation: &PolicyViolation{Rule: "no-unstable-features", Path: "clientOptions.features.global.unstable-feature", Value: "true", Message: `must not be "true"`},
+		},
+		{
+			name:      "forbidden env",
+			write:     func() error { return SetEnv("HTTPS_PROXY", "http://proxy") },
+			violation: &PolicyViolation{Rule: "no-proxy-override", Path: "clientOptions.env.HTTPS_PROXY", Value: "http://proxy", Message: "must not be set"},
+		},
+		{
+			name: "plaintext context endpoint",
+			write: func() error {
+				return SetContext(&configtypes.Context{Name: "ctx", ContextType: configtypes.ContextTypeK8s, ClusterOpts: &configtypes.ClusterServer{Endpoint: "http://cluster"}}, false)
+			},
+			violation: &PolicyViolation{Rule: "https-endpoints", Path: "contexts[ctx].clusterOpts.endpoint", Value: "http://cluster", Message: `must match "^https://"`},
+		},
+		{
+			name:  "compliant cert",
+			write: func() error { return SetCert(&configtypes.Cert{Host: "example.com", SkipCertVerify: "false"}) },
+		},
+		{
+			name: "approved discovery source",
+			write: func() error {
+				return SetCLIDiscoverySource(configtypes.PluginDiscovery{OCI: &configtypes.OCIDiscovery{Name: "default", Image: "registry.example.com/plugins:latest"}})
+			},
+		},
+		{
+			name:  "stable feature",
+			write: func() error { return SetFeature("global", "stable-feature", "true") },
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.write()
+			if tc.violation == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var violation *PolicyViolation
+			require.True(t, errors.As(err, &violation), "expected a policy violation, got %v", err)
+			assert.Equal(t, tc.violation, violation)
+		})
+	}
+
+	// Violating writes are not persisted
+	_, err := GetCert("example.com")
+	require.NoError(t, err)
+	_, err = GetEnv("HTTPS_PROXY")
+	assert.Error(t, err)
+	_, err = GetContext("ctx")
+	assert.Error(t, err)
+}
+
+func TestPolicyEnforcedOnAllWrites(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+	require.NoError(t, SetContext(&configtypes.Context{
+		Name:        "ctx",
+		ContextType: configtypes.ContextTypeK8s,
+		ClusterOpts: &configtypes.ClusterServer{Endpoint: "https://cluster", Path: "kubeconfig", Context: "ctx"},
+	}, false))
+	require.NoError(t, SetCLIDiscoverySource(configtypes.PluginDiscovery{OCI: &configtypes.OCIDiscovery{Name: "source", Image: "registry.example.com/plugins:latest"}, Disabled: true}))
+	setupTestPolicy(t, testPolicy+`  - name: no-context-proxy-override
+    path: contexts[*].env.HTTPS_PROXY
+    constraint:
+      forbidden: true
+  - name: no-unstable-context-features
+    path: contexts[*].features.*.unstable-*
+    constraint:
+      notEquals: "true"
+  - name: no-unstable-context-type-features
+    path: clientOptions.contextTypeFeatures.*.*.unstable-*
+    constraint:
+      notEquals: "true"
+  - name: no-context-type-proxy-override
+    path: clientOptions.contextTypeEnv.*.HTTPS_PROXY
+    constraint:
+      forbidden: true
+  - name: enabled-discovery-sources
+    path: cli.discoverySources[*].disabled
+    constraint:
+      forbidden: true
+  - name: default-priority
+    path: cli.discoverySources[*].priority
+    constraint:
+      forbidden: true
+  - name: approved-repositories
+    path: clientOptions.cli.repositories[*].ociPluginRepository.image
+    constraint:
+      pattern: ^registry\.example\.com/
+`)
+
+	for name, write := range map[string]func() error{
+		"SetContextEnv":     func() error { return SetContextEnv("ctx", "HTTPS_PROXY", "http://proxy") },
+		"SetContextTypeEnv": func() error { return SetContextTypeEnv(configtypes.ContextTypeK8s, "HTTPS_PROXY", "http://proxy") },
+		"SetContextFeature": func() error { return SetContextFeature("ctx", "global", "unstable-feature", "true") },
+		"SetContextTypeFeature": func() error {
+			return SetContextTypeFeature(configtypes.ContextTypeK8s, "global", "unstable-feature", "true")
+		},
+		"SetCLIDiscoverySourcePriority": func() error { return SetCLIDiscoverySourcePriority("source", 10) },
+		"SetCLIRepository": func() error {
+			return SetCLIRepository(configtypes.PluginRepository{OCIPluginRepository: &configtypes.OCIPluginRepository{Name: "repo", Image: "other.example.com/plugins:latest"}})
+		},
+		"StoreClientConfig": func() error {
+			cfg, err := GetClientConfig()
+			require.NoError(t, err)
+			cfg.ClientOptions = &configtypes.ClientOptions{Env: map[string]string{"HTTPS_PROXY": "http://proxy"}}
+			return StoreClientConfig(cfg)
+		},
+	} {
+		var violation *PolicyViolation
+		assert.True(t, errors.As(write(), &violation), name)
+	}
+
+	// The existing violation of the disabled discovery source does not prevent unrelated writes
+	assert.NoError(t, SetEnv("FOO", "bar"))
+	assert.NoError(t, EnableCLIDiscoverySource("source"))
+
+	// All the violations of a write are reported
+	err := SetCert(&configtypes.Cert{Host: "example.com", SkipCertVerify: "true", Insecure: "true"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `policy rule "no-skip-cert-verify" violated`)
+	assert.Contains(t, err.Error(), `policy rule "no-insecure-hosts" violated`)
+}
+
+func TestAuditPolicy(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+
+	violations, err := AuditPolicy()
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+
+	require.NoError(t, SetCert(&configtypes.Cert{Host: "a.example.com", SkipCertVerify: "true"}))
+	require.NoError(t, SetCert(&configtypes.Cert{Host: "b.example.com", SkipCertVerify: "false"}))
+	require.NoError(t, SetFeature("global", "unstable-feature", "true"))
+	setupTestPolicy(t, testPolicy)
+
+	violations, err = AuditPolicy()
+	require.NoError(t, err)
+	assert.Equal(t, []PolicyViolation{
+		{Rule: "no-skip-cert-verify", Path: "certs[a.example.com].skipCertVerify", Value: "true", Message: `must not be "true"`},
+		{Rule: "no-unstable-features", Path: "clientOptions.features.global.unstable-feature", Value: "true", Message: `must not be "true"`},
+	}, violations)
+}
+
+func TestInvalidPolicy(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+
+	tests := []struct {
+		name   string
+		policy string
+		errStr string
+	}{
+		{name: "missing name", policy: "rules:\n  - path: certs\n", errStr: "rule 0: name cannot be empty"},
+		{name: "empty path", policy: "rules:\n  - name: r\n", errStr: `rule "r": path cannot be empty`},
+		{name: "unterminated selector", policy: "rules:\n  - name: r\n    path: certs[*.host\n", errStr: `rule "r": invalid path "certs[*.host"`},
+		{name: "invalid pattern", policy: "rules:\n  - name: r\n    path: certs\n    constraint:\n      pattern: \"[\"\n", errStr: `rule "r": invalid pattern "["`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			setupTestPolicy(t, tc.policy)
+			_, err := AuditPolicy()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.errStr)
+			// Writes are refused while the policy is invalid
+			assert.Error(t, SetEnv("FOO", "bar"))
+		})
+	}
+}
+
+func TestUnloadablePolicy(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+
+	dir := t.TempDir()
+	tests := []struct {
+		name   string
+		setup  func(path string)
+		errStr string
+	}{
+		{
+			name:   "malformed yaml",
+			setup:  func(path string) { require.NoError(t, os.WriteFile(path, []byte("rules: [\n"), 0644)) },
+			errStr: "malformed config policy",
+		},
+		{
+			name:   "malformed rules",
+			setup:  func(path string) { require.NoError(t, os.WriteFile(path, []byte("rules: none\n"), 0644)) },
+			errStr: "malformed config policy",
+		},
+		{
+			name:   "unreadable",
+			setup:  func(path string) { require.NoError(t, os.Mkdir(path, 0755)) },
+			errStr: "failed to read config policy",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(dir, strings.ReplaceAll(tc.name, " ", "-")+".yaml")
+			tc.setup(path)
+			t.Setenv(EnvConfigPolicyKey, path)
+
+			// The error names the policy path
+			_, err := GetPolicy()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.errStr)
+			assert.Contains(t, err.Error(), path)
+
+			// Writes are refused with an error explaining how to recover
+			err = SetEnv("FOO", "bar")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), path)
+			assert.Contains(t, err.Error(), EnvConfigPolicyKey)
+
+			// Writes succeed again once the policy is removed
+			require.NoError(t, os.RemoveAll(path))
+			require.NoError(t, SetEnv("FOO", "bar"))
+			require.NoError(t, DeleteEnv("FOO"))
+		})
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// EnvConfigReadOnlyKey is the environment variable that enables the read-only mode of the config API when set to true
+const EnvConfigReadOnlyKey = "TANZU_CONFIG_READONLY"
+
+// ErrReadOnly is returned when writing the config in read-only mode
+var ErrReadOnly = errors.New("config is read-only")
+
+// ConfigOptions are the process wide options of the config API
+type ConfigOptions struct {
+	// ReadOnly rejects all the writes of the config, config metadata and legacy config with ErrReadOnly.
+	// Reads that would repair or migrate the config do so in memory only.
+	ReadOnly bool
+}
+
+type ConfigOpts func(opts *ConfigOptions)
+
+// WithReadOnly enables the read-only mode of the config API
+func WithReadOnly() ConfigOpts {
+	return func(opts *ConfigOptions) {
+		opts.ReadOnly = true
+	}
+}
+
+// configOptions are the process wide options of the config API set with Configure
+var configOptions = struct {
+	sync.RWMutex
+	ConfigOptions
+}{}
+
+// Configure sets the process wide options of the config API. Options that are not specified are reset to their defaults.
+func Configure(opts ...ConfigOpts) {
+	options := ConfigOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	configOptions.Lock()
+	defer configOptions.Unlock()
+	configOptions.ConfigOptions = options
+}
+
+// IsReadOnly checks whether the config API is in read-only mode, either with WithReadOnly or TANZU_CONFIG_READONLY
+func IsReadOnly() bool {
+	configOptions.RLock()
+	readOnly := configOptions.ReadOnly
+	configOptions.RUnlock()
+	if readOnly {
+		return true
+	}
+	readOnly, _ = strconv.ParseBool(os.Getenv(EnvConfigReadOnlyKey))
+	return readOnly
+}
+
+// checkReadOnly returns ErrReadOnly if the config API is in read-only mode
+func checkReadOnly(what string) error {
+	if IsReadOnly() {
+		return errors.Wrapf(ErrReadOnly, "cannot write %s", what)
+	}
+	return nil
+}
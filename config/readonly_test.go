@@ -0,0 +1,127 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+func TestReadOnlyRejectsWrites(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(t *testing.T)
+	}{
+		{
+			name:  "env",
+			setup: func(t *testing.T) { t.Setenv(EnvConfigReadOnlyKey, "true") },
+		},
+		{
+			name: "option",
+			setup: func(t *testing.T) {
+				Configure(WithReadOnly())
+				t.Cleanup(func() { Configure() })
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, cleanUp := setupTestConfig(t, &CfgTestData{})
+			defer cleanUp()
+			require.NoError(t, SetEnv("FOO", "bar"))
+			require.NoError(t, SetContext(testRevisionContext("ctx", "https://a"), false))
+			before := readTestConfigFiles(t)
+
+			tc.setup(t)
+			assert.True(t, IsReadOnly())
+
+			err := SetEnv("FOO", "updated")
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, ErrReadOnly))
+			assert.Equal(t, "cannot write the config: config is read-only", err.Error())
+			assert.ErrorIs(t, DeleteContext("ctx"), ErrReadOnly)
+			assert.ErrorIs(t, SetFeature("global", "feature", "true"), ErrReadOnly)
+			assert.ErrorIs(t, SetConfigMetadataSetting("key", "value"), ErrReadOnly)
+			AcquireTanzuConfigLock()
+			cfg, err := GetClientConfigNoLock()
+			require.NoError(t, err)
+			assert.ErrorIs(t, StoreClientConfig(cfg), ErrReadOnly)
+			ReleaseTanzuConfigLock()
+			assert.ErrorIs(t, DeleteClientConfig(), ErrReadOnly)
+			assert.ErrorIs(t, DeleteClientConfigNextGen(), ErrReadOnly)
+
+			// Reads are not affected
+			env, err := GetEnv("FOO")
+			require.NoError(t, err)
+			assert.Equal(t, "bar", env)
+			_, err = GetContext("ctx")
+			require.NoError(t, err)
+			assert.Equal(t, before, readTestConfigFiles(t))
+		})
+	}
+}
+
+func TestReadOnlyEphemeralConfig(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+	setupTestEphemeralConfig(t, testEphemeralConfig, EphemeralWritesInMemory)
+	t.Setenv(EnvConfigReadOnlyKey, "1")
+
+	assert.ErrorIs(t, SetEnv("FOO", "updated"), ErrReadOnly)
+	env, err := GetEnv("FOO")
+	require.NoError(t, err)
+	assert.Equal(t, "bar", env)
+}
+
+func TestReadOnlyLegacyConfigDirNotCopied(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv(EnvConfigKey, "")
+	require.NoError(t, os.Unsetenv(EnvConfigKey))
+	legacyDir, err := legacyLocalDir()
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(legacyDir, 0755))
+	legacyPath, err := legacyConfigPath()
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(legacyPath, []byte("clientOptions:\n  env:\n    FOO: legacy\n"), 0644))
+	t.Setenv(EnvConfigReadOnlyKey, "true")
+
+	require.NoError(t, CopyLegacyConfigDir())
+	localDir, err := LocalDir()
+	require.NoError(t, err)
+	assert.NoDirExists(t, localDir)
+
+	// The legacy config is read in memory
+	cfg, err := GetClientConfig()
+	require.NoError(t, err)
+	require.NotNil(t, cfg.ClientOptions)
+	assert.Equal(t, map[string]string{"FOO": "legacy"}, cfg.ClientOptions.Env)
+	assert.NoFileExists(t, filepath.Join(localDir, ConfigName))
+
+	// Contexts are populated in memory from the legacy servers
+	require.NoError(t, os.WriteFile(legacyPath, []byte("servers:\n  - name: mc\n    type: managementcluster\n    managementClusterOpts:\n      path: kubeconfig\n      context: mc\n"), 0644))
+	AcquireTanzuConfigLock()
+	cfg, err = GetClientConfigNoLock()
+	ReleaseTanzuConfigLock()
+	require.NoError(t, err)
+	require.True(t, PopulateContexts(cfg))
+	assert.Equal(t, []*configtypes.Context{{
+		Name:        "mc",
+		Target:      configtypes.TargetK8s,
+		ContextType: configtypes.ContextTypeK8s,
+		ClusterOpts: &configtypes.ClusterServer{Path: "kubeconfig", Context: "mc", IsManagementCluster: true},
+	}}, cfg.KnownContexts)
+	assert.NoDirExists(t, localDir)
+}
@@ -0,0 +1,142 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/config/nodeutils"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+// maxUpdateContextAttempts is the number of attempts of UpdateContext before giving up on revision conflicts
+const maxUpdateContextAttempts = 10
+
+// ErrRevisionConflict is returned by the compare-and-swap APIs when the config was modified since it was read
+var ErrRevisionConflict = errors.New("config revision conflict")
+
+// GetConfigRevision retrieves the revision of the config. The revision is stored in the config metadata
+// and incremented under the config lock on every write of the config through the config APIs.
+func GetConfigRevision() (int64, error) {
+	AcquireTanzuConfigLock()
+	defer ReleaseTanzuConfigLock()
+	return getConfigRevision()
+}
+
+// GetContextWithRevision retrieves the context by name along with the revision of the config it was read at.
+// The revision can be used with SetContextIfRevision to safely write back the modified context.
+func GetContextWithRevision(name string) (*configtypes.Context, int64, error) {
+	AcquireTanzuConfigLock()
+	defer ReleaseTanzuConfigLock()
+	revision, err := getConfigRevision()
+	if err != nil {
+		return nil, 0, err
+	}
+	node, err := getClientConfigNodeNoLock()
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := mergeSystemConfig(node); err != nil {
+		return nil, 0, err
+	}
+	ctx, err := getContext(node, name)
+	if err != nil {
+		return nil, 0, err
+	}
+	return ctx, revision, nil
+}
+
+// SetContextIfRevision add or update context and currentContext only if the config is still at the revision.
+// Returns an error wrapping ErrRevisionConflict if the config was modified since the revision.
+func SetContextIfRevision(c *configtypes.Context, setCurrent bool, revision int64) error {
+	AcquireTanzuConfigLock()
+	defer ReleaseTanzuConfigLock()
+	current, err := getConfigRevision()
+	if err != nil {
+		return err
+	}
+	if current != revision {
+		return errors.Wrapf(ErrRevisionConflict, "expected revision %d, current revision %d", revision, current)
+	}
+	return setContextNoLock(c, setCurrent)
+}
+
+// UpdateContext reads the context by name, applies the update and writes the context back with compare-and-swap.
+// The read-modify-write cycle is retried if the config is modified by another process in between,
+// so the update function may be invoked multiple times and should not have side effects.
+func UpdateContext(name string, update func(c *configtypes.Context) error) error {
+	if update == nil {
+		return errors.New("update function cannot be nil")
+	}
+	var err error
+	for attempt := 0; attempt < maxUpdateContextAttempts; attempt++ {
+		ctx, revision, getErr := GetContextWithRevision(name)
+		if getErr != nil {
+			return getErr
+		}
+		if updateErr := update(ctx); updateErr != nil {
+			return updateErr
+		}
+		if ctx.Name != name {
+			return errors.Errorf("context name cannot be changed from %q to %q", name, ctx.Name)
+		}
+		err = SetContextIfRevision(ctx, false, revision)
+		if !errors.Is(err, ErrRevisionConflict) {
+			return err
+		}
+	}
+	return errors.Wrapf(err, "failed to update context %q after %d attempts", name, maxUpdateContextAttempts)
+}
+
+// getConfigRevision retrieves the revision of the config from the config metadata
+func getConfigRevision() (int64, error) {
+	if IsEphemeralConfig() {
+		return getEphemeralConfigRevision(), nil
+	}
+	node, err := getMetadataNode()
+	if err != nil {
+		return 0, err
+	}
+	metadata, err := getMetadata(node)
+	if err != nil {
+		return 0, err
+	}
+	if metadata == nil || metadata.ConfigMetadata == nil {
+		return 0, nil
+	}
+	return metadata.ConfigMetadata.Revision, nil
+}
+
+// incrementConfigRevision increments the revision of the config in the config metadata, along with the
+// optional update of the config metadata made in the same write.
+// Pre-reqs: the tanzu config lock is acquired
+func incrementConfigRevision(update func(node *yaml.Node) error) error {
+	return updateConfigMetadata(func(node *yaml.Node) error {
+		if update != nil {
+			if err := update(node); err != nil {
+				return err
+			}
+		}
+		keys := []nodeutils.Key{
+			{Name: KeyConfigMetadata, Type: yaml.MappingNode},
+		}
+		configMetadataNode := nodeutils.FindNode(node.Content[0], nodeutils.WithForceCreate(), nodeutils.WithKeys(keys))
+		if configMetadataNode == nil {
+			return nodeutils.ErrNodeNotFound
+		}
+		var revision int64
+		if index := nodeutils.GetNodeIndex(configMetadataNode.Content, KeyRevision); index != -1 {
+			var err error
+			revision, err = strconv.ParseInt(configMetadataNode.Content[index].Value, 10, 64)
+			if err != nil {
+				return errors.Wrap(err, "invalid config revision")
+			}
+		}
+		setScalarValue(configMetadataNode, KeyRevision, strconv.FormatInt(revision+1, 10), "!!int")
+		return nil
+	})
+}
@@ -0,0 +1,168 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+func testRevisionContext(name, endpoint string) *configtypes.Context {
+	return &configtypes.Context{
+		Name:        name,
+		ContextType: configtypes.ContextTypeK8s,
+		ClusterOpts: &configtypes.ClusterServer{Endpoint: endpoint, Path: "test-path", Context: "test-context"},
+	}
+}
+
+func TestConfigRevisionIncrementedOnWrites(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+
+	revision, err := GetConfigRevision()
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), revision)
+
+	require.NoError(t, SetEnv("FOO", "bar"))
+	revision, err = GetConfigRevision()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), revision)
+
+	require.NoError(t, SetContext(testRevisionContext("ctx", "https://a"), false))
+	previous := revision
+	revision, err = GetConfigRevision()
+	require.NoError(t, err)
+	assert.Greater(t, revision, previous)
+
+	// Failed writes, writes without changes and metadata writes do not change the revision
+	previous = revision
+	assert.Error(t, DeleteContext("missing"))
+	require.NoError(t, SetEnv("FOO", "bar"))
+	require.NoError(t, SetConfigMetadataSetting("key", "value"))
+	revision, err = GetConfigRevision()
+	require.NoError(t, err)
+	assert.Equal(t, previous, revision)
+}
+
+func TestConfigRevisionStoredInMetadata(t *testing.T) {
+	cfgFiles, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+
+	require.NoError(t, SetContext(testRevisionContext("ctx", "https://a"), false))
+	revision, err := GetConfigRevision()
+	require.NoError(t, err)
+
+	// The revision is written to the config metadata file, the config files are left as written by the update
+	metadataPath, err := CfgMetadataFilePath()
+	require.NoError(t, err)
+	metadata, err := os.ReadFile(metadataPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(metadata), fmt.Sprintf("revision: %d\n", revision))
+	for _, f := range cfgFiles[:2] {
+		data, err := os.ReadFile(f.Name())
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "revision")
+	}
+
+	// A config write fails without being applied if the revision cannot be updated
+	require.NoError(t, os.Remove(metadataPath))
+	require.NoError(t, os.Mkdir(metadataPath, 0o700))
+	assert.Error(t, SetEnv("FOO", "bar"))
+	require.NoError(t, os.Remove(metadataPath))
+	require.NoError(t, os.WriteFile(metadataPath, metadata, 0o600))
+	_, err = GetEnv("FOO")
+	assert.Error(t, err)
+}
+
+func TestSetContextIfRevision(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+	require.NoError(t, SetContext(testRevisionContext("ctx", "https://a"), false))
+
+	ctx, revision, err := GetContextWithRevision("ctx")
+	require.NoError(t, err)
+	assert.Equal(t, "https://a", ctx.ClusterOpts.Endpoint)
+
+	// Another writer modifies the config
+	require.NoError(t, SetEnv("FOO", "bar"))
+
+	ctx.ClusterOpts.Endpoint = "https://b"
+	err = SetContextIfRevision(ctx, false, revision)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrRevisionConflict))
+	assert.Equal(t, fmt.Sprintf("expected revision %d, current revision %d: config revision conflict", revision, revision+1), err.Error())
+
+	ctx, revision, err = GetContextWithRevision("ctx")
+	require.NoError(t, err)
+	assert.Equal(t, "https://a", ctx.ClusterOpts.Endpoint)
+	ctx.ClusterOpts.Endpoint = "https://b"
+	require.NoError(t, SetContextIfRevision(ctx, true, revision))
+
+	ctx, err = GetContext("ctx")
+	require.NoError(t, err)
+	assert.Equal(t, "https://b", ctx.ClusterOpts.Endpoint)
+	current, err := GetActiveContext(configtypes.ContextTypeK8s)
+	require.NoError(t, err)
+	assert.Equal(t, "ctx", current.Name)
+}
+
+func TestUpdateContext(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+	require.NoError(t, SetContext(testRevisionContext("ctx", "https://a"), false))
+
+	attempts := 0
+	err := UpdateContext("ctx", func(c *configtypes.Context) error {
+		attempts++
+		if attempts == 1 {
+			// Concurrent write between the read and the write of the first attempt
+			require.NoError(t, SetEnv("FOO", "bar"))
+		}
+		c.ClusterOpts.Endpoint = "https://b"
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+
+	ctx, err := GetContext("ctx")
+	require.NoError(t, err)
+	assert.Equal(t, "https://b", ctx.ClusterOpts.Endpoint)
+
+	// Errors of the update function are returned without writing the context
+	err = UpdateContext("ctx", func(c *configtypes.Context) error {
+		c.ClusterOpts.Endpoint = "https://c"
+		return errors.New("update failed")
+	})
+	assert.EqualError(t, err, "update failed")
+
+	err = UpdateContext("ctx", func(c *configtypes.Context) error {
+		c.Name = "renamed"
+		return nil
+	})
+	assert.EqualError(t, err, `context name cannot be changed from "ctx" to "renamed"`)
+
+	// Persistent conflicts give up after the maximum number of attempts
+	attempts = 0
+	err = UpdateContext("ctx", func(c *configtypes.Context) error {
+		attempts++
+		return SetEnv("FOO", fmt.Sprint(attempts))
+	})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrRevisionConflict))
+	assert.Contains(t, err.Error(), `failed to update context "ctx" after 10 attempts`)
+	assert.Equal(t, maxUpdateContextAttempts, attempts)
+
+	ctx, err = GetContext("ctx")
+	require.NoError(t, err)
+	assert.Equal(t, "https://b", ctx.ClusterOpts.Endpoint)
+
+	assert.Error(t, UpdateContext("missing", func(c *configtypes.Context) error { return nil }))
+}
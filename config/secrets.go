@@ -0,0 +1,51 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// SecretRefPrefix is the prefix used by config values to reference a secret in the secret store.
+// E.g., ${secret:proxy-password}
+const SecretRefPrefix = "secret:"
+
+// SecretStore provides access to the secrets referenced from the configuration
+type SecretStore interface {
+	// GetSecret returns the secret value of the specified name
+	GetSecret(name string) (string, error)
+}
+
+var (
+	secretStoreMutex sync.RWMutex
+	secretStore      SecretStore
+)
+
+// SetSecretStore sets the secret store used to resolve the secret references in the configuration
+func SetSecretStore(store SecretStore) {
+	secretStoreMutex.Lock()
+	defer secretStoreMutex.Unlock()
+	secretStore = store
+}
+
+// GetSecret retrieves the secret value of the specified name from the configured secret store
+func GetSecret(name string) (string, error) {
+	secretStoreMutex.RLock()
+	defer secretStoreMutex.RUnlock()
+	if name == "" {
+		return "", errors.New("secret name cannot be empty")
+	}
+	if secretStore == nil {
+		return "", errors.Errorf("unable to resolve secret %q: secret store is not configured", name)
+	}
+	return secretStore.GetSecret(name)
+}
+
+// IsSecretRef returns true if the specified value is a reference to a secret in the secret store
+func IsSecretRef(value string) bool {
+	return strings.HasPrefix(value, "${"+SecretRefPrefix) && strings.HasSuffix(value, "}")
+}
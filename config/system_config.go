@@ -0,0 +1,379 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+)
+
+const (
+	// EnvSystemConfigKey is the environment variable that points to the system config.
+	// Setting it to an empty value disables the system config layer.
+	EnvSystemConfigKey = "TANZU_SYSTEM_CONFIG"
+
+	// systemConfigLockAll locks all the values of a section
+	systemConfigLockAll = "*"
+)
+
+// ErrLockedBySystemConfig is returned when modifying a value locked by the system config
+var ErrLockedBySystemConfig = errors.New("locked by the system config")
+
+// ConfigLayer is the config layer a value originates from
+type ConfigLayer string
+
+const (
+	// ConfigLayerUser is the user config layer stored in CFG and CFG_NG
+	ConfigLayerUser ConfigLayer = "user"
+	// ConfigLayerSystem is the read-only system config layer merged beneath the user config layer
+	ConfigLayerSystem ConfigLayer = "system"
+)
+
+// SystemConfigLocks are the keys of the values of the system config that cannot be overridden or modified in the user config.
+// The keys are the plugin and feature name for features i.e. "<plugin>.<feature>", the variable name for envs,
+// the host for certs and the source name for discovery sources. The key "*" locks all the values of the section.
+type SystemConfigLocks struct {
+	Features         []string `json:"features,omitempty" yaml:"features,omitempty"`
+	Envs             []string `json:"envs,omitempty" yaml:"envs,omitempty"`
+	Certs            []string `json:"certs,omitempty" yaml:"certs,omitempty"`
+	DiscoverySources []string `json:"discoverySources,omitempty" yaml:"discoverySources,omitempty"`
+}
+
+// SystemConfig is the read-only system config layer.
+// The feature flags and env variables of the client options, the certs and the cli discovery sources
+// of the system config are merged beneath the user config on every read.
+type SystemConfig struct {
+	configtypes.ClientConfig `yaml:",inline"`
+	// Locked are the values that cannot be overridden or modified in the user config
+	Locked SystemConfigLocks `json:"locked,omitempty" yaml:"locked,omitempty"`
+}
+
+// ConfigValueOrigin is the origin of a config value
+type ConfigValueOrigin struct {
+	// Layer the value originates from
+	Layer ConfigLayer `json:"layer" yaml:"layer"`
+	// Path of the config file of the system config layer, empty for the user config layer
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+	// Locked indicates the value is locked by the system config
+	Locked bool `json:"locked,omitempty" yaml:"locked,omitempty"`
+}
+
+// SystemConfigPath returns the path of the system config, checking for environment overrides.
+// Returns an empty path if the system config layer is disabled.
+func SystemConfigPath() string {
+	if path, ok := os.LookupEnv(EnvSystemConfigKey); ok {
+		return path
+	}
+	return filepath.Join(systemConfigDir(), ConfigName)
+}
+
+// systemConfigDir returns the directory of the system config and config policy
+func systemConfigDir() string {
+	if runtime.GOOS == "windows" {
+		return filepath.Join(os.Getenv("ProgramData"), "tanzu")
+	}
+	return filepath.Join(string(filepath.Separator), "etc", "tanzu")
+}
+
+// GetSystemConfig retrieves the system config. Returns nil if there is no system config.
+func GetSystemConfig() (*SystemConfig, error) {
+	path := SystemConfigPath()
+	if path == "" {
+		return nil, nil
+	}
+	node, err := readConfigFileNode(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to construct struct from system config %q", path)
+	}
+	if node == nil {
+		return nil, nil
+	}
+	var cfg SystemConfig
+	if err := node.Decode(&cfg); err != nil {
+		return nil, errors.Wrapf(err, "failed to construct struct from system config %q", path)
+	}
+	return &cfg, nil
+}
+
+// GetConfigValueOrigin returns the origin of the value identified by the key within the section.
+// The supported sections are features, envs, certs and discovery sources, keyed as in SystemConfigLocks.
+func GetConfigValueOrigin(section DiffSection, key string) (*ConfigValueOrigin, error) {
+	keysOf, err := systemConfigSectionKeys(section)
+	if err != nil {
+		return nil, err
+	}
+	sys, err := GetSystemConfig()
+	if err != nil {
+		return nil, err
+	}
+	if sys != nil {
+		if _, ok := keysOf(&sys.ClientConfig)[key]; ok && sys.isLocked(section, key) {
+			return &ConfigValueOrigin{Layer: ConfigLayerSystem, Path: SystemConfigPath(), Locked: true}, nil
+		}
+	}
+
+	AcquireTanzuConfigLock()
+	defer ReleaseTanzuConfigLock()
+	cfg, err := GetClientConfigNoLock()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := keysOf(cfg)[key]; ok {
+		return &ConfigValueOrigin{Layer: ConfigLayerUser}, nil
+	}
+	if sys != nil {
+		if _, ok := keysOf(&sys.ClientConfig)[key]; ok {
+			return &ConfigValueOrigin{Layer: ConfigLayerSystem, Path: SystemConfigPath()}, nil
+		}
+	}
+	return nil, errors.Errorf("%s %q not found", section, key)
+}
+
+// systemConfigSectionKeys returns the function that returns the keys of the values of the section of a ClientConfig
+func systemConfigSectionKeys(section DiffSection) (func(cfg *configtypes.ClientConfig) map[string]interface{}, error) {
+	toKeys := func(items map[string]string) map[string]interface{} {
+		keys := make(map[string]interface{}, len(items))
+		for k := range items {
+			keys[k] = nil
+		}
+		return keys
+	}
+	switch section {
+	case DiffSectionFeatures:
+		return func(cfg *configtypes.ClientConfig) map[string]interface{} { return toKeys(featuresByKey(cfg)) }, nil
+	case DiffSectionEnvs:
+		return func(cfg *configtypes.ClientConfig) map[string]interface{} { return toKeys(envsByKey(cfg)) }, nil
+	case DiffSectionCerts:
+		return certsByHost, nil
+	case DiffSectionDiscoverySources:
+		return discoverySourcesByName, nil
+	}
+	return nil, errors.Errorf("unsupported system config section %q", section)
+}
+
+// isLocked checks whether the value identified by the key within the section is locked by the system config
+func (c *SystemConfig) isLocked(section DiffSection, key string) bool {
+	var locked []string
+	switch section {
+	case DiffSectionFeatures:
+		locked = c.Locked.Features
+	case DiffSectionEnvs:
+		locked = c.Locked.Envs
+	case DiffSectionCerts:
+		locked = c.Locked.Certs
+	case DiffSectionDiscoverySources:
+		locked = c.Locked.DiscoverySources
+	}
+	for _, k := range locked {
+		if k == key || k == systemConfigLockAll {
+			return true
+		}
+	}
+	return false
+}
+
+// checkSystemConfigLock returns an error if the value identified by the key within the section is locked by the system config.
+// An invalid system config does not prevent modifications of the user config.
+func checkSystemConfigLock(section DiffSection, key string) error {
+	sys, err := GetSystemConfig()
+	if err != nil || sys == nil {
+		return nil
+	}
+	return sys.checkLock(section, key)
+}
+
+// checkLock returns an error if the value identified by the key within the section is locked
+func (c *SystemConfig) checkLock(section DiffSection, key string) error {
+	if c.isLocked(section, key) {
+		return errors.Wrapf(ErrLockedBySystemConfig, "cannot modify %s %q set by %s", section, key, SystemConfigPath())
+	}
+	return nil
+}
+
+// mergeSystemConfig merges the system config beneath the user config node.
+// The values of the system config are added to the node unless already set, locked values replace the values of the node.
+// An invalid system config is ignored with a warning.
+func mergeSystemConfig(node *yaml.Node) error {
+	sys, err := GetSystemConfig()
+	if err != nil {
+		log.Warningf("ignoring the system config: %v", err)
+		return nil
+	}
+	if sys == nil {
+		return nil
+	}
+	cfg, err := convertNodeToClientConfig(node)
+	if err != nil {
+		return err
+	}
+
+	features, envs := featuresByKey(cfg), envsByKey(cfg)
+	if sys.ClientOptions != nil {
+		for plugin, featureMap := range sys.ClientOptions.Features {
+			for key, value := range featureMap {
+				if _, exists := features[plugin+"."+key]; exists && !sys.isLocked(DiffSectionFeatures, plugin+"."+key) {
+					continue
+				}
+				if _, err := setFeature(node, plugin, key, value); err != nil {
+					return err
+				}
+			}
+		}
+		for key, value := range sys.ClientOptions.Env {
+			if _, exists := envs[key]; exists && !sys.isLocked(DiffSectionEnvs, key) {
+				continue
+			}
+			if _, err := setEnv(node, key, value); err != nil {
+				return err
+			}
+		}
+	}
+
+	certs := certsByHost(cfg)
+	for _, cert := range sys.Certs {
+		if cert == nil {
+			continue
+		}
+		if _, exists := certs[cert.Host]; exists {
+			if !sys.isLocked(DiffSectionCerts, cert.Host) {
+				continue
+			}
+			removeCert(node, cert.Host)
+		}
+		if _, err := setCert(node, cert); err != nil {
+			return err
+		}
+	}
+
+	if sys.CoreCliOptions != nil {
+		sources := discoverySourcesByName(cfg)
+		for _, source := range sys.CoreCliOptions.DiscoverySources {
+			_, name, err := getDiscoverySourceTypeAndName(source)
+			if err != nil {
+				continue
+			}
+			if _, exists := sources[name]; exists {
+				if !sys.isLocked(DiffSectionDiscoverySources, name) {
+					continue
+				}
+				if err := deleteCLIDiscoverySource(node, name); err != nil {
+					return err
+				}
+			}
+			if _, err := setCLIDiscoverySource(node, source); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// stripSystemConfigValues returns a copy of the config to store in the user config without the feature flags and env
+// variables merged from the system config, i.e. the values equal to those of the system config that are not set in
+// the user config. An error is returned if the config modifies a feature flag or env variable locked by the system
+// config, globally or scoped to a context.
+func stripSystemConfigValues(cfg, userCfg *configtypes.ClientConfig) (*configtypes.ClientConfig, error) {
+	sys, err := GetSystemConfig()
+	if err != nil || sys == nil {
+		return cfg, nil
+	}
+	if err := checkContextsSystemConfigLocks(sys, cfg, userCfg); err != nil {
+		return nil, err
+	}
+	if cfg.ClientOptions == nil || sys.ClientOptions == nil {
+		return cfg, nil
+	}
+
+	userFeatures, userEnvs := featuresByKey(userCfg), envsByKey(userCfg)
+	stripped := *cfg
+	opts := *cfg.ClientOptions
+	stripped.ClientOptions = &opts
+	if cfg.ClientOptions.Features != nil {
+		opts.Features = make(map[string]configtypes.FeatureMap)
+		for plugin, featureMap := range cfg.ClientOptions.Features {
+			opts.Features[plugin] = make(configtypes.FeatureMap)
+			for key, value := range featureMap {
+				sysValue, ok := sys.ClientOptions.Features[plugin][key]
+				strip, err := isSystemConfigValue(sys, DiffSectionFeatures, plugin+"."+key, value, sysValue, ok, userFeatures)
+				if err != nil {
+					return nil, err
+				}
+				if !strip {
+					opts.Features[plugin][key] = value
+				}
+			}
+		}
+	}
+	if cfg.ClientOptions.Env != nil {
+		opts.Env = make(map[string]string)
+		for key, value := range cfg.ClientOptions.Env {
+			sysValue, ok := sys.ClientOptions.Env[key]
+			strip, err := isSystemConfigValue(sys, DiffSectionEnvs, key, value, sysValue, ok, userEnvs)
+			if err != nil {
+				return nil, err
+			}
+			if !strip {
+				opts.Env[key] = value
+			}
+		}
+	}
+	return &stripped, nil
+}
+
+// isSystemConfigValue checks whether the value of the key within the section is the value merged from the system config.
+// An error is returned if the value differs from the locked value of the system config.
+func isSystemConfigValue(sys *SystemConfig, section DiffSection, key, value, sysValue string, inSystem bool, userValues map[string]string) (bool, error) {
+	if !inSystem {
+		return false, nil
+	}
+	if sys.isLocked(section, key) {
+		if value != sysValue {
+			return false, sys.checkLock(section, key)
+		}
+		return true, nil
+	}
+	_, inUser := userValues[key]
+	return value == sysValue && !inUser, nil
+}
+
+// checkContextsSystemConfigLocks returns an error if the config adds or modifies a feature flag or env variable scoped to
+// a context that overrides a feature flag or env variable locked by the system config
+func checkContextsSystemConfigLocks(sys *SystemConfig, cfg, userCfg *configtypes.ClientConfig) error {
+	for _, ctx := range cfg.KnownContexts {
+		if ctx == nil {
+			continue
+		}
+		userCtx, _ := findContext(userCfg, ctx.Name)
+		for plugin, featureMap := range ctx.Features {
+			for key, value := range featureMap {
+				if userCtx != nil {
+					if userValue, ok := userCtx.Features[plugin][key]; ok && userValue == value {
+						continue
+					}
+				}
+				if err := sys.checkLock(DiffSectionFeatures, plugin+"."+key); err != nil {
+					return err
+				}
+			}
+		}
+		for key, value := range ctx.Env {
+			if userCtx != nil {
+				if userValue, ok := userCtx.Env[key]; ok && userValue == value {
+					continue
+				}
+			}
+			if err := sys.checkLock(DiffSectionEnvs, key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
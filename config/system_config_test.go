@@ -0,0 +1,251 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+const testSystemConfig = `clientOptions:
+  features:
+    global:
+      system-feature: "true"
+      locked-feature: "true"
+  env:
+    SYSTEM_ENV: system
+    LOCKED_ENV: locked
+certs:
+  - host: system.example.com
+    skipCertVerify: "true"
+  - host: locked.example.com
+    insecure: "false"
+cli:
+  discoverySources:
+    - oci:
+        name: system-source
+        image: example.com/system:latest
+    - oci:
+        name: locked-source
+        image: example.com/locked:latest
+locked:
+  features:
+    - global.locked-feature
+  envs:
+    - LOCKED_ENV
+  certs:
+    - locked.example.com
+  discoverySources:
+    - locked-source
+`
+
+func setupTestSystemConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "system-config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	t.Setenv(EnvSystemConfigKey, path)
+	return path
+}
+
+func TestSystemConfigMergedBeneathUserConfig(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+
+	// Set user values before the system config locks them
+	require.NoError(t, SetFeature("global", "system-feature", "false"))
+	require.NoError(t, SetFeature("global", "locked-feature", "false"))
+	require.NoError(t, SetEnv("LOCKED_ENV", "user"))
+	require.NoError(t, SetCert(&configtypes.Cert{Host: "locked.example.com", Insecure: "true"}))
+	setupTestSystemConfig(t, testSystemConfig)
+
+	// User values override the system values unless locked
+	enabled, err := IsFeatureEnabled("global", "system-feature")
+	require.NoError(t, err)
+	assert.False(t, enabled)
+	enabled, err = IsFeatureEnabled("global", "locked-feature")
+	require.NoError(t, err)
+	assert.True(t, enabled)
+
+	envs, err := GetAllEnvs()
+	require.NoError(t, err)
+	assert.Equal(t, "system", envs["SYSTEM_ENV"])
+	assert.Equal(t, "locked", envs["LOCKED_ENV"])
+
+	cert, err := GetCert("locked.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "false", cert.Insecure)
+	cert, err = GetCert("system.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "true", cert.SkipCertVerify)
+
+	sources, err := GetCLIDiscoverySources()
+	require.NoError(t, err)
+	require.Len(t, sources, 2)
+	assert.Equal(t, "system-source", sources[0].OCI.Name)
+
+	// The system values are not written to the user config
+	require.NoError(t, SetEnv("USER_ENV", "user"))
+	AcquireTanzuConfigLock()
+	userCfg, err := GetClientConfigNoLock()
+	ReleaseTanzuConfigLock()
+	require.NoError(t, err)
+	assert.NotContains(t, userCfg.ClientOptions.Env, "SYSTEM_ENV")
+	assert.Equal(t, "user", userCfg.ClientOptions.Env["LOCKED_ENV"])
+	assert.Nil(t, userCfg.CoreCliOptions)
+}
+
+func TestSystemConfigLockedValuesRejected(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+	path := setupTestSystemConfig(t, testSystemConfig)
+
+	err := SetFeature("global", "locked-feature", "false")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrLockedBySystemConfig))
+	assert.Equal(t, `cannot modify features "global.locked-feature" set by `+path+`: locked by the system config`, err.Error())
+
+	assert.ErrorIs(t, DeleteFeature("global", "locked-feature"), ErrLockedBySystemConfig)
+	assert.ErrorIs(t, SetEnv("LOCKED_ENV", "user"), ErrLockedBySystemConfig)
+	assert.ErrorIs(t, DeleteEnv("LOCKED_ENV"), ErrLockedBySystemConfig)
+	assert.ErrorIs(t, SetCert(&configtypes.Cert{Host: "locked.example.com"}), ErrLockedBySystemConfig)
+	assert.ErrorIs(t, DeleteCert("locked.example.com"), ErrLockedBySystemConfig)
+	lockedSource := configtypes.PluginDiscovery{OCI: &configtypes.OCIDiscovery{Name: "locked-source", Image: "example.com/user:latest"}}
+	assert.ErrorIs(t, SetCLIDiscoverySource(lockedSource), ErrLockedBySystemConfig)
+	assert.ErrorIs(t, SetCLIDiscoverySources([]configtypes.PluginDiscovery{lockedSource}), ErrLockedBySystemConfig)
+	assert.ErrorIs(t, DeleteCLIDiscoverySource("locked-source"), ErrLockedBySystemConfig)
+	assert.ErrorIs(t, DisableCLIDiscoverySource("locked-source"), ErrLockedBySystemConfig)
+
+	// Values that are not locked can be overridden
+	assert.NoError(t, SetFeature("global", "system-feature", "false"))
+	assert.NoError(t, SetEnv("SYSTEM_ENV", "user"))
+
+	// Locking all the values of a section
+	setupTestSystemConfig(t, "locked:\n  envs:\n    - \"*\"\n")
+	assert.ErrorIs(t, SetEnv("ANY_ENV", "user"), ErrLockedBySystemConfig)
+}
+
+func TestSystemConfigLockedValuesRejectedForContexts(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+	setupTestSystemConfig(t, testSystemConfig)
+	require.NoError(t, SetContext(&configtypes.Context{
+		Name:        "test-k8s",
+		ContextType: configtypes.ContextTypeK8s,
+		ClusterOpts: &configtypes.ClusterServer{Endpoint: "https://k8s.example.com", Path: "kubeconfig", Context: "k8s"},
+	}, false))
+
+	// Context scoped values override the global values, so the locked values cannot be overridden through them
+	assert.ErrorIs(t, SetContextFeature("test-k8s", "global", "locked-feature", "false"), ErrLockedBySystemConfig)
+	assert.ErrorIs(t, SetContextTypeFeature(configtypes.ContextTypeK8s, "global", "locked-feature", "false"), ErrLockedBySystemConfig)
+	assert.ErrorIs(t, SetContextEnv("test-k8s", "LOCKED_ENV", "user"), ErrLockedBySystemConfig)
+	assert.ErrorIs(t, SetContextTypeEnv(configtypes.ContextTypeK8s, "LOCKED_ENV", "user"), ErrLockedBySystemConfig)
+
+	assert.NoError(t, SetContextFeature("test-k8s", "global", "system-feature", "false"))
+	assert.NoError(t, SetContextTypeFeature(configtypes.ContextTypeK8s, "global", "system-feature", "false"))
+	assert.NoError(t, SetContextEnv("test-k8s", "SYSTEM_ENV", "user"))
+	assert.NoError(t, SetContextTypeEnv(configtypes.ContextTypeK8s, "SYSTEM_ENV", "user"))
+}
+
+func TestStoreClientConfigWithSystemConfig(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+	setupTestSystemConfig(t, testSystemConfig)
+	require.NoError(t, SetContext(&configtypes.Context{
+		Name:        "test-k8s",
+		ContextType: configtypes.ContextTypeK8s,
+		ClusterOpts: &configtypes.ClusterServer{Endpoint: "https://k8s.example.com", Path: "kubeconfig", Context: "k8s"},
+	}, false))
+
+	// The values merged from the system config are not stored in the user config
+	cfg, err := GetClientConfig()
+	require.NoError(t, err)
+	require.Equal(t, "system", cfg.ClientOptions.Env["SYSTEM_ENV"])
+	cfg.ClientOptions.Env["USER_ENV"] = "user"
+	require.NoError(t, StoreClientConfig(cfg))
+	userCfg, err := GetClientConfigNoLock()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"USER_ENV": "user"}, userCfg.ClientOptions.Env)
+	assert.Empty(t, userCfg.ClientOptions.Features["global"])
+	// The config passed by the caller is not modified
+	assert.Equal(t, "system", cfg.ClientOptions.Env["SYSTEM_ENV"])
+
+	// Values that are not locked can be overridden
+	cfg.ClientOptions.Env["SYSTEM_ENV"] = "user"
+	require.NoError(t, StoreClientConfig(cfg))
+	env, err := GetEnv("SYSTEM_ENV")
+	require.NoError(t, err)
+	assert.Equal(t, "user", env)
+
+	// Locked values cannot be modified, globally or scoped to a context
+	cfg, err = GetClientConfig()
+	require.NoError(t, err)
+	cfg.ClientOptions.Env["LOCKED_ENV"] = "user"
+	assert.ErrorIs(t, StoreClientConfig(cfg), ErrLockedBySystemConfig)
+	cfg, err = GetClientConfig()
+	require.NoError(t, err)
+	cfg.ClientOptions.Features["global"]["locked-feature"] = "false"
+	assert.ErrorIs(t, StoreClientConfig(cfg), ErrLockedBySystemConfig)
+	cfg, err = GetClientConfig()
+	require.NoError(t, err)
+	cfg.KnownContexts[0].Env = map[string]string{"LOCKED_ENV": "user"}
+	assert.ErrorIs(t, StoreClientConfig(cfg), ErrLockedBySystemConfig)
+	env, err = GetEnv("LOCKED_ENV")
+	require.NoError(t, err)
+	assert.Equal(t, "locked", env)
+}
+
+func TestGetConfigValueOrigin(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+	require.NoError(t, SetEnv("LOCKED_ENV", "user"))
+	require.NoError(t, SetEnv("USER_ENV", "user"))
+	path := setupTestSystemConfig(t, testSystemConfig)
+
+	tests := []struct {
+		section DiffSection
+		key     string
+		origin  *ConfigValueOrigin
+		errStr  string
+	}{
+		{section: DiffSectionEnvs, key: "USER_ENV", origin: &ConfigValueOrigin{Layer: ConfigLayerUser}},
+		{section: DiffSectionEnvs, key: "SYSTEM_ENV", origin: &ConfigValueOrigin{Layer: ConfigLayerSystem, Path: path}},
+		{section: DiffSectionEnvs, key: "LOCKED_ENV", origin: &ConfigValueOrigin{Layer: ConfigLayerSystem, Path: path, Locked: true}},
+		{section: DiffSectionFeatures, key: "global.system-feature", origin: &ConfigValueOrigin{Layer: ConfigLayerSystem, Path: path}},
+		{section: DiffSectionCerts, key: "locked.example.com", origin: &ConfigValueOrigin{Layer: ConfigLayerSystem, Path: path, Locked: true}},
+		{section: DiffSectionDiscoverySources, key: "system-source", origin: &ConfigValueOrigin{Layer: ConfigLayerSystem, Path: path}},
+		{section: DiffSectionEnvs, key: "MISSING_ENV", errStr: `envs "MISSING_ENV" not found`},
+		{section: DiffSectionContexts, key: "ctx", errStr: `unsupported system config section "contexts"`},
+	}
+	for _, tc := range tests {
+		t.Run(string(tc.section)+"/"+tc.key, func(t *testing.T) {
+			origin, err := GetConfigValueOrigin(tc.section, tc.key)
+			if tc.errStr != "" {
+				assert.EqualError(t, err, tc.errStr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.origin, origin)
+		})
+	}
+}
+
+func TestInvalidSystemConfigIgnored(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+	require.NoError(t, SetEnv("USER_ENV", "user"))
+	setupTestSystemConfig(t, "clientOptions: [\n")
+
+	_, err := GetSystemConfig()
+	assert.Error(t, err)
+	envs, err := GetAllEnvs()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"USER_ENV": "user"}, envs)
+	assert.NoError(t, SetEnv("USER_ENV", "updated"))
+}
@@ -0,0 +1,214 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"github.com/pkg/errors"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+const (
+	// TanzuContextMetadataVersionKey is the key to Context's AdditionalMetadata map holding the version of the Tanzu metadata
+	TanzuContextMetadataVersionKey = "tanzuMetadataVersion"
+	// TanzuContextMetadataVersion is the latest version of the Tanzu metadata. It is incremented whenever keys are
+	// added whose absence has to be told apart from an older writer.
+	TanzuContextMetadataVersion = 1
+)
+
+// TanzuContextMetadata is the typed form of the Tanzu metadata stored in the AdditionalMetadata of a Tanzu context
+type TanzuContextMetadata struct {
+	// Version of the Tanzu metadata. Zero if the metadata was written before versioning.
+	Version int
+	// OrgID ID of the Organization
+	OrgID string
+	// OrgName name of the Organization
+	OrgName string
+	// ProjectName name of the Project
+	ProjectName string
+	// ProjectID ID of the Project
+	ProjectID string
+	// SpaceName name of the Space
+	SpaceName string
+	// ClusterGroupName name of the ClusterGroup
+	ClusterGroupName string
+	// FoundationGroupName name of the FoundationGroup
+	FoundationGroupName string
+	// TanzuMissionControlEndpoint is the Tanzu Mission Control endpoint
+	TanzuMissionControlEndpoint string
+	// TanzuHubEndpoint is the Tanzu Hub endpoint
+	TanzuHubEndpoint string
+	// TanzuAuthEndpoint is the endpoint of the IdP the context authenticates with
+	TanzuAuthEndpoint string
+	// TanzuIdpType is the type of the IdP the context authenticates with
+	TanzuIdpType IdpType
+
+	// contextName is the name of the context the metadata was read from, used in errors
+	contextName string
+}
+
+// GetTanzuContextMetadata retrieves the Tanzu metadata of the Tanzu context.
+// Returns an error if a value of the metadata is not of the expected type.
+func GetTanzuContextMetadata(contextName string) (*TanzuContextMetadata, error) {
+	ctx, err := GetContext(contextName)
+	if err != nil {
+		return nil, err
+	}
+	return NewTanzuContextMetadata(ctx)
+}
+
+// NewTanzuContextMetadata returns the Tanzu metadata stored in the AdditionalMetadata of the Tanzu context.
+// Returns an error if a value of the metadata is not of the expected type.
+func NewTanzuContextMetadata(ctx *configtypes.Context) (*TanzuContextMetadata, error) {
+	if ctx == nil {
+		return nil, errors.New("context cannot be nil")
+	}
+	if ctx.ContextType != configtypes.ContextTypeTanzu {
+		return nil, errors.Errorf("context must be of type: %s", configtypes.ContextTypeTanzu)
+	}
+	m := &TanzuContextMetadata{contextName: ctx.Name}
+	version, err := tanzuContextMetadataVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m.Version = version
+	var idpType string
+	for key, field := range m.stringFields(&idpType) {
+		if *field, err = TanzuContextMetadataValue(ctx, key); err != nil {
+			return nil, err
+		}
+	}
+	m.TanzuIdpType = IdpType(idpType)
+	return m, nil
+}
+
+// TanzuContextMetadataValue returns the string value of the key in the AdditionalMetadata of the context,
+// empty if not set. Only the value of the key is validated, so unrelated mistyped keys are ignored.
+// Returns an error if the value is not a string.
+func TanzuContextMetadataValue(ctx *configtypes.Context, key string) (string, error) {
+	if ctx == nil {
+		return "", errors.New("context cannot be nil")
+	}
+	value, ok := ctx.AdditionalMetadata[key]
+	if !ok || value == nil {
+		return "", nil
+	}
+	str, ok := value.(string)
+	if !ok {
+		return "", errors.Errorf("metadata %q of context %q must be a string, not %T", key, ctx.Name, value)
+	}
+	return str, nil
+}
+
+// SetTanzuContextMetadata sets the non-empty fields of the Tanzu metadata in the AdditionalMetadata of the
+// Tanzu context, along with the version. The other keys of the AdditionalMetadata are kept as is.
+func SetTanzuContextMetadata(contextName string, m *TanzuContextMetadata) error {
+	if m == nil {
+		return errors.New("tanzu context metadata cannot be nil")
+	}
+	if m.Version > TanzuContextMetadataVersion {
+		return errors.Errorf("unsupported tanzu context metadata version %d, supported versions are up to %d", m.Version, TanzuContextMetadataVersion)
+	}
+	if m.TanzuIdpType != "" {
+		if err := validateIdpType(m.TanzuIdpType); err != nil {
+			return err
+		}
+	}
+	return UpdateContext(contextName, func(c *configtypes.Context) error {
+		if c.ContextType != configtypes.ContextTypeTanzu {
+			return errors.Errorf("context must be of type: %s", configtypes.ContextTypeTanzu)
+		}
+		if c.AdditionalMetadata == nil {
+			c.AdditionalMetadata = make(map[string]interface{})
+		}
+		idpType := string(m.TanzuIdpType)
+		for key, field := range m.stringFields(&idpType) {
+			if *field != "" {
+				c.AdditionalMetadata[key] = *field
+			}
+		}
+		// The version written by a newer runtime is kept, as it only adds keys
+		if version, err := tanzuContextMetadataVersion(c); err != nil || version < TanzuContextMetadataVersion {
+			c.AdditionalMetadata[TanzuContextMetadataVersionKey] = TanzuContextMetadataVersion
+		}
+		return nil
+	})
+}
+
+// HubEndpoint returns the Tanzu Hub endpoint or an error if it is not configured
+func (m *TanzuContextMetadata) HubEndpoint() (string, error) {
+	if m.TanzuHubEndpoint == "" {
+		return "", errors.Errorf("%q has not been configured for the %q context", TanzuHubEndpointKey, m.contextName)
+	}
+	return m.TanzuHubEndpoint, nil
+}
+
+// AuthEndpoint returns the endpoint of the IdP or an error if it is not configured
+func (m *TanzuContextMetadata) AuthEndpoint() (string, error) {
+	if m.TanzuAuthEndpoint == "" {
+		return "", errors.Errorf("%q has not been configured for the %q context", TanzuAuthEndpointKey, m.contextName)
+	}
+	return m.TanzuAuthEndpoint, nil
+}
+
+// IdpType returns the type of the IdP or an error if it is not configured or not supported
+func (m *TanzuContextMetadata) IdpType() (IdpType, error) {
+	if m.TanzuIdpType == "" {
+		return "", errors.Errorf("%q has not been configured for the %q context", TanzuIdpTypeKey, m.contextName)
+	}
+	if err := validateIdpType(m.TanzuIdpType); err != nil {
+		return "", err
+	}
+	return m.TanzuIdpType, nil
+}
+
+// stringFields returns the string fields of the metadata keyed on their AdditionalMetadata key.
+// The IdP type is mapped to the specified string as it is not of type string.
+func (m *TanzuContextMetadata) stringFields(idpType *string) map[string]*string {
+	return map[string]*string{
+		OrgIDKey:                       &m.OrgID,
+		OrgNameKey:                     &m.OrgName,
+		ProjectNameKey:                 &m.ProjectName,
+		ProjectIDKey:                   &m.ProjectID,
+		SpaceNameKey:                   &m.SpaceName,
+		ClusterGroupNameKey:            &m.ClusterGroupName,
+		FoundationGroupNameKey:         &m.FoundationGroupName,
+		TanzuMissionControlEndpointKey: &m.TanzuMissionControlEndpoint,
+		TanzuHubEndpointKey:            &m.TanzuHubEndpoint,
+		TanzuAuthEndpointKey:           &m.TanzuAuthEndpoint,
+		TanzuIdpTypeKey:                idpType,
+	}
+}
+
+// tanzuContextMetadataVersion returns the version of the Tanzu metadata of the context, zero if not set.
+// A version newer than TanzuContextMetadataVersion is returned as is, as the keys known to this version are still valid.
+func tanzuContextMetadataVersion(ctx *configtypes.Context) (int, error) {
+	value, ok := ctx.AdditionalMetadata[TanzuContextMetadataVersionKey]
+	if !ok || value == nil {
+		return 0, nil
+	}
+	var version int
+	switch v := value.(type) {
+	case int:
+		version = v
+	case int64:
+		version = int(v)
+	case float64:
+		if v != float64(int(v)) {
+			return 0, errors.Errorf("metadata %q of context %q must be an integer, not %v", TanzuContextMetadataVersionKey, ctx.Name, v)
+		}
+		version = int(v)
+	default:
+		return 0, errors.Errorf("metadata %q of context %q must be an integer, not %T", TanzuContextMetadataVersionKey, ctx.Name, value)
+	}
+	return version, nil
+}
+
+func validateIdpType(idpType IdpType) error {
+	switch idpType {
+	case UAAIdpType, CSPIdpType:
+		return nil
+	}
+	return errors.Errorf("unsupported IdP type %q, supported types are %q and %q", idpType, UAAIdpType, CSPIdpType)
+}
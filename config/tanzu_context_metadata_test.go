@@ -0,0 +1,139 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+func TestTanzuContextMetadata(t *testing.T) {
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+	defer cleanUp()
+	require.NoError(t, SetContext(&configtypes.Context{
+		Name:        "tanzu",
+		ContextType: configtypes.ContextTypeTanzu,
+		GlobalOpts:  &configtypes.GlobalServer{Endpoint: "https://api.tanzu.example.com"},
+		AdditionalMetadata: map[string]interface{}{
+			OrgIDKey: "org-id",
+			"custom": "value",
+		},
+	}, false))
+
+	// Metadata written before versioning
+	m, err := GetTanzuContextMetadata("tanzu")
+	require.NoError(t, err)
+	assert.Equal(t, 0, m.Version)
+	assert.Equal(t, "org-id", m.OrgID)
+	_, err = m.HubEndpoint()
+	assert.EqualError(t, err, `"tanzuHubEndpoint" has not been configured for the "tanzu" context`)
+	_, err = m.AuthEndpoint()
+	assert.EqualError(t, err, `"tanzuAuthEndpoint" has not been configured for the "tanzu" context`)
+	_, err = m.IdpType()
+	assert.EqualError(t, err, `"tanzuIdpType" has not been configured for the "tanzu" context`)
+
+	require.NoError(t, SetTanzuContextMetadata("tanzu", &TanzuContextMetadata{
+		ProjectName:       "project",
+		TanzuHubEndpoint:  "https://hub.example.com",
+		TanzuAuthEndpoint: "https://auth.example.com",
+		TanzuIdpType:      CSPIdpType,
+	}))
+	m, err = GetTanzuContextMetadata("tanzu")
+	require.NoError(t, err)
+	assert.Equal(t, TanzuContextMetadataVersion, m.Version)
+	assert.Equal(t, "org-id", m.OrgID)
+	assert.Equal(t, "project", m.ProjectName)
+	endpoint, err := m.HubEndpoint()
+	require.NoError(t, err)
+	assert.Equal(t, "https://hub.example.com", endpoint)
+	endpoint, err = m.AuthEndpoint()
+	require.NoError(t, err)
+	assert.Equal(t, "https://auth.example.com", endpoint)
+	idpType, err := m.IdpType()
+	require.NoError(t, err)
+	assert.Equal(t, CSPIdpType, idpType)
+
+	// Keys other than the Tanzu metadata are kept
+	ctx, err := GetContext("tanzu")
+	require.NoError(t, err)
+	assert.Equal(t, "value", ctx.AdditionalMetadata["custom"])
+
+	assert.EqualError(t, SetTanzuContextMetadata("tanzu", &TanzuContextMetadata{TanzuIdpType: "ldap"}),
+		`unsupported IdP type "ldap", supported types are "uaa" and "csp"`)
+	assert.EqualError(t, SetTanzuContextMetadata("tanzu", &TanzuContextMetadata{Version: TanzuContextMetadataVersion + 1}),
+		"unsupported tanzu context metadata version 2, supported versions are up to 1")
+}
+
+func TestNewTanzuContextMetadataInvalidValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      *configtypes.Context
+		errStr   string
+		expected *TanzuContextMetadata
+	}{
+		{
+			name:   "non-string hub endpoint",
+			ctx:    &configtypes.Context{Name: "tanzu", ContextType: configtypes.ContextTypeTanzu, AdditionalMetadata: map[string]interface{}{TanzuHubEndpointKey: 42}},
+			errStr: `metadata "tanzuHubEndpoint" of context "tanzu" must be a string, not int`,
+		},
+		{
+			name:   "non-integer version",
+			ctx:    &configtypes.Context{Name: "tanzu", ContextType: configtypes.ContextTypeTanzu, AdditionalMetadata: map[string]interface{}{TanzuContextMetadataVersionKey: "one"}},
+			errStr: `metadata "tanzuMetadataVersion" of context "tanzu" must be an integer, not string`,
+		},
+		{
+			name:   "not a tanzu context",
+			ctx:    &configtypes.Context{Name: "k8s", ContextType: configtypes.ContextTypeK8s},
+			errStr: "context must be of type: tanzu",
+		},
+		{
+			name:     "newer version",
+			ctx:      &configtypes.Context{Name: "tanzu", ContextType: configtypes.ContextTypeTanzu, AdditionalMetadata: map[string]interface{}{TanzuContextMetadataVersionKey: float64(2), OrgIDKey: "org"}},
+			expected: &TanzuContextMetadata{Version: 2, OrgID: "org", contextName: "tanzu"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m, err := NewTanzuContextMetadata(tc.ctx)
+			if tc.errStr != "" {
+				assert.EqualError(t, err, tc.errStr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, m)
+		})
+	}
+
+	m := &TanzuContextMetadata{TanzuIdpType: "ldap", contextName: "tanzu"}
+	_, err := m.IdpType()
+	assert.EqualError(t, err, `unsupported IdP type "ldap", supported types are "uaa" and "csp"`)
+}
+
+func TestTanzuContextMetadataValue(t *testing.T) {
+	ctx := &configtypes.Context{
+		Name:        "tanzu",
+		ContextType: configtypes.ContextTypeTanzu,
+		AdditionalMetadata: map[string]interface{}{
+			TanzuHubEndpointKey: "https://hub.example.com",
+			OrgIDKey:            42,
+		},
+	}
+	// Unrelated mistyped keys do not prevent reading a key
+	value, err := TanzuContextMetadataValue(ctx, TanzuHubEndpointKey)
+	require.NoError(t, err)
+	assert.Equal(t, "https://hub.example.com", value)
+
+	value, err = TanzuContextMetadataValue(ctx, ProjectNameKey)
+	require.NoError(t, err)
+	assert.Empty(t, value)
+
+	_, err = TanzuContextMetadataValue(ctx, OrgIDKey)
+	assert.EqualError(t, err, `metadata "tanzuOrgID" of context "tanzu" must be a string, not int`)
+	_, err = TanzuContextMetadataValue(nil, OrgIDKey)
+	assert.Error(t, err)
+}
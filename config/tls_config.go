@@ -0,0 +1,102 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+// TLSConfigForHost returns the TLS configuration to use when communicating with the specified host, host:port or URI.
+// The TLS configuration is constructed from the cert configuration matching the host (see GetCert):
+//   - CACertData is added to the system cert pool and used as RootCAs
+//   - SkipCertVerify is honoured only if CACertData is not configured
+//   - ClientCertData and ClientKeyData are used as the client certificate for mutual TLS
+//
+// If no cert configuration matches the host, a TLS configuration that uses the system cert pool is returned.
+func TLSConfigForHost(host string) (*tls.Config, error) {
+	if host == "" {
+		return nil, errors.New("host is empty")
+	}
+	cert, err := getMatchingCert(host)
+	if err != nil {
+		return nil, err
+	}
+	return TLSConfigForCert(cert)
+}
+
+// TLSConfigForCert returns the TLS configuration constructed from the specified cert configuration
+func TLSConfigForCert(cert *configtypes.Cert) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if cert == nil {
+		return tlsConfig, nil
+	}
+
+	if cert.CACertData != "" {
+		decodedCACertData, err := base64.StdEncoding.DecodeString(cert.CACertData)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to decode the CA certificate data for %q", cert.Host)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if ok := pool.AppendCertsFromPEM(decodedCACertData); !ok {
+			return nil, errors.Errorf("unable to parse the CA certificate data for %q", cert.Host)
+		}
+		tlsConfig.RootCAs = pool
+	} else if skipCertVerify, _ := strconv.ParseBool(cert.SkipCertVerify); skipCertVerify {
+		// skipCertVerify: true is only possible if the user has explicitly enabled it
+		tlsConfig.InsecureSkipVerify = true //nolint:gosec
+	}
+
+	if cert.ClientCertData != "" || cert.ClientKeyData != "" {
+		clientCert, err := getClientCertificate(cert)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+	return tlsConfig, nil
+}
+
+// getClientCertificate returns the client certificate for mutual TLS from the cert configuration
+func getClientCertificate(cert *configtypes.Cert) (tls.Certificate, error) {
+	if cert.ClientCertData == "" || cert.ClientKeyData == "" {
+		return tls.Certificate{}, errors.Errorf("both client certificate and client key must be configured for %q", cert.Host)
+	}
+	certPEM, err := getPEMData(cert.ClientCertData)
+	if err != nil {
+		return tls.Certificate{}, errors.Wrapf(err, "unable to get the client certificate data for %q", cert.Host)
+	}
+	keyPEM, err := getPEMData(cert.ClientKeyData)
+	if err != nil {
+		return tls.Certificate{}, errors.Wrapf(err, "unable to get the client key data for %q", cert.Host)
+	}
+	clientCert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return tls.Certificate{}, errors.Wrapf(err, "unable to load the client certificate for %q", cert.Host)
+	}
+	return clientCert, nil
+}
+
+// getPEMData returns the PEM data of the value that is either a base64 encoded PEM
+// or a reference to a secret in the secret store holding the PEM data
+func getPEMData(value string) ([]byte, error) {
+	if IsSecretRef(value) {
+		secret, err := GetSecret(strings.TrimSuffix(strings.TrimPrefix(value, "${"+SecretRefPrefix), "}"))
+		if err != nil {
+			return nil, err
+		}
+		return []byte(secret), nil
+	}
+	return base64.StdEncoding.DecodeString(value)
+}
@@ -0,0 +1,169 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+)
+
+// generateTestClientCert generates a self-signed client certificate and returns the PEM encoded certificate and key
+func generateTestClientCert(t *testing.T) (certPEM, keyPEM []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestTLSConfigForHost(t *testing.T) {
+	// Setup config data
+	_, cleanUp := setupTestConfig(t, &CfgTestData{})
+
+	defer func() {
+		cleanUp()
+	}()
+
+	clientCertPEM, clientKeyPEM := generateTestClientCert(t)
+	clientCertPool := x509.NewCertPool()
+	assert.True(t, clientCertPool.AppendCertsFromPEM(clientCertPEM))
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  clientCertPool,
+		MinVersion: tls.VersionTLS12,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	get := func(tlsConfig *tls.Config) error {
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+		resp, err := client.Get(server.URL)
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	// No cert configuration for the host
+	tlsConfig, err := TLSConfigForHost(server.URL)
+	assert.NoError(t, err)
+	assert.Nil(t, tlsConfig.RootCAs)
+	assert.Error(t, get(tlsConfig))
+
+	// CA cert configured but client certificate is missing
+	caCertData := base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	assert.NoError(t, SetCert(&configtypes.Cert{Host: "127.0.0.0/8", CACertData: caCertData}))
+	tlsConfig, err = TLSConfigForHost(server.URL)
+	assert.NoError(t, err)
+	assert.NotNil(t, tlsConfig.RootCAs)
+	assert.Error(t, get(tlsConfig))
+
+	// Client certificate configured inline
+	assert.NoError(t, SetCert(&configtypes.Cert{
+		Host:           "127.0.0.0/8",
+		CACertData:     caCertData,
+		ClientCertData: base64.StdEncoding.EncodeToString(clientCertPEM),
+		ClientKeyData:  base64.StdEncoding.EncodeToString(clientKeyPEM),
+	}))
+	tlsConfig, err = TLSConfigForHost(server.URL)
+	assert.NoError(t, err)
+	assert.Len(t, tlsConfig.Certificates, 1)
+	assert.NoError(t, get(tlsConfig))
+
+	// Client certificate referenced from the secret store, host:port entry takes precedence over CIDR entry
+	SetSecretStore(fakeSecretStore{"client-cert": string(clientCertPEM), "client-key": string(clientKeyPEM)})
+	defer SetSecretStore(nil)
+	assert.NoError(t, SetCert(&configtypes.Cert{
+		Host:           server.Listener.Addr().String(),
+		CACertData:     caCertData,
+		ClientCertData: "${secret:client-cert}",
+		ClientKeyData:  "${secret:client-key}",
+	}))
+	cert, err := GetCert(server.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, server.Listener.Addr().String(), cert.Host)
+	tlsConfig, err = TLSConfigForHost(server.URL)
+	assert.NoError(t, err)
+	assert.NoError(t, get(tlsConfig))
+}
+
+func TestTLSConfigForCert(t *testing.T) {
+	tests := []struct {
+		name               string
+		cert               *configtypes.Cert
+		insecureSkipVerify bool
+		errStr             string
+	}{
+		{
+			name: "nil cert",
+		},
+		{
+			name:               "skip cert verify",
+			cert:               &configtypes.Cert{Host: "test", SkipCertVerify: "true"},
+			insecureSkipVerify: true,
+		},
+		{
+			name:   "invalid CA cert data",
+			cert:   &configtypes.Cert{Host: "test", CACertData: "invalid"},
+			errStr: "unable to decode the CA certificate data for \"test\"",
+		},
+		{
+			name:   "invalid CA cert PEM",
+			cert:   &configtypes.Cert{Host: "test", CACertData: base64.StdEncoding.EncodeToString([]byte("invalid"))},
+			errStr: "unable to parse the CA certificate data for \"test\"",
+		},
+		{
+			name:   "client cert without key",
+			cert:   &configtypes.Cert{Host: "test", ClientCertData: "data"},
+			errStr: "both client certificate and client key must be configured for \"test\"",
+		},
+		{
+			name:   "client cert secret without secret store",
+			cert:   &configtypes.Cert{Host: "test", ClientCertData: "${secret:cert}", ClientKeyData: "${secret:key}"},
+			errStr: "unable to get the client certificate data for \"test\"",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tlsConfig, err := TLSConfigForCert(tc.cert)
+			if tc.errStr != "" {
+				assert.ErrorContains(t, err, tc.errStr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.insecureSkipVerify, tlsConfig.InsecureSkipVerify)
+			assert.Equal(t, uint16(tls.VersionTLS12), tlsConfig.MinVersion)
+		})
+	}
+}
@@ -44,3 +44,26 @@ func (c *ClientConfig) IsConfigFeatureActivated(featurePath string) (bool, error
 	}
 	return booleanValue, nil
 }
+
+// GetFeatureForContext returns the value of the plugin feature flag applicable to the specified context.
+// Feature flags set on the context take precedence over feature flags set for the context type,
+// which in turn take precedence over the global feature flags.
+// The boolean return value reports whether the feature flag is set at any level.
+func (c *ClientConfig) GetFeatureForContext(plugin, feature string, ctx *Context) (string, bool) {
+	if ctx != nil {
+		if val, ok := ctx.Features[plugin][feature]; ok {
+			return val, true
+		}
+		if c.ClientOptions != nil {
+			if val, ok := c.ClientOptions.ContextTypeFeatures[ctx.ContextType][plugin][feature]; ok {
+				return val, true
+			}
+		}
+	}
+	if c.ClientOptions != nil {
+		if val, ok := c.ClientOptions.Features[plugin][feature]; ok {
+			return val, true
+		}
+	}
+	return "", false
+}
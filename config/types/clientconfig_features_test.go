@@ -135,3 +135,39 @@ func TestIsConfigFeatureActivated(t *testing.T) {
 		})
 	}
 }
+
+func TestGetFeatureForContext(t *testing.T) {
+	clientConfig := &ClientConfig{
+		ClientOptions: &ClientOptions{
+			Features: map[string]FeatureMap{"plugin1": {"feature1": "false", "feature2": "false"}},
+			ContextTypeFeatures: map[ContextType]map[string]FeatureMap{
+				ContextTypeTanzu: {"plugin1": {"feature1": "true", "feature3": "true"}},
+			},
+		},
+	}
+	tanzuCtx := &Context{Name: "tanzu", ContextType: ContextTypeTanzu, Features: map[string]FeatureMap{"plugin1": {"feature3": "false"}}}
+	k8sCtx := &Context{Name: "k8s", ContextType: ContextTypeK8s}
+
+	testCases := []struct {
+		name        string
+		feature     string
+		ctx         *Context
+		expectValue string
+		expectFound bool
+	}{
+		{name: "ContextTypeOverridesGlobal", feature: "feature1", ctx: tanzuCtx, expectValue: "true", expectFound: true},
+		{name: "GlobalForOtherContextType", feature: "feature1", ctx: k8sCtx, expectValue: "false", expectFound: true},
+		{name: "ContextOverridesContextType", feature: "feature3", ctx: tanzuCtx, expectValue: "false", expectFound: true},
+		{name: "GlobalFallback", feature: "feature2", ctx: tanzuCtx, expectValue: "false", expectFound: true},
+		{name: "NilContext", feature: "feature1", ctx: nil, expectValue: "false", expectFound: true},
+		{name: "NotFound", feature: "feature4", ctx: tanzuCtx, expectValue: "", expectFound: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			val, found := clientConfig.GetFeatureForContext("plugin1", tc.feature, tc.ctx)
+			assert.Equal(t, tc.expectFound, found)
+			assert.Equal(t, tc.expectValue, val)
+		})
+	}
+}
@@ -73,6 +73,14 @@ type Context struct {
 	// AdditionalMetadata to provide any additional data that is respective to each context
 	AdditionalMetadata map[string]interface{} `json:"additionalMetadata,omitempty" yaml:"additionalMetadata,omitempty"`
 
+	// Features are the plugin feature flags scoped to this context.
+	// These take precedence over the context type and global feature flags.
+	Features map[string]FeatureMap `json:"features,omitempty" yaml:"features,omitempty"`
+
+	// Env are the environment variables scoped to this context.
+	// These are merged over the context type and global environment variables when the context is active.
+	Env map[string]string `json:"env,omitempty" yaml:"env,omitempty"`
+
 	// DiscoverySources determines from where to discover plugins
 	// associated with this context.
 	// Deprecated: This field is deprecated.  It is currently no used.
@@ -153,6 +161,12 @@ type ClientOptions struct {
 	CLI      *CLIOptions           `json:"cli,omitempty" yaml:"cli,omitempty"`
 	Features map[string]FeatureMap `json:"features,omitempty" yaml:"features,omitempty"`
 	Env      map[string]string     `json:"env,omitempty" yaml:"env,omitempty"`
+	// ContextTypeFeatures are the plugin feature flags scoped to a context type.
+	// These take precedence over the global feature flags.
+	ContextTypeFeatures map[ContextType]map[string]FeatureMap `json:"contextTypeFeatures,omitempty" yaml:"contextTypeFeatures,omitempty"`
+	// ContextTypeEnv are the environment variables scoped to a context type.
+	// These are merged over the global environment variables when a context of the type is active.
+	ContextTypeEnv map[ContextType]EnvMap `json:"contextTypeEnv,omitempty" yaml:"contextTypeEnv,omitempty"`
 }
 
 // FeatureMap is simply a hash table, but needs an explicit type to be an object in another hash map (cf ClientOptions.Features)
@@ -208,6 +222,11 @@ type PluginDiscovery struct {
 	Kubernetes *KubernetesDiscovery `json:"k8s,omitempty" yaml:"k8s,omitempty"`
 	// LocalDiscovery is set if the plugins are to be discovered via Local Manifest fast.
 	Local *LocalDiscovery `json:"local,omitempty" yaml:"local,omitempty"`
+	// Priority of the discovery source. Discovery sources with a higher priority are ordered first
+	// in the effective discovery sources. Defaults to 0.
+	Priority int `json:"priority,omitempty" yaml:"priority,omitempty"`
+	// Disabled is set if the discovery source should not be used for plugin discovery.
+	Disabled bool `json:"disabled,omitempty" yaml:"disabled,omitempty"`
 }
 
 // GCPDiscovery provides a plugin discovery mechanism via a Google Cloud Storage
@@ -284,6 +303,12 @@ type LocalDiscovery struct {
 type PluginRepository struct {
 	// GCPPluginRepository is a plugin repository that utilizes GCP cloud storage.
 	GCPPluginRepository *GCPPluginRepository `json:"gcpPluginRepository,omitempty" yaml:"gcpPluginRepository,omitempty"`
+	// OCIPluginRepository is a plugin repository that utilizes an OCI image registry.
+	OCIPluginRepository *OCIPluginRepository `json:"ociPluginRepository,omitempty" yaml:"ociPluginRepository,omitempty"`
+	// LocalPluginRepository is a plugin repository that utilizes the local filesystem.
+	LocalPluginRepository *LocalPluginRepository `json:"localPluginRepository,omitempty" yaml:"localPluginRepository,omitempty"`
+	// HTTPPluginRepository is a plugin repository that utilizes a generic HTTP server.
+	HTTPPluginRepository *HTTPPluginRepository `json:"httpPluginRepository,omitempty" yaml:"httpPluginRepository,omitempty"`
 }
 
 // GCPPluginRepository is a plugin repository that utilizes GCP cloud storage.
@@ -300,6 +325,35 @@ type GCPPluginRepository struct {
 	RootPath string `json:"rootPath,omitempty" yaml:"rootPath,omitempty"`
 }
 
+// OCIPluginRepository is a plugin repository that utilizes an OCI image registry.
+type OCIPluginRepository struct {
+	// Name of the repository.
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+
+	// Image is an OCI compliant image reference of the repository.
+	// E.g., harbor.my-domain.local/tanzu-cli/plugins:latest
+	Image string `json:"image,omitempty" yaml:"image,omitempty"`
+}
+
+// LocalPluginRepository is a plugin repository that utilizes the local filesystem.
+type LocalPluginRepository struct {
+	// Name of the repository.
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+
+	// Path is a local path pointing to the root directory of the repository.
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+}
+
+// HTTPPluginRepository is a plugin repository that utilizes a generic HTTP server.
+type HTTPPluginRepository struct {
+	// Name of the repository.
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+
+	// URL is the http or https URL of the root of the repository.
+	// E.g., https://plugins.my-domain.local/tanzu-cli
+	URL string `json:"url,omitempty" yaml:"url,omitempty"`
+}
+
 // CoreCliOptions are core CLI specific options that are specific to CLI(not for plugins) like ceipOptIn, etc
 // that goes into nextgen configuration file.
 type CoreCliOptions struct {
@@ -328,7 +382,8 @@ type TelemetryOptions struct {
 
 // Cert provides a certificate configuration for an endpoint
 type Cert struct {
-	// Host is the host(or ipaddress) or host:port for which the certificate configuration is applicable
+	// Host is the host(or ipaddress) or host:port for which the certificate configuration is applicable.
+	// It can also be a wildcard host (e.g., *.example.com or *.example.com:443) or a CIDR range (e.g., 10.0.0.0/8).
 	Host string `json:"host,omitempty" yaml:"host,omitempty"`
 	// CACertData is the CA certificate for the host
 	CACertData string `json:"caCertData,omitempty" yaml:"caCertData,omitempty"`
@@ -336,6 +391,12 @@ type Cert struct {
 	Insecure string `json:"insecure,omitempty" yaml:"insecure,omitempty"`
 	// SkipCertVerify is to skip certificate validation
 	SkipCertVerify string `json:"skipCertVerify,omitempty" yaml:"skipCertVerify,omitempty"`
+	// ClientCertData is the base64 encoded PEM client certificate used for mutual TLS with the host,
+	// or a reference to a secret in the secret store holding the PEM client certificate (e.g., ${secret:my-client-cert})
+	ClientCertData string `json:"clientCertData,omitempty" yaml:"clientCertData,omitempty"`
+	// ClientKeyData is the base64 encoded PEM client key used for mutual TLS with the host,
+	// or a reference to a secret in the secret store holding the PEM client key (e.g., ${secret:my-client-key})
+	ClientKeyData string `json:"clientKeyData,omitempty" yaml:"clientKeyData,omitempty"`
 }
 
 // ClientConfig is the Schema for the configs API
@@ -365,6 +426,21 @@ type ClientConfig struct {
 
 	// Certs is the collection of host, and its certificate data used to communicate with the host
 	Certs []*Cert `json:"certs,omitempty" yaml:"certs,omitempty"`
+
+	// ContextSets are the named groups of contexts that are activated together.
+	ContextSets []*ContextSet `json:"contextSets,omitempty" yaml:"contextSets,omitempty"`
+
+	// CurrentContextSet is the name of the context set activated last.
+	CurrentContextSet string `json:"currentContextSet,omitempty" yaml:"currentContextSet,omitempty"`
+}
+
+// ContextSet is a named group of contexts, at most one per context type, that are activated together.
+type ContextSet struct {
+	// Name of the context set.
+	Name string `json:"name" yaml:"name"`
+
+	// Contexts of the set keyed on their context type.
+	Contexts map[ContextType]string `json:"contexts" yaml:"contexts"`
 }
 
 // ClientConfigList contains a list of ClientConfig
@@ -15,4 +15,6 @@ type ConfigMetadata struct {
 	PatchStrategy map[string]string `json:"patchStrategy,omitempty" yaml:"patchStrategy,omitempty" mapstructure:"patchStrategy,omitempty"`
 	// Settings related to config
 	Settings map[string]string `json:"settings,omitempty" yaml:"settings,omitempty" mapstructure:"settings,omitempty"`
+	// Revision of the config, incremented on every write of the config
+	Revision int64 `json:"revision,omitempty" yaml:"revision,omitempty" mapstructure:"revision,omitempty"`
 }
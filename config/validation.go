@@ -0,0 +1,69 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ociImageRefRegexp matches an OCI image reference of the form [registry[:port]/]repository[:tag][@digest]
+var ociImageRefRegexp = regexp.MustCompile(`^` +
+	// optional registry with optional port
+	`(?:(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9])(?:\.(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9]))*(?::[0-9]+)?/)?` +
+	// repository path components
+	`[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*` +
+	// optional tag
+	`(?::[\w][\w.-]{0,127})?` +
+	// optional digest
+	`(?:@[A-Za-z][A-Za-z0-9]*(?:[-_+.][A-Za-z][A-Za-z0-9]*)*:[0-9a-fA-F]{32,})?` +
+	`$`)
+
+// validateOCIImageRef validates the OCI image reference
+func validateOCIImageRef(image string) error {
+	if image == "" {
+		return errors.New("image cannot be empty")
+	}
+	if !ociImageRefRegexp.MatchString(image) {
+		return errors.Errorf("invalid OCI image reference %q", image)
+	}
+	return nil
+}
+
+// validateHTTPURL validates the url is an absolute http or https URL
+func validateHTTPURL(rawURL string) error {
+	if rawURL == "" {
+		return errors.New("url cannot be empty")
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return errors.Wrapf(err, "invalid url %q", rawURL)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.Errorf("invalid url %q: must be an absolute http or https url", rawURL)
+	}
+	return nil
+}
+
+// validateRESTEndpoint validates the REST endpoint is either a host with an optional port and path
+// or an absolute http or https URL
+func validateRESTEndpoint(endpoint string) error {
+	if endpoint == "" {
+		return errors.New("endpoint cannot be empty")
+	}
+	if strings.Contains(endpoint, "://") {
+		return validateHTTPURL(endpoint)
+	}
+	if strings.ContainsAny(endpoint, " \t\n") {
+		return errors.Errorf("invalid endpoint %q", endpoint)
+	}
+	u, err := url.Parse("https://" + endpoint)
+	if err != nil || u.Hostname() == "" {
+		return errors.Errorf("invalid endpoint %q", endpoint)
+	}
+	return nil
+}
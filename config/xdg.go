@@ -0,0 +1,275 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/config/collectionutils"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/log"
+)
+
+const (
+	// EnvXDGLayoutKey is the environment variable that, when set to true, stores the config, cache and
+	// state of the CLI in the XDG base directories
+	EnvXDGLayoutKey = "TANZU_XDG_LAYOUT"
+	// EnvXDGConfigHomeKey is the XDG base directory of the config
+	EnvXDGConfigHomeKey = "XDG_CONFIG_HOME"
+	// EnvXDGCacheHomeKey is the XDG base directory of the cache
+	EnvXDGCacheHomeKey = "XDG_CACHE_HOME"
+	// EnvXDGStateHomeKey is the XDG base directory of the state and logs
+	EnvXDGStateHomeKey = "XDG_STATE_HOME"
+)
+
+var (
+	// XDGDirName is the name of the tanzu directory within the XDG base directories
+	XDGDirName = "tanzu"
+	// CacheDirName is the name of the directory within LocalDir holding the cache without the XDG layout
+	CacheDirName = "cache"
+	// StateDirName is the name of the directory within LocalDir holding the state without the XDG layout
+	StateDirName = "state"
+
+	// xdgCopiedFileNames are the config files read by plugins built with a runtime predating the XDG layout,
+	// which are copied rather than moved by MigrateToXDGLayout
+	xdgCopiedFileNames = []string{ConfigName, CfgNextGenName, CfgMetadataName}
+
+	// xdgDefaultDirs are the base directories relative to the home directory used if the XDG variable is not set
+	xdgDefaultDirs = map[string]string{
+		EnvXDGConfigHomeKey: ".config",
+		EnvXDGCacheHomeKey:  ".cache",
+		EnvXDGStateHomeKey:  filepath.Join(".local", "state"),
+	}
+)
+
+// XDGMigratedPath is a file or directory moved or copied by MigrateToXDGLayout
+type XDGMigratedPath struct {
+	// From is the path in LocalDir before the XDG layout
+	From string `json:"from" yaml:"from"`
+	// To is the path in the XDG base directory
+	To string `json:"to" yaml:"to"`
+	// Copied indicates the file was copied and left in place for the plugins reading it
+	Copied bool `json:"copied,omitempty" yaml:"copied,omitempty"`
+}
+
+// UseXDGLayout returns true if the XDG layout is enabled with TANZU_XDG_LAYOUT
+func UseXDGLayout() bool {
+	return strings.EqualFold(os.Getenv(EnvXDGLayoutKey), "true")
+}
+
+// CacheDir returns the directory in which the plugin can cache data, creating it if required.
+// It is the plugin directory within XDG_CACHE_HOME/tanzu with the XDG layout, within LocalDir/cache otherwise.
+func CacheDir(plugin string) (string, error) {
+	base, err := cacheBaseDir()
+	if err != nil {
+		return "", err
+	}
+	return pluginDir(base, plugin)
+}
+
+// StateDir returns the directory in which the plugin can store state and logs, creating it if required.
+// It is the plugin directory within XDG_STATE_HOME/tanzu with the XDG layout, within LocalDir/state otherwise.
+func StateDir(plugin string) (string, error) {
+	base, err := stateBaseDir()
+	if err != nil {
+		return "", err
+	}
+	return pluginDir(base, plugin)
+}
+
+// MigrateToXDGLayout moves the files of LocalDir before the XDG layout to the XDG base directories: the config
+// files and plugin owned directories to XDG_CONFIG_HOME/tanzu, the audit trail and the plugin state directories
+// to XDG_STATE_HOME/tanzu and the plugin cache directories to XDG_CACHE_HOME/tanzu.
+// The config files are copied rather than moved, as plugins built with an older runtime keep reading them
+// from the original location. The copies left there are no longer updated, which is reported with a warning.
+// Files that already exist in the XDG base directories and lock files are left in place, so the migration
+// can be run again. It must not be run while other tanzu processes are running.
+func MigrateToXDGLayout() ([]XDGMigratedPath, error) {
+	if !UseXDGLayout() {
+		return nil, errors.Errorf("the XDG layout is not enabled, set %s=true to enable it", EnvXDGLayoutKey)
+	}
+	if err := checkReadOnly("the XDG layout"); err != nil {
+		return nil, err
+	}
+	src, err := localDirPath(LocalDirName)
+	if err != nil {
+		return nil, err
+	}
+	configDir, err := LocalDir()
+	if err != nil {
+		return nil, err
+	}
+	cacheDir, err := cacheBaseDir()
+	if err != nil {
+		return nil, err
+	}
+	stateDir, err := stateBaseDir()
+	if err != nil {
+		return nil, err
+	}
+
+	migrated := make([]XDGMigratedPath, 0)
+	err = migrateToXDGDir(src, func(entry os.DirEntry) string {
+		switch {
+		case entry.IsDir() && (entry.Name() == CacheDirName || entry.Name() == StateDirName):
+			return ""
+		case strings.HasPrefix(entry.Name(), ConfigAuditName):
+			return stateDir
+		}
+		return configDir
+	}, &migrated)
+	if err != nil {
+		return migrated, err
+	}
+	// The plugin directories of the cache and state are moved to their base directory rather than within the config
+	for name, dstDir := range map[string]string{CacheDirName: cacheDir, StateDirName: stateDir} {
+		dir := filepath.Join(src, name)
+		if err := migrateToXDGDir(dir, func(os.DirEntry) string { return dstDir }, &migrated); err != nil {
+			return migrated, err
+		}
+		// The emptied directory is removed, so that it is not moved to the config dir on the next migration
+		_ = os.Remove(dir)
+	}
+	for _, path := range migrated {
+		if path.Copied {
+			log.Warningf("%s is no longer updated, plugins built with a runtime predating the XDG layout keep reading it and do not see the config changes made in %s", path.From, path.To)
+		}
+	}
+	return migrated, nil
+}
+
+// migrateToXDGDir moves or copies the entries of the directory to the XDG base directory returned by dstDir,
+// skipping the entries for which it returns an empty directory
+func migrateToXDGDir(src string, dstDir func(entry os.DirEntry) string, migrated *[]XDGMigratedPath) error {
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to read %s", src)
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasSuffix(name, ".lock") {
+			continue
+		}
+		dst := dstDir(entry)
+		if dst == "" {
+			continue
+		}
+		from, to := filepath.Join(src, name), filepath.Join(dst, name)
+		if filepath.Clean(from) == filepath.Clean(to) {
+			continue
+		}
+		exists, err := fileExists(to)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		if !entry.IsDir() && collectionutils.Contains(xdgCopiedFileNames, name) {
+			if err := copyPath(from, to); err != nil {
+				return errors.Wrapf(err, "failed to copy %s to %s", from, to)
+			}
+			*migrated = append(*migrated, XDGMigratedPath{From: from, To: to, Copied: true})
+			continue
+		}
+		if err := movePath(from, to, entry.IsDir()); err != nil {
+			return errors.Wrapf(err, "failed to move %s to %s", from, to)
+		}
+		*migrated = append(*migrated, XDGMigratedPath{From: from, To: to})
+	}
+	return nil
+}
+
+// xdgBaseDir returns the tanzu directory within the XDG base directory of the environment variable,
+// or false if the variable is not set to an absolute path as required by the XDG specification
+func xdgBaseDir(envKey string) (string, bool) {
+	dir := os.Getenv(envKey)
+	if dir == "" || !filepath.IsAbs(dir) {
+		return "", false
+	}
+	return filepath.Join(dir, XDGDirName), true
+}
+
+// xdgBaseDirOrDefault returns the tanzu directory within the XDG base directory of the environment variable,
+// falling back to the default base directory within the home directory
+func xdgBaseDirOrDefault(envKey string) (string, error) {
+	if dir, ok := xdgBaseDir(envKey); ok {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", errors.Wrap(err, "could not locate the home dir")
+	}
+	return filepath.Join(home, xdgDefaultDirs[envKey], XDGDirName), nil
+}
+
+// cacheBaseDir returns the directory holding the cache of the CLI and plugins
+func cacheBaseDir() (string, error) {
+	if UseXDGLayout() {
+		return xdgBaseDirOrDefault(EnvXDGCacheHomeKey)
+	}
+	localDir, err := LocalDir()
+	if err != nil {
+		return "", errors.Wrap(err, "could not find local tanzu dir for OS")
+	}
+	return filepath.Join(localDir, CacheDirName), nil
+}
+
+// stateBaseDir returns the directory holding the state and logs of the CLI and plugins
+func stateBaseDir() (string, error) {
+	if UseXDGLayout() {
+		return xdgBaseDirOrDefault(EnvXDGStateHomeKey)
+	}
+	localDir, err := LocalDir()
+	if err != nil {
+		return "", errors.Wrap(err, "could not find local tanzu dir for OS")
+	}
+	return filepath.Join(localDir, StateDirName), nil
+}
+
+// pluginDir returns the plugin directory within the base directory, creating it if required
+func pluginDir(base, plugin string) (string, error) {
+	if plugin == "" || plugin == "." || plugin == ".." || strings.ContainsAny(plugin, `/\`) {
+		return "", errors.Errorf("invalid plugin name %q", plugin)
+	}
+	dir := filepath.Join(base, plugin)
+	if err := os.MkdirAll(dir, configDirMode); err != nil {
+		return "", errors.Wrapf(err, "could not make the directory of plugin %q", plugin)
+	}
+	return dir, nil
+}
+
+// copyPath copies the file, creating the parent directory of the destination if required
+func copyPath(from, to string) error {
+	if err := os.MkdirAll(filepath.Dir(to), configDirMode); err != nil {
+		return err
+	}
+	return copyFile(from, to)
+}
+
+// movePath moves the file or directory, copying it if it cannot be renamed e.g. across file systems
+func movePath(from, to string, isDir bool) error {
+	if err := os.MkdirAll(filepath.Dir(to), configDirMode); err != nil {
+		return err
+	}
+	if err := os.Rename(from, to); err == nil {
+		return nil
+	}
+	var err error
+	if isDir {
+		err = copyDir(from, to)
+	} else {
+		err = copyFile(from, to)
+	}
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(from)
+}
@@ -0,0 +1,150 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupTestXDGHome points the home and XDG base directories to temporary directories, without config overrides
+func setupTestXDGHome(t *testing.T) (home, xdgConfig, xdgCache, xdgState string) {
+	home = t.TempDir()
+	xdgConfig, xdgCache, xdgState = t.TempDir(), t.TempDir(), t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv(EnvXDGConfigHomeKey, xdgConfig)
+	t.Setenv(EnvXDGCacheHomeKey, xdgCache)
+	t.Setenv(EnvXDGStateHomeKey, xdgState)
+	for _, key := range []string{EnvConfigKey, EnvConfigNextGenKey, EnvConfigMetadataKey, EnvConfigAuditFileKey} {
+		t.Setenv(key, "")
+		require.NoError(t, os.Unsetenv(key))
+	}
+	return home, xdgConfig, xdgCache, xdgState
+}
+
+func TestXDGDirs(t *testing.T) {
+	home, xdgConfig, xdgCache, xdgState := setupTestXDGHome(t)
+
+	// Without the XDG layout everything is under LocalDir
+	localDir, err := LocalDir()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, LocalDirName), localDir)
+	cacheDir, err := CacheDir("my-plugin")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(localDir, CacheDirName, "my-plugin"), cacheDir)
+	assert.DirExists(t, cacheDir)
+	stateDir, err := StateDir("my-plugin")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(localDir, StateDirName, "my-plugin"), stateDir)
+	auditPath, err := AuditFilePath()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(localDir, ConfigAuditName), auditPath)
+
+	t.Setenv(EnvXDGLayoutKey, "true")
+	localDir, err = LocalDir()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(xdgConfig, XDGDirName), localDir)
+	configPath, err := ClientConfigPath()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(xdgConfig, XDGDirName, ConfigName), configPath)
+	cacheDir, err = CacheDir("my-plugin")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(xdgCache, XDGDirName, "my-plugin"), cacheDir)
+	stateDir, err = StateDir("my-plugin")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(xdgState, XDGDirName, "my-plugin"), stateDir)
+	auditPath, err = AuditFilePath()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(xdgState, XDGDirName, ConfigAuditName), auditPath)
+
+	// TANZU_CONFIG still overrides the config path
+	t.Setenv(EnvConfigKey, filepath.Join(home, "custom.yaml"))
+	configPath, err = ClientConfigPath()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, "custom.yaml"), configPath)
+
+	// Unset or relative XDG variables fall back to the defaults
+	t.Setenv(EnvXDGConfigHomeKey, "relative")
+	require.NoError(t, os.Unsetenv(EnvXDGCacheHomeKey))
+	localDir, err = LocalDir()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, ".config", XDGDirName), localDir)
+	cacheDir, err = CacheDir("my-plugin")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, ".cache", XDGDirName, "my-plugin"), cacheDir)
+
+	for _, plugin := range []string{"", "..", "a/b"} {
+		_, err = CacheDir(plugin)
+		assert.Error(t, err, plugin)
+		_, err = StateDir(plugin)
+		assert.Error(t, err, plugin)
+	}
+}
+
+func TestMigrateToXDGLayout(t *testing.T) {
+	home, xdgConfig, xdgCache, xdgState := setupTestXDGHome(t)
+	_, err := MigrateToXDGLayout()
+	assert.EqualError(t, err, "the XDG layout is not enabled, set TANZU_XDG_LAYOUT=true to enable it")
+
+	legacyDir := filepath.Join(home, LocalDirName)
+	for _, dir := range []string{PluginsBaseDir, CacheDirName, StateDirName} {
+		require.NoError(t, os.MkdirAll(filepath.Join(legacyDir, dir, "my-plugin"), 0700))
+	}
+	for name, data := range map[string]string{
+		ConfigName:             "clientOptions: {}\n",
+		CfgNextGenName:         "contexts: []\n",
+		ConfigAuditName:        "{}\n",
+		ConfigAuditName + ".1": "{}\n",
+		LocalTanzuFileLock:     "",
+		filepath.Join(PluginsBaseDir, "my-plugin", "a"): "a",
+		filepath.Join(CacheDirName, "my-plugin", "b"):   "b",
+		filepath.Join(StateDirName, "my-plugin", "c"):   "c",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(legacyDir, name), []byte(data), 0600))
+	}
+	// A file already present in the XDG layout is kept
+	require.NoError(t, os.MkdirAll(filepath.Join(xdgConfig, XDGDirName), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(xdgConfig, XDGDirName, CfgNextGenName), []byte("contexts: [] # new\n"), 0600))
+
+	t.Setenv(EnvXDGLayoutKey, "true")
+	migrated, err := MigrateToXDGLayout()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []XDGMigratedPath{
+		{From: filepath.Join(legacyDir, ConfigName), To: filepath.Join(xdgConfig, XDGDirName, ConfigName), Copied: true},
+		{From: filepath.Join(legacyDir, PluginsBaseDir), To: filepath.Join(xdgConfig, XDGDirName, PluginsBaseDir)},
+		{From: filepath.Join(legacyDir, ConfigAuditName), To: filepath.Join(xdgState, XDGDirName, ConfigAuditName)},
+		{From: filepath.Join(legacyDir, ConfigAuditName+".1"), To: filepath.Join(xdgState, XDGDirName, ConfigAuditName+".1")},
+		{From: filepath.Join(legacyDir, CacheDirName, "my-plugin"), To: filepath.Join(xdgCache, XDGDirName, "my-plugin")},
+		{From: filepath.Join(legacyDir, StateDirName, "my-plugin"), To: filepath.Join(xdgState, XDGDirName, "my-plugin")},
+	}, migrated)
+	assert.FileExists(t, filepath.Join(xdgConfig, XDGDirName, PluginsBaseDir, "my-plugin", "a"))
+	// The plugin cache and state are found by CacheDir and StateDir
+	cacheDir, err := CacheDir("my-plugin")
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(cacheDir, "b"))
+	stateDir, err := StateDir("my-plugin")
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(stateDir, "c"))
+	assert.NoDirExists(t, filepath.Join(legacyDir, CacheDirName))
+	assert.NoDirExists(t, filepath.Join(legacyDir, StateDirName))
+	assert.NoDirExists(t, filepath.Join(xdgConfig, XDGDirName, CacheDirName))
+	assert.FileExists(t, filepath.Join(legacyDir, LocalTanzuFileLock))
+	// The config files are left in place for the plugins built with an older runtime
+	assert.FileExists(t, filepath.Join(legacyDir, ConfigName))
+	assert.NoDirExists(t, filepath.Join(legacyDir, PluginsBaseDir))
+	assert.FileExists(t, filepath.Join(legacyDir, CfgNextGenName))
+	data, err := os.ReadFile(filepath.Join(xdgConfig, XDGDirName, CfgNextGenName))
+	require.NoError(t, err)
+	assert.Equal(t, "contexts: [] # new\n", string(data))
+
+	// The migration can be run again
+	migrated, err = MigrateToXDGLayout()
+	require.NoError(t, err)
+	assert.Empty(t, migrated)
+}
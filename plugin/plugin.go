@@ -11,6 +11,7 @@ import (
 	"go.uber.org/multierr"
 	"golang.org/x/mod/semver"
 
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/config"
 	"github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
 )
 
@@ -26,6 +27,8 @@ func NewPlugin(descriptor *PluginDescriptor) (*Plugin, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "invalid PluginDescriptor specified")
 	}
+	// Record the plugin name in the config audit trail for the config mutations of the plugin
+	config.SetAuditPluginName(descriptor.Name)
 	p := &Plugin{
 		Cmd: newRootCmd(descriptor),
 	}
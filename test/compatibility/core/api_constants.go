@@ -42,6 +42,10 @@ const (
 	GetCLIDiscoverySourceAPI    RuntimeAPIName = "GetCLIDiscoverySource"
 	DeleteCLIDiscoverySourceAPI RuntimeAPIName = "DeleteCLIDiscoverySource"
 
+	SetCLIRepositoryAPI    RuntimeAPIName = "SetCLIRepository"
+	GetCLIRepositoryAPI    RuntimeAPIName = "GetCLIRepository"
+	DeleteCLIRepositoryAPI RuntimeAPIName = "DeleteCLIRepository"
+
 	GetMetadataAPI                     RuntimeAPIName = "GetMetadata"
 	GetConfigMetadataAPI               RuntimeAPIName = "GetConfigMetadata"
 	GetConfigMetadataPatchStrategyAPI  RuntimeAPIName = "GetConfigMetadataPatchStrategy"
@@ -75,6 +79,7 @@ const (
 	Key             APIArgumentType = "key"
 	Value           APIArgumentType = "value"
 	DiscoverySource APIArgumentType = "discoverySource"
+	Repository      APIArgumentType = "repository"
 )
 
 type Result string
@@ -0,0 +1,182 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package clirepositories provides api command helpers and validators to write compatibility tests for CLI Repositories apis
+package clirepositories
+
+import (
+	"gopkg.in/yaml.v3"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/test/compatibility/core"
+)
+
+// NewSetCLIRepositoryCommand constructs a command to make a call to specific runtime version SetCLIRepository API
+// Input Parameter inputOpts has all input parameters which are required for Runtime SetCLIRepository API
+// Input Parameter: outputOpts has details about expected output from Runtime SetCLIRepository API call
+// Return: command to execute or error if any validations fails for SetCLIRepositoryInputOptions or SetCLIRepositoryOutputOptions
+// This method does validate the input parameters SetCLIRepositoryInputOptions or SetCLIRepositoryOutputOptions based on Runtime API Version
+// For more details about supported parameters refer to SetCLIRepositoryInputOptions or SetCLIRepositoryOutputOptions definition(and PluginRepositoryOpts struct, which is embedded)
+func NewSetCLIRepositoryCommand(inputOpts *SetCLIRepositoryInputOptions, outputOpts *SetCLIRepositoryOutputOptions) (*core.Command, error) {
+	// Init the Command object
+	c := &core.Command{}
+
+	// Init the API object
+	api := &core.API{}
+
+	// Set API name
+	api.Name = core.SetCLIRepositoryAPI
+
+	// Validate the SetCLIRepository input arguments
+	_, err := inputOpts.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// Set API version
+	api.Version = inputOpts.RuntimeVersion
+
+	// Construct the SetCLIRepository API arguments
+	bytes, err := yaml.Marshal(inputOpts.PluginRepositoryOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	api.Arguments = map[core.APIArgumentType]interface{}{
+		core.Repository: string(bytes),
+	}
+
+	// Construct Output parameters
+	var res = core.Success
+	var content = ""
+
+	if outputOpts != nil && outputOpts.Error != "" {
+		res = core.Failed
+		content = outputOpts.Error
+	}
+
+	api.Output = &core.Output{
+		Result:  res,
+		Content: content,
+	}
+
+	c.APIs = append(c.APIs, api)
+
+	return c, nil
+}
+
+// NewGetCLIRepositoryCommand constructs a command to make a call to specific runtime version GetCLIRepository API
+// Input Parameter inputOpts has all input parameters which are required for Runtime GetCLIRepository API
+// Input Parameter: outputOpts has details about expected output from Runtime GetCLIRepository API call
+// Return: command to execute or error if any validations fails for GetCLIRepositoryInputOptions or GetCLIRepositoryOutputOptions
+// This method does validate the input parameters GetCLIRepositoryInputOptions or GetCLIRepositoryOutputOptions based on Runtime API Version
+// For more details about supported parameters refer to GetCLIRepositoryInputOptions or GetCLIRepositoryOutputOptions definition(and PluginRepositoryOpts struct, which is embedded)
+func NewGetCLIRepositoryCommand(inputOpts *GetCLIRepositoryInputOptions, outputOpts *GetCLIRepositoryOutputOptions) (*core.Command, error) {
+	// Init the Command object
+	c := &core.Command{}
+
+	// Init the API object
+	api := &core.API{}
+
+	// Set API name
+	api.Name = core.GetCLIRepositoryAPI
+
+	// Validate the Input Options
+	_, err := inputOpts.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// Set API version
+	api.Version = inputOpts.RuntimeVersion
+
+	// Construct the GetCLIRepository API arguments
+	api.Arguments = map[core.APIArgumentType]interface{}{
+		core.Name: inputOpts.RepositoryName,
+	}
+
+	// Construct Output parameters
+	var res = core.Success
+	var content = ""
+
+	if outputOpts != nil {
+		if outputOpts.Error != "" {
+			res = core.Failed
+			content = outputOpts.Error
+		} else if outputOpts.PluginRepositoryOpts != nil {
+			// Validate the Output Options
+			_, err = outputOpts.Validate()
+			if err != nil {
+				return nil, err
+			}
+
+			// Construct get repository output opts
+			bytes, err := yaml.Marshal(outputOpts.PluginRepositoryOpts)
+			if err != nil {
+				return nil, err
+			}
+
+			content = string(bytes)
+			res = core.Success
+		}
+	}
+
+	api.Output = &core.Output{
+		Result:  res,
+		Content: content,
+	}
+
+	if outputOpts != nil && outputOpts.ValidationStrategy != "" {
+		api.Output.ValidationStrategy = outputOpts.ValidationStrategy
+	}
+
+	c.APIs = append(c.APIs, api)
+	return c, nil
+}
+
+// NewDeleteCLIRepositoryCommand constructs a command to make a call to specific runtime version DeleteCLIRepository API
+// Input Parameter inputOpts has all input parameters which are required for Runtime DeleteCLIRepository API
+// Input Parameter: outputOpts has details about expected output from Runtime DeleteCLIRepository API call
+// Return: command to execute or error if any validations fails for DeleteCLIRepositoryInputOptions or DeleteCLIRepositoryOutputOptions
+// This method does validate the input parameters DeleteCLIRepositoryInputOptions or DeleteCLIRepositoryOutputOptions based on Runtime API Version
+// For more details about supported parameters refer to DeleteCLIRepositoryInputOptions or DeleteCLIRepositoryOutputOptions definition
+func NewDeleteCLIRepositoryCommand(inputOpts *DeleteCLIRepositoryInputOptions, outputOpts *DeleteCLIRepositoryOutputOptions) (*core.Command, error) {
+	// Init the Command object
+	c := &core.Command{}
+
+	// Init the API object
+	api := &core.API{}
+
+	// Set API name
+	api.Name = core.DeleteCLIRepositoryAPI
+
+	// Validate the input options
+	_, err := inputOpts.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// Set API version
+	api.Version = inputOpts.RuntimeVersion
+
+	// Construct the DeleteCLIRepository api arguments
+	api.Arguments = map[core.APIArgumentType]interface{}{
+		core.Name: inputOpts.RepositoryName,
+	}
+
+	// Construct Output parameters
+	var res = core.Success
+	var content = ""
+
+	if outputOpts != nil && outputOpts.Error != "" {
+		res = core.Failed
+		content = outputOpts.Error
+	}
+
+	api.Output = &core.Output{
+		Result:  res,
+		Content: content,
+	}
+
+	c.APIs = append(c.APIs, api)
+	return c, nil
+}
@@ -0,0 +1,236 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package clirepositories
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/test/compatibility/framework/types"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/test/compatibility/core"
+)
+
+const repository string = `ociPluginRepository:
+    name: compatibility-tests-repository-name
+    image: compatibility-tests-repository-image
+`
+
+func TestNewSetCLIRepositoryCommand(t *testing.T) {
+	tests := []struct {
+		inputOpts  *SetCLIRepositoryInputOptions
+		outputOpts *SetCLIRepositoryOutputOptions
+		cmd        *core.Command
+		err        string
+	}{
+		{
+			&SetCLIRepositoryInputOptions{
+				RuntimeAPIVersion: &core.RuntimeAPIVersion{
+					RuntimeVersion: core.VersionLatest,
+				},
+				PluginRepositoryOpts: &types.PluginRepositoryOpts{
+					OCIPluginRepository: &types.OCIPluginRepositoryOpts{
+						Name:  CompatibilityTestsRepositoryName,
+						Image: CompatibilityTestsRepositoryImage,
+					},
+				},
+			}, nil,
+			&core.Command{
+				APIs: []*core.API{
+					{
+						Name:    core.SetCLIRepositoryAPI,
+						Version: core.VersionLatest,
+						Arguments: map[core.APIArgumentType]interface{}{
+							core.Repository: repository,
+						},
+						Output: &core.Output{
+							ValidationStrategy: "",
+							Result:             core.Success,
+							Content:            "",
+						},
+					},
+				},
+			}, "",
+		},
+		{
+			&SetCLIRepositoryInputOptions{
+				RuntimeAPIVersion: &core.RuntimeAPIVersion{
+					RuntimeVersion: core.Version102,
+				},
+				PluginRepositoryOpts: &types.PluginRepositoryOpts{
+					OCIPluginRepository: &types.OCIPluginRepositoryOpts{
+						Name:  CompatibilityTestsRepositoryName,
+						Image: CompatibilityTestsRepositoryImage,
+					},
+				},
+			}, nil, nil,
+			"SetCLIRepository API is not supported for the specified runtime version",
+		},
+		{
+			&SetCLIRepositoryInputOptions{
+				RuntimeAPIVersion: &core.RuntimeAPIVersion{
+					RuntimeVersion: core.VersionLatest,
+				},
+				PluginRepositoryOpts: &types.PluginRepositoryOpts{},
+			}, nil, nil,
+			"one repository config should be set",
+		},
+	}
+
+	for _, tt := range tests {
+		cmd, err := NewSetCLIRepositoryCommand(tt.inputOpts, tt.outputOpts)
+		if tt.err != "" {
+			assert.EqualError(t, err, tt.err)
+		} else {
+			assert.NoError(t, err)
+			assert.Equal(t, tt.cmd, cmd)
+		}
+	}
+}
+
+func TestNewGetCLIRepositoryCommand(t *testing.T) {
+	tests := []struct {
+		inputOpts  *GetCLIRepositoryInputOptions
+		outputOpts *GetCLIRepositoryOutputOptions
+		cmd        *core.Command
+		err        string
+	}{
+		{
+			&GetCLIRepositoryInputOptions{
+				RuntimeAPIVersion: &core.RuntimeAPIVersion{
+					RuntimeVersion: core.VersionLatest,
+				},
+				RepositoryName: CompatibilityTestsRepositoryName,
+			},
+			&GetCLIRepositoryOutputOptions{
+				RuntimeAPIVersion: &core.RuntimeAPIVersion{
+					RuntimeVersion: core.VersionLatest,
+				},
+				PluginRepositoryOpts: &types.PluginRepositoryOpts{
+					OCIPluginRepository: &types.OCIPluginRepositoryOpts{
+						Name:  CompatibilityTestsRepositoryName,
+						Image: CompatibilityTestsRepositoryImage,
+					},
+				},
+			},
+			&core.Command{
+				APIs: []*core.API{
+					{
+						Name:    core.GetCLIRepositoryAPI,
+						Version: core.VersionLatest,
+						Arguments: map[core.APIArgumentType]interface{}{
+							core.Name: CompatibilityTestsRepositoryName,
+						},
+						Output: &core.Output{
+							ValidationStrategy: "",
+							Result:             core.Success,
+							Content:            repository,
+						},
+					},
+				},
+			}, "",
+		},
+		{
+			&GetCLIRepositoryInputOptions{
+				RuntimeAPIVersion: &core.RuntimeAPIVersion{
+					RuntimeVersion: core.VersionLatest,
+				},
+				RepositoryName: CompatibilityTestsRepositoryName,
+			},
+			&GetCLIRepositoryOutputOptions{
+				RuntimeAPIVersion: &core.RuntimeAPIVersion{
+					RuntimeVersion: core.VersionLatest,
+				},
+				Error: CLIRepositoryNotFound,
+			},
+			&core.Command{
+				APIs: []*core.API{
+					{
+						Name:    core.GetCLIRepositoryAPI,
+						Version: core.VersionLatest,
+						Arguments: map[core.APIArgumentType]interface{}{
+							core.Name: CompatibilityTestsRepositoryName,
+						},
+						Output: &core.Output{
+							ValidationStrategy: "",
+							Result:             core.Failed,
+							Content:            CLIRepositoryNotFound,
+						},
+					},
+				},
+			}, "",
+		},
+		{
+			&GetCLIRepositoryInputOptions{
+				RuntimeAPIVersion: &core.RuntimeAPIVersion{
+					RuntimeVersion: core.VersionLatest,
+				},
+			}, nil, nil,
+			"repository name is required",
+		},
+	}
+
+	for _, tt := range tests {
+		cmd, err := NewGetCLIRepositoryCommand(tt.inputOpts, tt.outputOpts)
+		if tt.err != "" {
+			assert.EqualError(t, err, tt.err)
+		} else {
+			assert.NoError(t, err)
+			assert.Equal(t, tt.cmd, cmd)
+		}
+	}
+}
+
+func TestNewDeleteCLIRepositoryCommand(t *testing.T) {
+	tests := []struct {
+		inputOpts  *DeleteCLIRepositoryInputOptions
+		outputOpts *DeleteCLIRepositoryOutputOptions
+		cmd        *core.Command
+		err        string
+	}{
+		{
+			&DeleteCLIRepositoryInputOptions{
+				RuntimeAPIVersion: &core.RuntimeAPIVersion{
+					RuntimeVersion: core.VersionLatest,
+				},
+				RepositoryName: CompatibilityTestsRepositoryName,
+			}, nil,
+			&core.Command{
+				APIs: []*core.API{
+					{
+						Name:    core.DeleteCLIRepositoryAPI,
+						Version: core.VersionLatest,
+						Arguments: map[core.APIArgumentType]interface{}{
+							core.Name: CompatibilityTestsRepositoryName,
+						},
+						Output: &core.Output{
+							ValidationStrategy: "",
+							Result:             core.Success,
+							Content:            "",
+						},
+					},
+				},
+			}, "",
+		},
+		{
+			&DeleteCLIRepositoryInputOptions{
+				RuntimeAPIVersion: &core.RuntimeAPIVersion{
+					RuntimeVersion: core.VersionLatest,
+				},
+			}, nil, nil,
+			"repository name is required",
+		},
+	}
+
+	for _, tt := range tests {
+		cmd, err := NewDeleteCLIRepositoryCommand(tt.inputOpts, tt.outputOpts)
+		if tt.err != "" {
+			assert.EqualError(t, err, tt.err)
+		} else {
+			assert.NoError(t, err)
+			assert.Equal(t, tt.cmd, cmd)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package clirepositories
+
+import (
+	"github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/test/compatibility/framework/types"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/test/compatibility/core"
+)
+
+const (
+	CLIRepositoryNotFound             string = "cli repository not found"
+	CompatibilityTestsRepositoryName  string = "compatibility-tests-repository-name"
+	CompatibilityTestsRepositoryImage string = "compatibility-tests-repository-image"
+)
+
+// DefaultSetCLIRepositoryCommand constructs a default SetCLIRepository command without an error.
+func DefaultSetCLIRepositoryCommand(version core.RuntimeVersion, opts ...CfgCLIRepositoriesArgsOption) *core.Command {
+	// Make input and output options
+	defaultPluginRepository := DefaultCLIRepositoryPerVersion(version)
+
+	args := &CfgCLIRepositoriesArgs{
+		PluginRepositoryOpts: defaultPluginRepository.PluginRepositoryOpts,
+	}
+
+	for _, opt := range opts {
+		opt(args)
+	}
+
+	inputOpts := &SetCLIRepositoryInputOptions{
+		RuntimeAPIVersion: &core.RuntimeAPIVersion{
+			RuntimeVersion: version,
+		},
+		PluginRepositoryOpts: args.PluginRepositoryOpts,
+	}
+
+	outputOpts := &SetCLIRepositoryOutputOptions{
+		RuntimeAPIVersion: &core.RuntimeAPIVersion{
+			RuntimeVersion: version,
+		},
+		Error: args.Error,
+	}
+
+	// Construct API command
+	cmd, err := NewSetCLIRepositoryCommand(inputOpts, outputOpts)
+	gomega.Expect(err).To(gomega.BeNil())
+	return cmd
+}
+
+// DefaultGetCLIRepositoryCommand constructs a default GetCLIRepository command without an error.
+func DefaultGetCLIRepositoryCommand(version core.RuntimeVersion, opts ...CfgCLIRepositoriesArgsOption) *core.Command {
+	// Make input and output options
+	defaultPluginRepository := DefaultCLIRepositoryPerVersion(version)
+
+	args := &CfgCLIRepositoriesArgs{
+		RepositoryName:       CompatibilityTestsRepositoryName,
+		PluginRepositoryOpts: defaultPluginRepository.PluginRepositoryOpts,
+	}
+
+	for _, opt := range opts {
+		opt(args)
+	}
+
+	inputOpts := &GetCLIRepositoryInputOptions{
+		RuntimeAPIVersion: &core.RuntimeAPIVersion{
+			RuntimeVersion: version,
+		},
+		RepositoryName: args.RepositoryName,
+	}
+
+	outputOpts := &GetCLIRepositoryOutputOptions{
+		RuntimeAPIVersion: &core.RuntimeAPIVersion{
+			RuntimeVersion: version,
+		},
+		PluginRepositoryOpts: args.PluginRepositoryOpts,
+		ValidationStrategy:   args.ValidationStrategy,
+		Error:                args.Error,
+	}
+
+	// Construct API command
+	cmd, err := NewGetCLIRepositoryCommand(inputOpts, outputOpts)
+	gomega.Expect(err).To(gomega.BeNil())
+	return cmd
+}
+
+// DefaultDeleteCLIRepositoryCommand constructs a default DeleteCLIRepository command without an error.
+func DefaultDeleteCLIRepositoryCommand(version core.RuntimeVersion, opts ...CfgCLIRepositoriesArgsOption) *core.Command {
+	args := &CfgCLIRepositoriesArgs{
+		RepositoryName: CompatibilityTestsRepositoryName,
+	}
+
+	for _, opt := range opts {
+		opt(args)
+	}
+
+	inputOpts := &DeleteCLIRepositoryInputOptions{
+		RuntimeAPIVersion: &core.RuntimeAPIVersion{
+			RuntimeVersion: version,
+		},
+		RepositoryName: args.RepositoryName,
+	}
+
+	outputOpts := &DeleteCLIRepositoryOutputOptions{
+		RuntimeAPIVersion: &core.RuntimeAPIVersion{
+			RuntimeVersion: version,
+		},
+		Error: args.Error,
+	}
+
+	// Construct API command
+	cmd, err := NewDeleteCLIRepositoryCommand(inputOpts, outputOpts)
+	gomega.Expect(err).To(gomega.BeNil())
+	return cmd
+}
+
+// DefaultCLIRepositoryPerVersion constructs a default PluginRepositoryOpts
+func DefaultCLIRepositoryPerVersion(version core.RuntimeVersion) *CfgCLIRepositoriesArgs {
+	switch version {
+	case core.VersionLatest:
+		return NewCfgCLIRepositoriesArgs(
+			WithOCIRepositoryOpts(&types.OCIPluginRepositoryOpts{
+				Name:  CompatibilityTestsRepositoryName,
+				Image: CompatibilityTestsRepositoryImage,
+			}),
+		)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package clirepositories
+
+import (
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/test/compatibility/core"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/test/compatibility/framework/types"
+)
+
+// GetCLIRepositoryInputOptions used to generate GetCLIRepository command
+type GetCLIRepositoryInputOptions struct {
+	*core.RuntimeAPIVersion        // required
+	RepositoryName          string // required
+}
+
+// GetCLIRepositoryOutputOptions used to generate GetCLIRepository command
+type GetCLIRepositoryOutputOptions struct {
+	*core.RuntimeAPIVersion                             // required
+	PluginRepositoryOpts    *types.PluginRepositoryOpts // For specific version options look into PluginRepositoryOpts definition
+	ValidationStrategy      core.ValidationStrategy     // Type of validation to be performed i.e. exact or partial. default is partial
+	Error                   string                      // expected error message could be the sub string of actual error message
+}
+
+// SetCLIRepositoryInputOptions used to generate SetCLIRepository command
+type SetCLIRepositoryInputOptions struct {
+	*core.RuntimeAPIVersion                             // required
+	PluginRepositoryOpts    *types.PluginRepositoryOpts // For specific version options look into PluginRepositoryOpts definition
+}
+
+// SetCLIRepositoryOutputOptions used to generate SetCLIRepository command
+type SetCLIRepositoryOutputOptions struct {
+	*core.RuntimeAPIVersion        // required
+	Error                   string // expected error message could be the sub string of actual error message
+}
+
+// DeleteCLIRepositoryInputOptions used to generate DeleteCLIRepository command
+type DeleteCLIRepositoryInputOptions struct {
+	*core.RuntimeAPIVersion        // required
+	RepositoryName          string // required
+}
+
+// DeleteCLIRepositoryOutputOptions used to generate DeleteCLIRepository command
+type DeleteCLIRepositoryOutputOptions struct {
+	*core.RuntimeAPIVersion        // required
+	Error                   string // expected error message could be the sub string of actual error message
+}
+
+// CfgCLIRepositoriesArgs used to construct input and output options
+type CfgCLIRepositoriesArgs struct {
+	*core.RuntimeAPIVersion
+	RepositoryName       string
+	PluginRepositoryOpts *types.PluginRepositoryOpts // For specific version options look into PluginRepositoryOpts definition
+	ValidationStrategy   core.ValidationStrategy
+	Error                string
+}
+
+type CfgCLIRepositoriesArgsOption func(*CfgCLIRepositoriesArgs)
+
+func WithValidationStrategy(v core.ValidationStrategy) CfgCLIRepositoriesArgsOption {
+	return func(c *CfgCLIRepositoriesArgs) {
+		c.ValidationStrategy = v
+	}
+}
+
+func WithStrictValidationStrategy() CfgCLIRepositoriesArgsOption {
+	return WithValidationStrategy(core.ValidationStrategyStrict)
+}
+
+func WithRuntimeAPIVersion(v *core.RuntimeAPIVersion) CfgCLIRepositoriesArgsOption {
+	return func(c *CfgCLIRepositoriesArgs) {
+		c.RuntimeAPIVersion = v
+	}
+}
+
+func WithRepositoryName(name string) CfgCLIRepositoriesArgsOption {
+	return func(c *CfgCLIRepositoriesArgs) {
+		c.RepositoryName = name
+	}
+}
+
+func WithError(e string) CfgCLIRepositoriesArgsOption {
+	return func(c *CfgCLIRepositoriesArgs) {
+		c.Error = e
+	}
+}
+
+func NewCfgCLIRepositoriesArgs(options ...CfgCLIRepositoriesArgsOption) *CfgCLIRepositoriesArgs {
+	// Default Value
+	p := &CfgCLIRepositoriesArgs{
+		PluginRepositoryOpts: &types.PluginRepositoryOpts{},
+	}
+
+	for _, opt := range options {
+		opt(p)
+	}
+
+	return p
+}
+
+// WithGCPRepositoryOpts replaces the repository with a GCP plugin repository
+func WithGCPRepositoryOpts(opts *types.GCPPluginRepositoryOpts) CfgCLIRepositoriesArgsOption {
+	return func(p *CfgCLIRepositoriesArgs) {
+		p.PluginRepositoryOpts = &types.PluginRepositoryOpts{GCPPluginRepository: opts}
+	}
+}
+
+// WithOCIRepositoryOpts replaces the repository with an OCI plugin repository
+func WithOCIRepositoryOpts(opts *types.OCIPluginRepositoryOpts) CfgCLIRepositoriesArgsOption {
+	return func(p *CfgCLIRepositoriesArgs) {
+		p.PluginRepositoryOpts = &types.PluginRepositoryOpts{OCIPluginRepository: opts}
+	}
+}
+
+// WithLocalRepositoryOpts replaces the repository with a local plugin repository
+func WithLocalRepositoryOpts(opts *types.LocalPluginRepositoryOpts) CfgCLIRepositoriesArgsOption {
+	return func(p *CfgCLIRepositoriesArgs) {
+		p.PluginRepositoryOpts = &types.PluginRepositoryOpts{LocalPluginRepository: opts}
+	}
+}
+
+// WithHTTPRepositoryOpts replaces the repository with an HTTP plugin repository
+func WithHTTPRepositoryOpts(opts *types.HTTPPluginRepositoryOpts) CfgCLIRepositoriesArgsOption {
+	return func(p *CfgCLIRepositoriesArgs) {
+		p.PluginRepositoryOpts = &types.PluginRepositoryOpts{HTTPPluginRepository: opts}
+	}
+}
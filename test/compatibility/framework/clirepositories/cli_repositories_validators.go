@@ -0,0 +1,74 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package clirepositories
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/test/compatibility/core"
+)
+
+// Validate the SetCLIRepositoryInputOptions as per runtime version i.e. check whether mandatory fields are set and throw error if missing
+func (opts *SetCLIRepositoryInputOptions) Validate() (bool, error) {
+	// Run Core Validators
+	_, err := opts.RuntimeAPIVersion.Validate()
+	if err != nil {
+		return false, err
+	}
+
+	switch opts.RuntimeVersion {
+	case core.VersionLatest:
+		err = opts.PluginRepositoryOpts.ValidPluginRepository()
+		if err != nil {
+			return false, err
+		}
+		return true, nil
+	default:
+		return false, errors.New("SetCLIRepository API is not supported for the specified runtime version")
+	}
+}
+
+// Validate the opts as per runtime version i.e. check whether the expected fields are supported for the runtime version specified
+func (opts *GetCLIRepositoryInputOptions) Validate() (bool, error) {
+	_, err := opts.RuntimeAPIVersion.Validate()
+	if err != nil {
+		return false, err
+	}
+
+	if opts.RepositoryName == "" {
+		return false, errors.New("repository name is required")
+	}
+	return true, nil
+}
+
+// Validate the opts as per runtime version i.e. check whether the expected fields are supported for the runtime version specified
+func (opts *GetCLIRepositoryOutputOptions) Validate() (bool, error) {
+	_, err := opts.RuntimeAPIVersion.Validate()
+	if err != nil {
+		return false, err
+	}
+
+	switch opts.RuntimeVersion {
+	case core.VersionLatest:
+		err = opts.PluginRepositoryOpts.ValidPluginRepository()
+		if err != nil {
+			return false, err
+		}
+		return true, nil
+	default:
+		return false, errors.New("GetCLIRepository API is not supported for the specified runtime version")
+	}
+}
+
+// Validate the opts as per runtime version i.e. check whether the expected fields are supported for the runtime version specified
+func (opts *DeleteCLIRepositoryInputOptions) Validate() (bool, error) {
+	_, err := opts.RuntimeAPIVersion.Validate()
+	if err != nil {
+		return false, err
+	}
+	if opts.RepositoryName == "" {
+		return false, errors.New("repository name is required")
+	}
+	return true, nil
+}
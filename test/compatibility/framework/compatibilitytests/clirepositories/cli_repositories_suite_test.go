@@ -0,0 +1,16 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package clirepositories_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestCLIRepositories(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Cross-version API Compatibility Test Suite for CLI Repositories")
+}
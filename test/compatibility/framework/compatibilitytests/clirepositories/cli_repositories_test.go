@@ -0,0 +1,86 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package clirepositories_test
+
+import (
+	"github.com/onsi/ginkgo/v2"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/test/compatibility/framework/types"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/test/compatibility/framework/clirepositories"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/test/compatibility/framework/executer"
+
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/test/compatibility/core"
+)
+
+var _ = ginkgo.Describe("CLI Repository APIs Compatibility Tests for supported Runtime version latest", func() {
+	ginkgo.GinkgoWriter.Println("Get/Set/Delete CLI Repository API methods are tested for OCI, local and HTTP plugin repositories with Runtime version latest")
+
+	ginkgo.BeforeEach(func() {
+		// Setup mock temporary config files for testing
+		_, cleanup := core.SetupTempCfgFiles()
+		ginkgo.DeferCleanup(func() {
+			cleanup()
+		})
+	})
+
+	ginkgo.Context("Run SetCLIRepository, GetCLIRepository, DeleteCLIRepository on Runtime library version latest", func() {
+		ginkgo.It("Run SetCLIRepository, GetCLIRepository and DeleteCLIRepository for an OCI plugin repository", func() {
+			testCase := core.NewTestCase()
+			testCase.Add(clirepositories.DefaultSetCLIRepositoryCommand(core.VersionLatest))
+			testCase.Add(clirepositories.DefaultGetCLIRepositoryCommand(core.VersionLatest, clirepositories.WithStrictValidationStrategy()))
+			testCase.Add(clirepositories.DefaultDeleteCLIRepositoryCommand(core.VersionLatest))
+			testCase.Add(clirepositories.DefaultGetCLIRepositoryCommand(core.VersionLatest, clirepositories.WithError(clirepositories.CLIRepositoryNotFound)))
+
+			// Run all the commands
+			executer.Execute(testCase)
+		})
+
+		ginkgo.It("Run SetCLIRepository, GetCLIRepository and DeleteCLIRepository for a local plugin repository", func() {
+			local := clirepositories.WithLocalRepositoryOpts(&types.LocalPluginRepositoryOpts{
+				Name: clirepositories.CompatibilityTestsRepositoryName,
+				Path: "/tmp/compatibility-tests-repository",
+			})
+
+			testCase := core.NewTestCase()
+			testCase.Add(clirepositories.DefaultSetCLIRepositoryCommand(core.VersionLatest, local))
+			testCase.Add(clirepositories.DefaultGetCLIRepositoryCommand(core.VersionLatest, local, clirepositories.WithStrictValidationStrategy()))
+			testCase.Add(clirepositories.DefaultDeleteCLIRepositoryCommand(core.VersionLatest))
+			testCase.Add(clirepositories.DefaultGetCLIRepositoryCommand(core.VersionLatest, clirepositories.WithError(clirepositories.CLIRepositoryNotFound)))
+
+			// Run all the commands
+			executer.Execute(testCase)
+		})
+
+		ginkgo.It("Run SetCLIRepository of an OCI plugin repository then SetCLIRepository of an HTTP plugin repository with the same name and GetCLIRepository", func() {
+			http := clirepositories.WithHTTPRepositoryOpts(&types.HTTPPluginRepositoryOpts{
+				Name: clirepositories.CompatibilityTestsRepositoryName,
+				URL:  "https://example.com/compatibility-tests-repository",
+			})
+
+			testCase := core.NewTestCase()
+			testCase.Add(clirepositories.DefaultSetCLIRepositoryCommand(core.VersionLatest))
+			testCase.Add(clirepositories.DefaultSetCLIRepositoryCommand(core.VersionLatest, http))
+			testCase.Add(clirepositories.DefaultGetCLIRepositoryCommand(core.VersionLatest, http, clirepositories.WithStrictValidationStrategy()))
+
+			// Run all the commands
+			executer.Execute(testCase)
+		})
+
+		ginkgo.It("Run SetCLIRepository with an invalid local plugin repository", func() {
+			testCase := core.NewTestCase()
+			testCase.Add(clirepositories.DefaultSetCLIRepositoryCommand(core.VersionLatest,
+				clirepositories.WithLocalRepositoryOpts(&types.LocalPluginRepositoryOpts{
+					Name: clirepositories.CompatibilityTestsRepositoryName,
+				}),
+				clirepositories.WithError("failed: invalid local repository: path cannot be empty"),
+			))
+			testCase.Add(clirepositories.DefaultGetCLIRepositoryCommand(core.VersionLatest, clirepositories.WithError(clirepositories.CLIRepositoryNotFound)))
+
+			// Run all the commands
+			executer.Execute(testCase)
+		})
+	})
+})
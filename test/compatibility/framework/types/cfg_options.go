@@ -214,6 +214,42 @@ type LocalDiscoveryOpts struct {
 type PluginRepositoryOpts struct {
 	// GCPPluginRepository is a plugin repository that utilizes GCP cloud storage.
 	GCPPluginRepository *GCPPluginRepositoryOpts `json:"gcpPluginRepository,omitempty" yaml:"gcpPluginRepository,omitempty"`
+
+	// OCIPluginRepository is a plugin repository that utilizes an OCI registry.
+	OCIPluginRepository *OCIPluginRepositoryOpts `json:"ociPluginRepository,omitempty" yaml:"ociPluginRepository,omitempty"`
+
+	// LocalPluginRepository is a plugin repository that utilizes the local filesystem.
+	LocalPluginRepository *LocalPluginRepositoryOpts `json:"localPluginRepository,omitempty" yaml:"localPluginRepository,omitempty"`
+
+	// HTTPPluginRepository is a plugin repository that utilizes a generic HTTP server.
+	HTTPPluginRepository *HTTPPluginRepositoryOpts `json:"httpPluginRepository,omitempty" yaml:"httpPluginRepository,omitempty"`
+}
+
+// OCIPluginRepositoryOpts is a plugin repository that utilizes an OCI registry.
+type OCIPluginRepositoryOpts struct {
+	// Name of the repository.
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+
+	// Image is the OCI image reference of the repository.
+	Image string `json:"image,omitempty" yaml:"image,omitempty"`
+}
+
+// LocalPluginRepositoryOpts is a plugin repository that utilizes the local filesystem.
+type LocalPluginRepositoryOpts struct {
+	// Name of the repository.
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+
+	// Path is the local path of the repository.
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+}
+
+// HTTPPluginRepositoryOpts is a plugin repository that utilizes a generic HTTP server.
+type HTTPPluginRepositoryOpts struct {
+	// Name of the repository.
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+
+	// URL of the repository.
+	URL string `json:"url,omitempty" yaml:"url,omitempty"`
 }
 
 // GCPPluginRepositoryOpts is a plugin repository that utilizes GCP cloud storage.
@@ -32,6 +32,26 @@ func (opts *PluginDiscoveryOpts) ValidPluginDiscovery() error {
 	return nil
 }
 
+func (opts *PluginRepositoryOpts) ValidPluginRepository() error {
+	configsSet := 0
+	if opts.GCPPluginRepository != nil {
+		configsSet++
+	}
+	if opts.OCIPluginRepository != nil {
+		configsSet++
+	}
+	if opts.LocalPluginRepository != nil {
+		configsSet++
+	}
+	if opts.HTTPPluginRepository != nil {
+		configsSet++
+	}
+	if configsSet != 1 {
+		return errors.New("one repository config should be set")
+	}
+	return nil
+}
+
 func (opts *PluginDiscoveryOpts) ValidContextType() error {
 	if opts.ContextType == CtxTypeK8s || opts.ContextType == CtxTypeTMC {
 		return nil
@@ -39,6 +39,16 @@ func parseCLIDiscoverySource(source string) (*configtypes.PluginDiscovery, error
 	return &pluginDiscovery, nil
 }
 
+// parseCLIRepository unmarshalls string to PluginRepository struct
+func parseCLIRepository(repository string) (*configtypes.PluginRepository, error) {
+	var pluginRepository configtypes.PluginRepository
+	err := yaml.Unmarshal([]byte(repository), &pluginRepository)
+	if err != nil {
+		return nil, err
+	}
+	return &pluginRepository, nil
+}
+
 // parseClientConfig unmarshalls string to ClientConfig struct
 func parseClientConfig(cfgStr string) (*configtypes.ClientConfig, error) {
 	var cfg configtypes.ClientConfig
@@ -48,6 +48,11 @@ var apiHandlers = map[core.RuntimeAPIName]func(*core.API) *core.APIResponse{
 	core.GetCLIDiscoverySourceAPI:    triggerGetCLIDiscoverySourceAPI,
 	core.DeleteCLIDiscoverySourceAPI: triggerDeleteCLIDiscoverySourceAPI,
 
+	// CLI Repository APIs
+	core.SetCLIRepositoryAPI:    triggerSetCLIRepositoryAPI,
+	core.GetCLIRepositoryAPI:    triggerGetCLIRepositoryAPI,
+	core.DeleteCLIRepositoryAPI: triggerDeleteCLIRepositoryAPI,
+
 	// Metadata APIs
 	core.SetConfigMetadataSettingAPI:        triggerSetConfigMetadataSettingAPI,
 	core.SetConfigMetadataPatchStrategyAPI:  triggerSetConfigMetadataPatchStrategyAPI,
@@ -0,0 +1,98 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	configlib "github.com/vmware-tanzu/tanzu-plugin-runtime/config"
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/test/compatibility/core"
+)
+
+// triggerGetCLIRepositoryAPI trigger get cli repository runtime api
+func triggerGetCLIRepositoryAPI(api *core.API) *core.APIResponse {
+	// Parse arguments needed to trigger the runtime api
+	name, err := core.ParseStr(api.Arguments[core.Name])
+	if err != nil {
+		return &core.APIResponse{
+			ResponseType: core.ErrorResponse,
+			ResponseBody: fmt.Errorf("failed to parse string from argument %v with error %v ", core.Name, err.Error()),
+		}
+	}
+	return getCLIRepository(name)
+}
+
+// triggerSetCLIRepositoryAPI trigger set cli repository runtime api
+func triggerSetCLIRepositoryAPI(api *core.API) *core.APIResponse {
+	// Parse arguments needed to trigger the runtime api
+	repository, err := parseCLIRepository(api.Arguments[core.Repository].(string))
+	if err != nil {
+		return &core.APIResponse{
+			ResponseType: core.ErrorResponse,
+			ResponseBody: fmt.Errorf("failed to parse repository from argument %v with error %v ", core.Repository, err.Error()),
+		}
+	}
+	return setCLIRepository(repository)
+}
+
+// triggerDeleteCLIRepositoryAPI trigger delete cli repository runtime api
+func triggerDeleteCLIRepositoryAPI(api *core.API) *core.APIResponse {
+	// Parse arguments needed to trigger the runtime api
+	name, err := core.ParseStr(api.Arguments[core.Name])
+	if err != nil {
+		return &core.APIResponse{
+			ResponseType: core.ErrorResponse,
+			ResponseBody: fmt.Errorf("failed to parse string from argument %v with error %v ", core.Name, err.Error()),
+		}
+	}
+	return deleteCLIRepository(name)
+}
+
+func getCLIRepository(name string) *core.APIResponse {
+	// Call runtime GetCLIRepository API
+	repository, err := configlib.GetCLIRepository(name) //nolint:staticcheck // Deprecated
+	if err != nil {
+		return &core.APIResponse{
+			ResponseType: core.ErrorResponse,
+			ResponseBody: err.Error(),
+		}
+	}
+	return &core.APIResponse{
+		ResponseType: core.MapResponse,
+		ResponseBody: repository,
+	}
+}
+
+func setCLIRepository(repository *configtypes.PluginRepository) *core.APIResponse {
+	// Call runtime SetCLIRepository API
+	err := configlib.SetCLIRepository(*repository) //nolint:staticcheck // Deprecated
+	if err != nil {
+		return &core.APIResponse{
+			ResponseType: core.ErrorResponse,
+			ResponseBody: errors.Wrap(err, "failed").Error(),
+		}
+	}
+	return &core.APIResponse{
+		ResponseBody: "",
+		ResponseType: core.StringResponse,
+	}
+}
+
+func deleteCLIRepository(name string) *core.APIResponse {
+	// Call runtime DeleteCLIRepository API
+	err := configlib.DeleteCLIRepository(name) //nolint:staticcheck // Deprecated
+	if err != nil {
+		return &core.APIResponse{
+			ResponseType: core.ErrorResponse,
+			ResponseBody: err.Error(),
+		}
+	}
+	return &core.APIResponse{
+		ResponseBody: "",
+		ResponseType: core.StringResponse,
+	}
+}
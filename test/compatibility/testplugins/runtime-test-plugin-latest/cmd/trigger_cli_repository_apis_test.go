@@ -0,0 +1,164 @@
+// Copyright 2024 VMware, Inc. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	configtypes "github.com/vmware-tanzu/tanzu-plugin-runtime/config/types"
+	"github.com/vmware-tanzu/tanzu-plugin-runtime/test/compatibility/core"
+)
+
+const repositoryOne string = "compatibility-tests-repository-one"
+
+func TestTriggerCLIRepositoryAPIs(t *testing.T) {
+	_, cleanup := core.SetupTempCfgFiles()
+	defer func() {
+		cleanup()
+	}()
+	ociRepository := `
+ociPluginRepository:
+    name: compatibility-tests-repository-one
+    image: example.com/tanzu/plugins:latest
+`
+	httpRepository := `
+httpPluginRepository:
+    name: compatibility-tests-repository-one
+    url: https://example.com/plugins
+`
+	var tests = []struct {
+		name         string
+		apiName      core.RuntimeAPIName
+		apis         []core.API
+		expectedLogs map[core.RuntimeAPIName][]core.APILog
+	}{
+		{
+			name:    "Trigger GetCLIRepositoryAPI",
+			apiName: core.GetCLIRepositoryAPI,
+			apis: []core.API{
+				{
+					Name:    core.SetCLIRepositoryAPI,
+					Version: core.VersionLatest,
+					Arguments: map[core.APIArgumentType]interface{}{
+						core.Repository: ociRepository,
+					},
+					Output: &core.Output{
+						Result:  "success",
+						Content: "",
+					},
+				},
+				{
+					Name:    core.GetCLIRepositoryAPI,
+					Version: core.VersionLatest,
+					Arguments: map[core.APIArgumentType]interface{}{
+						core.Name: repositoryOne,
+					},
+					Output: &core.Output{
+						Result:  "success",
+						Content: ociRepository,
+					},
+				},
+			},
+
+			expectedLogs: map[core.RuntimeAPIName][]core.APILog{
+				core.GetCLIRepositoryAPI: {
+					{
+						APIResponse: &core.APIResponse{
+							ResponseBody: &configtypes.PluginRepository{
+								OCIPluginRepository: &configtypes.OCIPluginRepository{
+									Name:  repositoryOne,
+									Image: "example.com/tanzu/plugins:latest",
+								},
+							},
+							ResponseType: core.MapResponse,
+						},
+					},
+				},
+			},
+		},
+
+		{
+			name:    "Trigger GetCLIRepositoryAPI after replacing the repository type",
+			apiName: core.GetCLIRepositoryAPI,
+			apis: []core.API{
+				{
+					Name:    core.SetCLIRepositoryAPI,
+					Version: core.VersionLatest,
+					Arguments: map[core.APIArgumentType]interface{}{
+						core.Repository: httpRepository,
+					},
+					Output: &core.Output{
+						Result:  "success",
+						Content: "",
+					},
+				},
+				{
+					Name:    core.GetCLIRepositoryAPI,
+					Version: core.VersionLatest,
+					Arguments: map[core.APIArgumentType]interface{}{
+						core.Name: repositoryOne,
+					},
+					Output: &core.Output{
+						Result:  "success",
+						Content: httpRepository,
+					},
+				},
+			},
+
+			expectedLogs: map[core.RuntimeAPIName][]core.APILog{
+				core.GetCLIRepositoryAPI: {
+					{
+						APIResponse: &core.APIResponse{
+							ResponseBody: &configtypes.PluginRepository{
+								HTTPPluginRepository: &configtypes.HTTPPluginRepository{
+									Name: repositoryOne,
+									URL:  "https://example.com/plugins",
+								},
+							},
+							ResponseType: core.MapResponse,
+						},
+					},
+				},
+			},
+		},
+
+		{
+			name:    "Trigger DeleteCLIRepositoryAPI",
+			apiName: core.DeleteCLIRepositoryAPI,
+			apis: []core.API{
+				{
+					Name:    core.DeleteCLIRepositoryAPI,
+					Version: core.VersionLatest,
+					Arguments: map[core.APIArgumentType]interface{}{
+						core.Name: repositoryOne,
+					},
+					Output: &core.Output{
+						Result:  "success",
+						Content: "",
+					},
+				},
+			},
+
+			expectedLogs: map[core.RuntimeAPIName][]core.APILog{
+				core.DeleteCLIRepositoryAPI: {
+					{
+						APIResponse: &core.APIResponse{
+							ResponseBody: "",
+							ResponseType: core.StringResponse,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actualLogs := triggerAPIs(tt.apis)
+			assert.Equal(t, tt.expectedLogs[tt.apiName], actualLogs[tt.apiName])
+		})
+	}
+}